@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/storage/mysql"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/joeshaw/envdecode"
+	"github.com/sirupsen/logrus"
+	"os"
+	"strconv"
+)
+
+type config struct {
+	Logger log.Config
+	Db     mysql.Config
+}
+
+const usage = `Usage: migrate <command> [arg]
+
+Commands:
+  up          Apply all up migrations
+  down N      Apply N down migrations
+  goto V      Migrate to version V
+  version     Print current migration version
+  force V     Set version V without running migrations (after a failed migration)
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var cfg config
+	if err := envdecode.StrictDecode(&cfg); err != nil {
+		logrus.WithError(err).Fatal("Cannot decode config envs")
+	}
+	logger := log.New(cfg.Logger)
+
+	m, err := mysql.NewMigrate(cfg.Db, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Cannot create migrations")
+	}
+	defer m.Close()
+
+	if err = run(m, os.Args[1], os.Args[2:]); err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			logger.Info("No change")
+			return
+		}
+		logger.WithError(err).Fatal("Migration failed")
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		logger.WithError(err).Fatal("Cannot get version")
+	}
+	logger.Infof("Version: %d, dirty: %t", version, dirty)
+}
+
+func run(m *migrate.Migrate, command string, args []string) error {
+	switch command {
+	case "up":
+		return m.Up()
+	case "down":
+		n, err := intArg(args)
+		if err != nil {
+			return err
+		}
+		if n <= 0 {
+			return fmt.Errorf("number of down migrations must be positive")
+		}
+		return m.Steps(-n)
+	case "goto":
+		v, err := intArg(args)
+		if err != nil {
+			return err
+		}
+		return m.Migrate(uint(v))
+	case "version":
+		return nil
+	case "force":
+		v, err := intArg(args)
+		if err != nil {
+			return err
+		}
+		return m.Force(v)
+	}
+	return fmt.Errorf("unknown command %q\n%s", command, usage)
+}
+
+func intArg(args []string) (int, error) {
+	if len(args) < 1 {
+		return 0, fmt.Errorf("argument is required\n%s", usage)
+	}
+	v, err := strconv.Atoi(args[0])
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("argument must be a non-negative number: %s", args[0])
+	}
+	return v, nil
+}
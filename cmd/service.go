@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/basicus/hla-course/log"
 	"github.com/basicus/hla-course/service"
 	client_auth "github.com/basicus/hla-course/service/client-auth"
@@ -16,8 +17,11 @@ import (
 	"github.com/basicus/hla-course/service/queue"
 	"github.com/basicus/hla-course/service/rest"
 	rest_chats "github.com/basicus/hla-course/service/rest-chats"
+	"github.com/basicus/hla-course/service/tasks"
 	wspusher "github.com/basicus/hla-course/service/wsclients"
+	"github.com/basicus/hla-course/storage/cache"
 	"github.com/basicus/hla-course/storage/mysql"
+	"github.com/basicus/hla-course/storage/tarantool"
 	"github.com/joeshaw/envdecode"
 	"github.com/oklog/run"
 	"github.com/sirupsen/logrus"
@@ -31,6 +35,8 @@ type config struct {
 	Mon              monitoring.Config
 	Logger           log.Config
 	Db               mysql.Config
+	Tarantool        tarantool.Config
+	Cache            cache.Config
 	Queue            queue.Config
 	Ws               wspusher.Config
 	EvConsumerConfig eventconsumer.Config
@@ -42,6 +48,7 @@ type config struct {
 	GrpcChats        grpc_chats.Config
 	RestChats        rest_chats.Config
 	GrpcCounter      grpc_counter.Config
+	Tasks            tasks.Config
 }
 
 func main() {
@@ -72,6 +79,18 @@ func main() {
 	if err != nil {
 		logger.WithError(err).Fatal("Cannot access to database")
 	}
+	if cfg.Tarantool.Enable {
+		dbc, err = tarantool.New(cfg.Tarantool, dbc, logger)
+		if err != nil {
+			logger.WithError(err).Fatal("Cannot access to tarantool")
+		}
+	}
+	if cfg.Cache.Enable {
+		dbc, err = cache.New(cfg.Cache, dbc, logger)
+		if err != nil {
+			logger.WithError(err).Fatal("Cannot create storage cache")
+		}
+	}
 
 	// Database storage-chats
 	dbcChats, err := mysql.NewChats(cfg.Db, logger)
@@ -126,7 +145,7 @@ func main() {
 	clientCounter.Run(ctx)
 
 	// GRPC chats server
-	grpcChats, err := grpc_chats.New(cfg.GrpcChats, &dbcChats, clientAuth, logger)
+	grpcChats, err := grpc_chats.New(cfg.GrpcChats, &dbcChats, clientAuth, logger, evProducer.PublishEvent)
 	err = service.Setup(ctx, grpcChats, "grpc chats server", g)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed run grpc chats server service")
@@ -141,7 +160,7 @@ func main() {
 		}*/
 
 	// Queue service
-	queueSrv, err := queue.New(cfg.Queue, &dbc, logger, evProducer.PublishEvent)
+	queueSrv, err := queue.New(cfg.Queue, &dbc, logger, evProducer.PublishEvent, evProducer.PublishEventBatch)
 	if err != nil {
 		logger.WithError(err).Fatal("Cannot create queue service")
 	}
@@ -150,6 +169,36 @@ func main() {
 		logger.WithError(err).Fatal("Failed run queue service")
 	}
 
+	// Scheduler of periodic and delayed jobs
+	scheduler, err := tasks.New(cfg.Tasks, queueSrv.GetRedisClient(), logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Cannot create scheduler service")
+	}
+	if err = scheduler.Schedule("queue-clean", fmt.Sprintf("@every %s", cfg.Queue.CleanPeriod), queueSrv.CleanQueues); err != nil {
+		logger.WithError(err).Fatal("Cannot schedule queue cleaning")
+	}
+	if err = scheduler.Schedule("feed-cache-expiry", cfg.Tasks.FeedCacheExpirySchedule, queueSrv.ExpireFeedCaches); err != nil {
+		logger.WithError(err).Fatal("Cannot schedule feed cache expiry")
+	}
+	err = scheduler.Schedule("counters-reconcile", cfg.Tasks.CountersReconcileSchedule, func(ctx context.Context) error {
+		fixed, err := dbc.ReconcileCounters(ctx)
+		if err != nil {
+			return err
+		}
+		log.Ctx(ctx).Infof("reconciled %d counters", fixed)
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Fatal("Cannot schedule counters reconciliation")
+	}
+	if err = scheduler.Schedule("scheduled-posts", cfg.Tasks.ScheduledPostsSchedule, queueSrv.PublishScheduledPosts); err != nil {
+		logger.WithError(err).Fatal("Cannot schedule publishing of scheduled posts")
+	}
+	err = service.Setup(ctx, scheduler, "scheduler", g)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed run scheduler service")
+	}
+
 	// Websocket and message/post queue service
 	wsSrv, err := wspusher.New(cfg.Ws, &dbc, logger)
 	if err != nil {
@@ -183,7 +232,7 @@ func main() {
 	}
 
 	// REST Service chats
-	restServiceChats, err := rest_chats.New(cfg.RestChats, logger, mon, &dbcChats, clientAuth.Client, clientCounter.Client)
+	restServiceChats, err := rest_chats.New(cfg.RestChats, logger, mon, &dbcChats, clientAuth.Client, clientCounter.Client, evProducer.PublishEvent)
 
 	if err != nil {
 		logger.WithError(err).Fatal("Cannot create rest chat service")
@@ -198,6 +247,13 @@ func main() {
 	if err := g.Run(); err != nil {
 		logger.WithError(err).Fatal("The service has been stopped with error")
 	}
+	// Все сервисы остановлены, хранилища больше не используются
+	if err := dbc.Close(); err != nil {
+		logger.WithError(err).Error("Cannot close storage")
+	}
+	if err := dbcChats.Close(); err != nil {
+		logger.WithError(err).Error("Cannot close storage-chats")
+	}
 	logger.Info("The service is stopped")
 
 }
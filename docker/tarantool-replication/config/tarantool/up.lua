@@ -8,7 +8,7 @@ box.space.binlog:create_index('primary', {
 
 
 
-box.schema.space.create('users',{id=513, if_not_exists = true, field_count = 7})
+box.schema.space.create('users',{id=513, if_not_exists = true, field_count = 16})
 box.space.users:create_index('primary', {
 	type = 'HASH',
 	if_not_exists = true,
@@ -24,3 +24,16 @@ box.space.users:create_index('email', {
 	if_not_exists = true,
 	parts = {3, 'string'}
 });
+-- Поиск по префиксу имени и фамилии (без учета регистра, как в MySQL)
+box.space.users:create_index('name', {
+	type = 'TREE',
+	unique = false,
+	if_not_exists = true,
+	parts = {{6, 'string', collation = 'unicode_ci'}, {7, 'string', collation = 'unicode_ci'}, {1, 'unsigned'}}
+});
+box.space.users:create_index('surname', {
+	type = 'TREE',
+	unique = false,
+	if_not_exists = true,
+	parts = {{7, 'string', collation = 'unicode_ci'}, {1, 'unsigned'}}
+});
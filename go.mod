@@ -3,7 +3,9 @@ module github.com/basicus/hla-course
 go 1.16
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/adjust/rmq/v4 v4.0.5
+	github.com/alicebob/miniredis/v2 v2.30.0
 	github.com/ansrivas/fiberprometheus/v2 v2.2.0
 	github.com/brianvoe/gofakeit/v6 v6.17.0
 	github.com/go-redis/redis/v8 v8.11.5
@@ -23,7 +25,7 @@ require (
 	github.com/prometheus/client_golang v1.12.1
 	github.com/rabbitmq/amqp091-go v1.4.0
 	github.com/sirupsen/logrus v1.8.1
-	github.com/stretchr/testify v1.7.1 // indirect
+	github.com/tarantool/go-tarantool v1.12.2
 	golang.org/x/crypto v0.0.0-20220214200702-86341886e292
 	google.golang.org/grpc v1.45.0
 	google.golang.org/protobuf v1.27.1
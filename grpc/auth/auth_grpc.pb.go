@@ -21,6 +21,8 @@ type AuthServiceClient interface {
 	CheckSession(ctx context.Context, in *CheckSessionRequest, opts ...grpc.CallOption) (*CheckSessionResponse, error)
 	UserName(ctx context.Context, in *UserNameRequest, opts ...grpc.CallOption) (*UserNameResponse, error)
 	UserShard(ctx context.Context, in *UserShardRequest, opts ...grpc.CallOption) (*UserShardResponse, error)
+	UserNames(ctx context.Context, in *UserNamesRequest, opts ...grpc.CallOption) (*UserNamesResponse, error)
+	UserShards(ctx context.Context, in *UserShardsRequest, opts ...grpc.CallOption) (*UserShardsResponse, error)
 }
 
 type authServiceClient struct {
@@ -58,6 +60,24 @@ func (c *authServiceClient) UserShard(ctx context.Context, in *UserShardRequest,
 	return out, nil
 }
 
+func (c *authServiceClient) UserNames(ctx context.Context, in *UserNamesRequest, opts ...grpc.CallOption) (*UserNamesResponse, error) {
+	out := new(UserNamesResponse)
+	err := c.cc.Invoke(ctx, "/auth_api.AuthService/UserNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) UserShards(ctx context.Context, in *UserShardsRequest, opts ...grpc.CallOption) (*UserShardsResponse, error) {
+	out := new(UserShardsResponse)
+	err := c.cc.Invoke(ctx, "/auth_api.AuthService/UserShards", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility
@@ -65,6 +85,8 @@ type AuthServiceServer interface {
 	CheckSession(context.Context, *CheckSessionRequest) (*CheckSessionResponse, error)
 	UserName(context.Context, *UserNameRequest) (*UserNameResponse, error)
 	UserShard(context.Context, *UserShardRequest) (*UserShardResponse, error)
+	UserNames(context.Context, *UserNamesRequest) (*UserNamesResponse, error)
+	UserShards(context.Context, *UserShardsRequest) (*UserShardsResponse, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -81,6 +103,12 @@ func (UnimplementedAuthServiceServer) UserName(context.Context, *UserNameRequest
 func (UnimplementedAuthServiceServer) UserShard(context.Context, *UserShardRequest) (*UserShardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserShard not implemented")
 }
+func (UnimplementedAuthServiceServer) UserNames(context.Context, *UserNamesRequest) (*UserNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserNames not implemented")
+}
+func (UnimplementedAuthServiceServer) UserShards(context.Context, *UserShardsRequest) (*UserShardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserShards not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 
 // UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -148,6 +176,42 @@ func _AuthService_UserShard_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_UserNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UserNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_api.AuthService/UserNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UserNames(ctx, req.(*UserNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UserShards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserShardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UserShards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/auth_api.AuthService/UserShards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UserShards(ctx, req.(*UserShardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -167,6 +231,14 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UserShard",
 			Handler:    _AuthService_UserShard_Handler,
 		},
+		{
+			MethodName: "UserNames",
+			Handler:    _AuthService_UserNames_Handler,
+		},
+		{
+			MethodName: "UserShards",
+			Handler:    _AuthService_UserShards_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
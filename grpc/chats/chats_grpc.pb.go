@@ -23,6 +23,18 @@ type ChatServiceClient interface {
 	CreateChat(ctx context.Context, in *CreateChatRequest, opts ...grpc.CallOption) (*CreateChatResponse, error)
 	PostMessage(ctx context.Context, in *PostMessageRequest, opts ...grpc.CallOption) (*PostMessageResponse, error)
 	Get(ctx context.Context, in *GetChatRequest, opts ...grpc.CallOption) (*GetChatResponse, error)
+	ReactMessage(ctx context.Context, in *ReactMessageRequest, opts ...grpc.CallOption) (*ReactMessageResponse, error)
+	UnreactMessage(ctx context.Context, in *ReactMessageRequest, opts ...grpc.CallOption) (*ReactMessageResponse, error)
+	AddParticipants(ctx context.Context, in *ChatParticipantsRequest, opts ...grpc.CallOption) (*ChatParticipantsResponse, error)
+	RemoveParticipants(ctx context.Context, in *ChatParticipantsRequest, opts ...grpc.CallOption) (*ChatParticipantsResponse, error)
+	LeaveChat(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatActionResponse, error)
+	RenameChat(ctx context.Context, in *RenameChatRequest, opts ...grpc.CallOption) (*ChatActionResponse, error)
+	CloseChat(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatActionResponse, error)
+	DeleteChat(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatActionResponse, error)
+	ListMembers(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatMembersResponse, error)
+	SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*ChatMembersResponse, error)
+	UpdateChatSettings(ctx context.Context, in *UpdateChatSettingsRequest, opts ...grpc.CallOption) (*ChatActionResponse, error)
+	GetDialog(ctx context.Context, in *GetDialogRequest, opts ...grpc.CallOption) (*GetChatResponse, error)
 }
 
 type chatServiceClient struct {
@@ -78,6 +90,114 @@ func (c *chatServiceClient) Get(ctx context.Context, in *GetChatRequest, opts ..
 	return out, nil
 }
 
+func (c *chatServiceClient) ReactMessage(ctx context.Context, in *ReactMessageRequest, opts ...grpc.CallOption) (*ReactMessageResponse, error) {
+	out := new(ReactMessageResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/ReactMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UnreactMessage(ctx context.Context, in *ReactMessageRequest, opts ...grpc.CallOption) (*ReactMessageResponse, error) {
+	out := new(ReactMessageResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/UnreactMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) AddParticipants(ctx context.Context, in *ChatParticipantsRequest, opts ...grpc.CallOption) (*ChatParticipantsResponse, error) {
+	out := new(ChatParticipantsResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/AddParticipants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RemoveParticipants(ctx context.Context, in *ChatParticipantsRequest, opts ...grpc.CallOption) (*ChatParticipantsResponse, error) {
+	out := new(ChatParticipantsResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/RemoveParticipants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) LeaveChat(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatActionResponse, error) {
+	out := new(ChatActionResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/LeaveChat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) RenameChat(ctx context.Context, in *RenameChatRequest, opts ...grpc.CallOption) (*ChatActionResponse, error) {
+	out := new(ChatActionResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/RenameChat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) CloseChat(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatActionResponse, error) {
+	out := new(ChatActionResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/CloseChat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) DeleteChat(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatActionResponse, error) {
+	out := new(ChatActionResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/DeleteChat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) ListMembers(ctx context.Context, in *ChatActionRequest, opts ...grpc.CallOption) (*ChatMembersResponse, error) {
+	out := new(ChatMembersResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/ListMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*ChatMembersResponse, error) {
+	out := new(ChatMembersResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/SetMemberRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) UpdateChatSettings(ctx context.Context, in *UpdateChatSettingsRequest, opts ...grpc.CallOption) (*ChatActionResponse, error) {
+	out := new(ChatActionResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/UpdateChatSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) GetDialog(ctx context.Context, in *GetDialogRequest, opts ...grpc.CallOption) (*GetChatResponse, error) {
+	out := new(GetChatResponse)
+	err := c.cc.Invoke(ctx, "/chat_api.ChatService/GetDialog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility
@@ -87,6 +207,18 @@ type ChatServiceServer interface {
 	CreateChat(context.Context, *CreateChatRequest) (*CreateChatResponse, error)
 	PostMessage(context.Context, *PostMessageRequest) (*PostMessageResponse, error)
 	Get(context.Context, *GetChatRequest) (*GetChatResponse, error)
+	ReactMessage(context.Context, *ReactMessageRequest) (*ReactMessageResponse, error)
+	UnreactMessage(context.Context, *ReactMessageRequest) (*ReactMessageResponse, error)
+	AddParticipants(context.Context, *ChatParticipantsRequest) (*ChatParticipantsResponse, error)
+	RemoveParticipants(context.Context, *ChatParticipantsRequest) (*ChatParticipantsResponse, error)
+	LeaveChat(context.Context, *ChatActionRequest) (*ChatActionResponse, error)
+	RenameChat(context.Context, *RenameChatRequest) (*ChatActionResponse, error)
+	CloseChat(context.Context, *ChatActionRequest) (*ChatActionResponse, error)
+	DeleteChat(context.Context, *ChatActionRequest) (*ChatActionResponse, error)
+	ListMembers(context.Context, *ChatActionRequest) (*ChatMembersResponse, error)
+	SetMemberRole(context.Context, *SetMemberRoleRequest) (*ChatMembersResponse, error)
+	UpdateChatSettings(context.Context, *UpdateChatSettingsRequest) (*ChatActionResponse, error)
+	GetDialog(context.Context, *GetDialogRequest) (*GetChatResponse, error)
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -109,6 +241,42 @@ func (UnimplementedChatServiceServer) PostMessage(context.Context, *PostMessageR
 func (UnimplementedChatServiceServer) Get(context.Context, *GetChatRequest) (*GetChatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedChatServiceServer) ReactMessage(context.Context, *ReactMessageRequest) (*ReactMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactMessage not implemented")
+}
+func (UnimplementedChatServiceServer) UnreactMessage(context.Context, *ReactMessageRequest) (*ReactMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnreactMessage not implemented")
+}
+func (UnimplementedChatServiceServer) AddParticipants(context.Context, *ChatParticipantsRequest) (*ChatParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddParticipants not implemented")
+}
+func (UnimplementedChatServiceServer) RemoveParticipants(context.Context, *ChatParticipantsRequest) (*ChatParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveParticipants not implemented")
+}
+func (UnimplementedChatServiceServer) LeaveChat(context.Context, *ChatActionRequest) (*ChatActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveChat not implemented")
+}
+func (UnimplementedChatServiceServer) RenameChat(context.Context, *RenameChatRequest) (*ChatActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameChat not implemented")
+}
+func (UnimplementedChatServiceServer) CloseChat(context.Context, *ChatActionRequest) (*ChatActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseChat not implemented")
+}
+func (UnimplementedChatServiceServer) DeleteChat(context.Context, *ChatActionRequest) (*ChatActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteChat not implemented")
+}
+func (UnimplementedChatServiceServer) ListMembers(context.Context, *ChatActionRequest) (*ChatMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedChatServiceServer) SetMemberRole(context.Context, *SetMemberRoleRequest) (*ChatMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemberRole not implemented")
+}
+func (UnimplementedChatServiceServer) UpdateChatSettings(context.Context, *UpdateChatSettingsRequest) (*ChatActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateChatSettings not implemented")
+}
+func (UnimplementedChatServiceServer) GetDialog(context.Context, *GetDialogRequest) (*GetChatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDialog not implemented")
+}
 func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
 
 // UnsafeChatServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -212,6 +380,222 @@ func _ChatService_Get_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatService_ReactMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ReactMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/ReactMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ReactMessage(ctx, req.(*ReactMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UnreactMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UnreactMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/UnreactMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UnreactMessage(ctx, req.(*ReactMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_AddParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).AddParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/AddParticipants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).AddParticipants(ctx, req.(*ChatParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RemoveParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RemoveParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/RemoveParticipants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RemoveParticipants(ctx, req.(*ChatParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_LeaveChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).LeaveChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/LeaveChat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).LeaveChat(ctx, req.(*ChatActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_RenameChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameChatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).RenameChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/RenameChat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).RenameChat(ctx, req.(*RenameChatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_CloseChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).CloseChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/CloseChat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).CloseChat(ctx, req.(*ChatActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_DeleteChat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).DeleteChat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/DeleteChat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).DeleteChat(ctx, req.(*ChatActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChatActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/ListMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).ListMembers(ctx, req.(*ChatActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_SetMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).SetMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/SetMemberRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).SetMemberRole(ctx, req.(*SetMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_UpdateChatSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChatSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).UpdateChatSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/UpdateChatSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).UpdateChatSettings(ctx, req.(*UpdateChatSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_GetDialog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDialogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetDialog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat_api.ChatService/GetDialog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetDialog(ctx, req.(*GetDialogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +623,54 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _ChatService_Get_Handler,
 		},
+		{
+			MethodName: "ReactMessage",
+			Handler:    _ChatService_ReactMessage_Handler,
+		},
+		{
+			MethodName: "UnreactMessage",
+			Handler:    _ChatService_UnreactMessage_Handler,
+		},
+		{
+			MethodName: "AddParticipants",
+			Handler:    _ChatService_AddParticipants_Handler,
+		},
+		{
+			MethodName: "RemoveParticipants",
+			Handler:    _ChatService_RemoveParticipants_Handler,
+		},
+		{
+			MethodName: "LeaveChat",
+			Handler:    _ChatService_LeaveChat_Handler,
+		},
+		{
+			MethodName: "RenameChat",
+			Handler:    _ChatService_RenameChat_Handler,
+		},
+		{
+			MethodName: "CloseChat",
+			Handler:    _ChatService_CloseChat_Handler,
+		},
+		{
+			MethodName: "DeleteChat",
+			Handler:    _ChatService_DeleteChat_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _ChatService_ListMembers_Handler,
+		},
+		{
+			MethodName: "SetMemberRole",
+			Handler:    _ChatService_SetMemberRole_Handler,
+		},
+		{
+			MethodName: "UpdateChatSettings",
+			Handler:    _ChatService_UpdateChatSettings_Handler,
+		},
+		{
+			MethodName: "GetDialog",
+			Handler:    _ChatService_GetDialog_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chats.proto",
@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists user_friend;
+drop table if exists users;
+
+commit;
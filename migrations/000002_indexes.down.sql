@@ -0,0 +1,9 @@
+begin;
+
+ALTER TABLE users DROP INDEX users_name_idx;
+ALTER TABLE user_friend DROP INDEX user_friends_uid_idx;
+ALTER TABLE user_friend DROP INDEX user_friends_fid_idx;
+alter table users DROP INDEX users_login_idx;
+alter table users DROP INDEX users_country_idx;
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+ALTER TABLE users DROP INDEX users_name_idx;
+ALTER TABLE user_friend DROP INDEX user_friends_uid_idx;
+ALTER TABLE user_friend DROP INDEX user_friends_fid_idx;
+alter table users DROP INDEX users_login_idx;
+alter table users DROP INDEX users_country_idx;
+ALTER TABLE users ADD INDEX users_name_idx (name, surname);
+ALTER TABLE user_friend ADD INDEX user_friends_uid_idx (user_id);
+ALTER TABLE user_friend ADD INDEX user_friends_fid_idx (friend_id);
+ALTER TABLE users ADD INDEX users_login_idx (login);
+ALTER TABLE users ADD INDEX users_country_idx (country);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- Сопоставление таблиц не возвращается: прежнее значение зависело от настроек сервера,
+-- а utf8mb4_unicode_ci используется всеми последующими миграциями
+
+commit;
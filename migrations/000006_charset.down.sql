@@ -0,0 +1,7 @@
+begin;
+
+-- Кодировка базы и таблиц не возвращается: обратная конвертация из utf8mb4 может потерять данные
+alter table posts modify title varchar(200) not null;
+alter table posts modify message varchar(2048) null;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table posts modify updated_at DATETIME ON UPDATE CURRENT_TIMESTAMP;
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+alter table posts
+    modify deleted bool null;
+
+commit;
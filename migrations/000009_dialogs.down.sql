@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists messages;
+drop table if exists chat_participants;
+drop table if exists chats;
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+alter table posts
+    drop column comments_count;
+drop table if exists post_comments;
+
+commit;
@@ -0,0 +1,19 @@
+begin;
+
+create table if not exists post_comments
+(
+    id         bigint primary key AUTO_INCREMENT auto_increment,
+    post_id    bigint                             not null,
+    user_id    bigint                             not null,
+    message    varchar(2048)                      not null collate utf8mb4_unicode_ci,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP not null,
+    deleted    bool     default false             not null
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+alter table post_comments
+    add index post_comments_post_idx (post_id, created_at) using btree;
+
+alter table posts
+    add column comments_count bigint default 0 not null;
+
+commit;
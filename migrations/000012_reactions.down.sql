@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists reaction_counts;
+drop table if exists reactions;
+
+commit;
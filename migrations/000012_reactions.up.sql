@@ -0,0 +1,22 @@
+begin;
+
+create table if not exists reactions
+(
+    item_type  enum ('post','message')            not null,
+    item_id    bigint                             not null,
+    user_id    bigint                             not null,
+    reaction   varchar(16)                        not null,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP not null,
+    primary key (item_type, item_id, user_id)
+);
+
+create table if not exists reaction_counts
+(
+    item_type enum ('post','message') not null,
+    item_id   bigint                  not null,
+    reaction  varchar(16)             not null,
+    count     bigint default 0        not null,
+    primary key (item_type, item_id, reaction)
+);
+
+commit;
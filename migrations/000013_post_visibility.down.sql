@@ -0,0 +1,6 @@
+begin;
+
+alter table posts
+    drop column visibility;
+
+commit;
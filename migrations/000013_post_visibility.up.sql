@@ -0,0 +1,6 @@
+begin;
+
+alter table posts
+    add column visibility enum ('public','friends','private') default 'public' not null;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+drop table if exists post_drafts;
+
+commit;
@@ -0,0 +1,22 @@
+begin;
+
+create table if not exists post_drafts
+(
+    id         bigint primary key AUTO_INCREMENT auto_increment,
+    user_id    bigint                                                            not null,
+    title      varchar(200)                                                      not null collate utf8mb4_unicode_ci,
+    message    varchar(2048)                                                     not null collate utf8mb4_unicode_ci,
+    visibility enum ('public','friends','private') default 'public'              not null,
+    status     enum ('draft','scheduled','published','cancelled') default 'draft' not null,
+    publish_at DATETIME                                                          null,
+    post_id    bigint                                                            null,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP                                not null,
+    updated_at timestamp                                                         null on update CURRENT_TIMESTAMP
+) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+alter table post_drafts
+    add index post_drafts_user_idx (user_id, status, created_at) using btree;
+alter table post_drafts
+    add index post_drafts_due_idx (status, publish_at) using btree;
+
+commit;
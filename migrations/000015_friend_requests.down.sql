@@ -0,0 +1,8 @@
+begin;
+
+-- Удаленные дубликаты подписок не восстанавливаются
+drop table if exists friend_requests;
+alter table user_friend
+    drop index user_friend_pair_uq;
+
+commit;
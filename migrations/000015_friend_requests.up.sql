@@ -0,0 +1,32 @@
+begin;
+
+-- Удаляем дубликаты подписок: DDL в MySQL фиксирует транзакцию неявно, поэтому исходная таблица не очищается.
+-- Уникальные подписки копируются в новую таблицу с уникальным индексом, затем таблицы атомарно меняются местами
+drop table if exists user_friend_dedup;
+create table user_friend_dedup like user_friend;
+alter table user_friend_dedup
+    add unique index user_friend_pair_uq (user_id, friend_id);
+insert ignore into user_friend_dedup (user_id, friend_id)
+select user_id, friend_id
+from user_friend
+where user_id is not null
+  and friend_id is not null;
+rename table user_friend to user_friend_old, user_friend_dedup to user_friend;
+drop table user_friend_old;
+
+create table if not exists friend_requests
+(
+    id           bigint primary key AUTO_INCREMENT auto_increment,
+    from_user_id bigint                                                           not null,
+    to_user_id   bigint                                                           not null,
+    status       enum ('pending','accepted','declined','cancelled') default 'pending' not null,
+    created_at   DATETIME DEFAULT CURRENT_TIMESTAMP                               not null,
+    updated_at   timestamp                                                        null on update CURRENT_TIMESTAMP
+);
+
+alter table friend_requests
+    add unique index friend_requests_pair_uq (from_user_id, to_user_id);
+alter table friend_requests
+    add index friend_requests_to_idx (to_user_id, status, created_at) using btree;
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+create table if not exists user_blocks
+(
+    user_id    bigint                             not null,
+    target_id  bigint                             not null,
+    kind       enum ('block','mute')              not null,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP not null,
+    primary key (user_id, target_id)
+);
+
+alter table user_blocks
+    add index user_blocks_target_idx (target_id, kind) using btree;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+alter table users
+    drop column followers_count,
+    drop column following_count,
+    drop column friends_count;
+
+commit;
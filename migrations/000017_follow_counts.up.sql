@@ -0,0 +1,17 @@
+begin;
+
+alter table users
+    add column followers_count bigint default 0 not null,
+    add column following_count bigint default 0 not null,
+    add column friends_count   bigint default 0 not null;
+
+-- Заполняем счетчики по существующим подпискам
+update users u
+set u.following_count = (select count(*) from user_friend f where f.user_id = u.user_id),
+    u.followers_count = (select count(*) from user_friend f where f.friend_id = u.user_id),
+    u.friends_count   = (select count(*)
+                         from user_friend f
+                                  join user_friend r on r.user_id = f.friend_id and r.friend_id = f.user_id
+                         where f.user_id = u.user_id);
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+alter table users
+    drop index users_interests_ft;
+alter table users
+    drop index users_city_idx;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+alter table users
+    add fulltext index users_interests_ft (interests);
+alter table users
+    add index users_city_idx (city) using btree;
+
+commit;
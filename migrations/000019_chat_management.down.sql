@@ -0,0 +1,9 @@
+begin;
+
+alter table messages
+    drop column `system`;
+
+alter table chats
+    drop column created_by;
+
+commit;
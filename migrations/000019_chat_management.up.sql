@@ -0,0 +1,17 @@
+begin;
+
+alter table chats
+    add column created_by bigint default 0 not null;
+
+-- Автором существующих чатов считаем первого добавленного участника
+update chats c
+set c.created_by = coalesce((select p.user_id
+                             from chat_participants p
+                             where p.chat_id = c.id
+                             order by p.id
+                             limit 1), 0);
+
+alter table messages
+    add column `system` bool default false not null;
+
+commit;
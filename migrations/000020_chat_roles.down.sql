@@ -0,0 +1,11 @@
+begin;
+
+alter table chats
+    drop column invite_role,
+    drop column post_role,
+    drop column rename_role;
+
+alter table chat_participants
+    drop column role;
+
+commit;
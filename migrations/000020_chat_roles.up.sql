@@ -0,0 +1,15 @@
+begin;
+
+alter table chat_participants
+    add column role enum ('owner','admin','member') default 'member' not null;
+
+update chat_participants p
+    join chats c on c.id = p.chat_id and c.created_by = p.user_id
+set p.role = 'owner';
+
+alter table chats
+    add column invite_role enum ('owner','admin','member') default 'member' not null,
+    add column post_role   enum ('owner','admin','member') default 'member' not null,
+    add column rename_role enum ('owner','admin','member') default 'admin' not null;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+drop table if exists chat_dialogs;
+
+alter table chats
+    drop column dialog;
+
+commit;
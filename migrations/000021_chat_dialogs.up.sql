@@ -0,0 +1,35 @@
+begin;
+
+alter table chats
+    add column dialog bool default false not null;
+
+-- Диалог двух пользователей: пара хранится упорядоченной (user_low < user_high)
+create table if not exists chat_dialogs
+(
+    user_low  bigint not null,
+    user_high bigint not null,
+    chat_id   bigint not null,
+    primary key (user_low, user_high),
+    unique key chat_dialogs_chat_idx (chat_id)
+);
+
+-- Существующие чаты: диалогом считается самый ранний чат без названия, в котором за все время
+-- было ровно два участника (создан как переписка двух пользователей). Остальные чаты остаются групповыми
+insert into chat_dialogs (user_low, user_high, chat_id)
+select t.user_low, t.user_high, min(t.chat_id)
+from (select p.chat_id, min(p.user_id) as user_low, max(p.user_id) as user_high
+      from chat_participants p
+               join chats c on c.id = p.chat_id
+      where c.title = ''
+        and c.closed = false
+      group by p.chat_id
+      having count(*) = 2
+         and sum(p.status = 1) = 2
+         and min(p.user_id) <> max(p.user_id)) t
+group by t.user_low, t.user_high;
+
+update chats
+set dialog = true
+where id in (select chat_id from chat_dialogs);
+
+commit;
@@ -1,15 +1,47 @@
 // Code generated by go-bindata.
 // sources:
+// 000001_init.down.sql
 // 000001_init.up.sql
+// 000002_indexes.down.sql
 // 000002_indexes.up.sql
+// 000003_indexes.down.sql
 // 000003_indexes.up.sql
+// 000004_posts.down.sql
 // 000004_posts.up.sql
+// 000005_collate.down.sql
 // 000005_collate.up.sql
+// 000006_charset.down.sql
 // 000006_charset.up.sql
+// 000007_dtupdate.down.sql
 // 000007_dtupdate.up.sql
+// 000008_delupd.down.sql
 // 000008_delupd.up.sql
+// 000009_dialogs.down.sql
 // 000009_dialogs.up.sql
+// 000010_user_shard.down.sql
 // 000010_user_shard.up.sql
+// 000011_comments.down.sql
+// 000011_comments.up.sql
+// 000012_reactions.down.sql
+// 000012_reactions.up.sql
+// 000013_post_visibility.down.sql
+// 000013_post_visibility.up.sql
+// 000014_post_drafts.down.sql
+// 000014_post_drafts.up.sql
+// 000015_friend_requests.down.sql
+// 000015_friend_requests.up.sql
+// 000016_user_blocks.down.sql
+// 000016_user_blocks.up.sql
+// 000017_follow_counts.down.sql
+// 000017_follow_counts.up.sql
+// 000018_users_search.down.sql
+// 000018_users_search.up.sql
+// 000019_chat_management.down.sql
+// 000019_chat_management.up.sql
+// 000020_chat_roles.down.sql
+// 000020_chat_roles.up.sql
+// 000021_chat_dialogs.down.sql
+// 000021_chat_dialogs.up.sql
 // bindata.go
 // migrations.go
 // DO NOT EDIT!
@@ -79,6 +111,26 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var __000001_initDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4f\x00\xb0\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x75\x73\x65\x72\x5f\x66\x72\x69\x65\x6e\x64\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x75\x73\x65\x72\x73\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\xf2\xa3\x16\x3c\x4f\x00\x00\x00")
+
+func _000001_initDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000001_initDownSql,
+		"000001_init.down.sql",
+	)
+}
+
+func _000001_initDownSql() (*asset, error) {
+	bytes, err := _000001_initDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000001_init.down.sql", size: 79, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000001_initUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x91\xc1\x4e\xc3\x30\x0c\x86\xef\x79\x0a\xdf\xd6\x4a\x3b\x94\x69\xa0\x49\x3b\x21\xb4\x03\x07\x86\x84\xc6\xb9\x4a\x5b\xb7\xb3\x48\x9c\xc9\x49\x60\x7d\x7b\xd4\xa6\x53\x35\x60\xe2\xe6\xfa\xf3\xf7\x37\x89\x2b\xec\x88\xb7\x4a\xd5\x82\x3a\x20\x04\x5d\x19\x04\x6a\x81\x5d\x00\x3c\x93\x0f\x1e\xa2\x47\xf1\x2a\x53\x00\x30\xd6\x25\x35\x00\x50\x51\x47\x1c\xe0\x24\x64\xb5\xf4\xf0\x81\x3d\x3c\xbe\x1f\x5e\xcb\xe7\xfd\xd3\xdb\xee\x65\xb7\x3f\x80\x8e\xc1\x95\xc4\xb5\xa0\x45\x0e\xcb\xd1\x37\xae\x23\x1e\x0a\xf8\xd4\x52\x1f\xb5\x64\xab\xa2\xc8\x87\xef\xe1\x87\x1c\x8d\x49\x73\x68\x35\x99\xab\xb9\xbb\xa2\xc8\x13\x3b\x1d\x1d\xe3\x35\xbb\xbf\x20\xed\xfd\x97\x93\x66\x46\x0f\xeb\xfc\x47\x34\x6b\x9b\xec\x59\x5f\x6d\x26\xdf\x47\x99\xf0\x6f\xa6\xbb\x49\x03\xba\xdc\xc6\xe3\x79\x6a\x21\x47\x0b\xd9\xc2\x6a\x83\x8b\xe5\xa2\xc5\xb1\x98\xc4\xda\x45\x0e\xd2\xff\x19\x5a\x53\xe8\x6f\x1d\x86\x38\xa0\xe0\xb0\x81\xf9\xad\xd6\x9b\x5c\xa9\xfc\xff\x7d\x95\xad\x10\x72\x73\x63\x6b\x29\x3f\x8d\x0c\xfd\xd4\x4d\xb9\xce\x5a\x0a\x5b\xf5\x1d\x00\x00\xff\xff\x09\xe8\xcf\x40\x18\x02\x00\x00")
 
 func _000001_initUpSqlBytes() ([]byte, error) {
@@ -99,6 +151,26 @@ func _000001_initUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000002_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4a\x4a\x4d\xcf\xcc\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x80\x08\xc4\xe7\x25\xe6\xa6\xc6\x67\xa6\x54\x58\x63\x28\x8e\x4f\x2b\xca\x4c\xcd\x4b\x41\xd7\x02\x15\x2e\x8e\x2f\xcd\x4c\x21\x4f\x63\x1a\x4c\x63\x62\x4e\x49\x6a\x91\x42\x49\x62\x52\x4e\x2a\x2e\xe7\xe5\xe4\xa7\x67\xe6\x11\xad\x3a\x39\xbf\x34\xaf\xa4\xa8\x12\xa2\x9e\x2b\x39\x3f\x37\x37\xb3\xc4\x9a\x0b\x30\x00\x05\xcd\x1f\x81\x0e\x01\x00\x00")
+
+func _000002_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000002_indexesDownSql,
+		"000002_indexes.down.sql",
+	)
+}
+
+func _000002_indexesDownSql() (*asset, error) {
+	bytes, err := _000002_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000002_indexes.down.sql", size: 270, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000002_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\xbb\xca\x83\x40\x10\x46\xfb\x7d\x8a\x29\x7f\xe1\x7f\x83\xad\x0c\x5a\x04\x24\x45\xb0\x48\xb7\xac\xce\x28\x03\xba\x0b\x7b\x01\xf3\xf6\x41\x27\xf7\x90\x26\xdd\xb7\x67\x39\x07\xa6\xa3\x91\x9d\x56\xaa\x6c\xda\xfa\x08\x6d\xb9\x6b\x6a\xc8\x91\x42\x54\x00\x00\x65\x55\xc1\xfe\x50\xd5\x27\x61\xc6\xd9\x99\x0c\xe3\x02\x7f\xeb\xfa\x87\x98\xc3\x3a\x0a\xfd\xe1\x9b\x21\x30\x39\xbc\x57\xd8\x21\x2d\xcf\x3f\xd1\x64\x46\x69\x6d\x94\xf1\xb7\xca\x70\xab\x08\x90\x8e\x9d\x12\x05\x48\xb6\x9b\xe8\xed\x9a\x47\x21\x9a\xc9\x8f\xec\x44\xde\xe6\x57\xd1\x22\xbe\x88\xbd\xcf\x2e\x85\xb3\xa8\xd7\x47\xa1\x95\xea\xfd\x3c\x73\xd2\xea\x12\x00\x00\xff\xff\x31\x64\x7b\xe9\x55\x01\x00\x00")
 
 func _000002_indexesUpSqlBytes() ([]byte, error) {
@@ -119,6 +191,26 @@ func _000002_indexesUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000003_indexesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xcd\x0a\x83\x30\x10\x84\xef\x79\x8a\x3d\x46\xe8\x1b\xe4\x64\x89\x87\x82\xb4\x45\x3c\xf4\x16\xd4\x44\x59\xd0\x08\xf9\x81\xf6\xed\x8b\x3f\x2d\xad\x46\xb0\xbd\xed\x0e\x3b\xc3\xce\x57\xaa\x06\x35\x23\x24\x4e\xf3\x24\x83\x3c\x3e\xa6\x09\x78\xab\x8c\x05\x9e\x5d\xae\x70\x3a\xf3\xe4\x36\x09\x42\x17\x9d\x12\x28\xef\x6c\x75\x2c\x6a\x83\x4a\xcb\xa5\x65\x96\xad\xf0\x28\xff\x33\xd6\x2f\x63\xd1\x3a\x65\xc0\x15\x65\xab\xb6\xde\x6b\xfb\x06\xf5\xee\xeb\xaa\xf7\xda\x99\x47\xf8\x2d\x0b\x31\xe7\xc1\xee\x40\x07\x0a\x07\xb0\xde\x0c\x43\xb4\x5d\xe9\x3b\x61\x89\x02\xe8\xa8\xa2\xfc\x3d\x61\x66\x02\x74\x12\xc2\x19\xeb\x06\x6f\x3c\x40\xc7\x71\x97\xe9\x83\x12\xd0\x79\x89\x18\x21\x55\xdf\x75\xe8\x18\x79\x0e\x00\xc1\x5a\x1f\x92\x3e\x02\x00\x00")
+
+func _000003_indexesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000003_indexesDownSql,
+		"000003_indexes.down.sql",
+	)
+}
+
+func _000003_indexesDownSql() (*asset, error) {
+	bytes, err := _000003_indexesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000003_indexes.down.sql", size: 574, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000003_indexesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x90\xcd\x8a\x83\x30\x1c\xc4\xef\x79\x8a\xff\x31\xc2\xbe\x81\x27\x25\x61\x11\xc4\x5d\x5c\x17\x7a\x0b\x6a\xa2\x04\x34\x42\x3e\xa0\x7d\xfb\xe2\x17\x95\x54\xc1\xb6\xb7\xc9\x30\xf3\x23\xff\xa9\x44\x2b\x55\x88\x50\x94\x16\x34\x87\x22\x8a\x53\x0a\xce\x08\x6d\x80\xe4\x3f\xbf\x90\x64\x84\x5e\x66\x83\xa9\xb2\x17\x4c\xf2\x6b\xf8\x14\x66\x8d\x96\x42\x71\xbf\xb2\xd8\x86\x39\xc9\xdf\x2b\x36\x6b\xb1\xec\xac\xd0\x60\xcb\xaa\x13\x47\xdf\xeb\x86\x56\xaa\xd3\xe9\x7a\x70\xca\xea\xdb\xfe\xb7\x0c\x44\x84\x2c\x71\xef\x78\xc0\xa3\xfa\x02\xe3\xf4\x28\x02\xf8\xff\x4b\xb2\x6f\x88\x8b\x9c\xd2\xe3\xfb\x1e\xb8\xbd\x5d\x00\x4f\xae\xe4\x01\x7c\x84\x6b\x56\xdc\x6c\x9c\x00\x1a\x0f\xb5\x59\x11\xf0\x24\x5f\x27\x6c\x96\x05\xbc\x3c\x7c\x0a\xaa\x87\xbe\x97\x36\x44\xf7\x00\x00\x00\xff\xff\x1c\x37\x60\x12\x7f\x02\x00\x00")
 
 func _000003_indexesUpSqlBytes() ([]byte, error) {
@@ -139,6 +231,26 @@ func _000003_indexesUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000004_postsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x2d\x00\xd2\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x70\x6f\x73\x74\x73\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\xd6\x93\xea\xe5\x2d\x00\x00\x00")
+
+func _000004_postsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000004_postsDownSql,
+		"000004_posts.down.sql",
+	)
+}
+
+func _000004_postsDownSql() (*asset, error) {
+	bytes, err := _000004_postsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000004_posts.down.sql", size: 45, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000004_postsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x91\x31\x6b\xf3\x30\x10\x86\x77\xfd\x8a\x77\x8c\x21\x43\xf8\xf8\x86\x42\x26\x25\x56\x8b\x21\x71\x82\x23\x43\x37\x21\xc7\xd7\x54\xd4\xb6\x82\x74\x29\xc9\xbf\x2f\xb6\x3c\xb4\x94\x6a\xd3\xab\x7b\x8e\xe7\x74\x0d\x5d\xdc\xb0\x16\xe2\x1c\xc8\x32\x81\x6d\xd3\x11\xdc\x1b\x06\xcf\xa0\xbb\x8b\x1c\x71\xf5\x91\xa3\x58\x08\x00\x70\x2d\xe6\xd3\xb8\x8b\x1b\x18\xd7\xe0\x7a\x1b\x1e\xf8\xa0\x07\x64\xad\x0f\xa6\x28\xb7\x95\xda\xab\x52\xc3\xde\xd8\x1b\x37\x9c\x03\xf5\x34\xf0\x72\xe2\x6f\x91\x82\x99\x9a\x24\x3e\xa5\xec\xb8\xa3\xa9\xeb\xa7\x0d\xe7\x77\x1b\x16\xff\x56\xab\x6c\x72\x18\x6e\x5d\x97\x8a\x7a\x8a\xd1\x5e\xe8\x47\xd1\xff\xa7\x2c\x3d\x26\xfd\xd6\x58\x46\x2e\xb5\xd2\xc5\x5e\x21\x57\xcf\xb2\xde\x69\x6c\xeb\xaa\x52\xa5\x36\x63\x78\xd2\x72\x7f\x9c\x55\xae\xed\x2f\xe4\x50\xa2\x3e\x8e\xb7\xbf\xa0\x96\x3a\x62\x6a\xd1\x78\xdf\x89\x6c\x2d\x84\xdc\x69\x55\x41\xcb\xcd\x4e\xa5\x8f\x82\xcc\x73\x14\x65\xae\x5e\x91\x02\x33\xce\x1c\x8d\x6b\xef\x58\xcc\xe3\x2f\xbf\xf9\x66\xa8\x4f\x45\xf9\x82\x8d\xae\x94\x1a\x17\xe1\xfb\xde\xf1\x5a\x7c\x05\x00\x00\xff\xff\x4a\x02\x7b\x0d\x99\x01\x00\x00")
 
 func _000004_postsUpSqlBytes() ([]byte, error) {
@@ -159,6 +271,26 @@ func _000004_postsUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000005_collateDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\x8f\x31\x4e\xf3\x40\x14\x84\x7b\x9f\xe2\x1d\xe0\x4f\xf7\x17\x88\x1c\x26\x52\x4c\x40\x2e\x9c\x34\x49\x6f\x3b\x52\x50\x24\x44\xce\xc0\x0d\x16\xc3\xe2\x15\x8e\xd7\x57\xf8\xe6\x46\x68\x37\x82\xe6\x15\x6f\x46\xdf\xcc\xac\x37\x4f\xd5\x76\x59\x14\x8b\x85\xf1\x46\x64\x26\xaa\x55\x87\xa3\x67\xc4\x33\x11\xf0\x96\x1f\xef\x8c\x04\x9d\x8c\x09\x6f\xf4\x44\x06\x7a\x35\x38\x9d\x71\x78\x75\x6a\x75\xb9\x37\x66\x35\x78\xbe\x92\x2d\x19\x07\x26\x9c\x9e\xff\x58\x0c\x19\x1e\xd4\xe2\x19\x89\x46\x54\x97\xa0\x2e\x05\xab\x21\xe2\xf9\xb6\x24\xab\xa1\xcf\xd7\xfd\xcb\x05\x9d\x1d\xf6\x8f\x77\xf5\xfa\xff\xea\xb0\xad\xca\xdd\xc3\x66\x55\x56\x96\x49\x33\x91\x51\x2f\x0c\x3a\xfe\x36\x31\xfa\xc4\xe0\x4a\xb0\xdb\xac\x3c\xe8\x53\x47\xbd\xea\x4c\xb8\x09\x57\x02\x1f\x29\x41\x27\x82\x2e\xe9\x59\x14\xe5\xae\xae\xab\xfd\xb2\xf8\x19\x00\x6b\x61\xcc\x7a\x1d\x01\x00\x00")
+
+func _000005_collateDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000005_collateDownSql,
+		"000005_collate.down.sql",
+	)
+}
+
+func _000005_collateDownSql() (*asset, error) {
+	bytes, err := _000005_collateDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000005_collate.down.sql", size: 285, mode: os.FileMode(436), modTime: time.Unix(1792410803, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000005_collateUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xca\x31\x0e\x80\x20\x0c\x05\xd0\xbd\xa7\xe8\x21\x1c\x4c\x88\x67\x21\x50\xab\x69\x52\xa8\x81\xcf\xfd\x9d\x9d\xdc\x5f\xd5\xdb\x7a\x22\x2a\x0e\x1d\x8c\x52\x5d\xf9\x89\x89\xc9\x12\xee\x05\xca\x07\x2f\x5c\x7b\xab\x5b\x5e\xdd\x24\x4e\xcd\x62\xe9\xe3\xd7\xd4\xf1\xe7\x49\xa2\x35\x43\x22\xa2\x37\x00\x00\xff\xff\xc3\xbe\x04\xae\x73\x00\x00\x00")
 
 func _000005_collateUpSqlBytes() ([]byte, error) {
@@ -179,6 +311,26 @@ func _000005_collateUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000006_charsetDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcc\xbf\x4a\xc3\x50\x18\x86\xf1\x3d\x57\xf1\x8d\x76\x28\x14\xe9\x50\xcc\xd5\x24\x35\xad\x81\xfc\x91\xe4\x54\x70\x8b\x19\x0a\x52\x21\xbb\x57\x71\x12\x1a\x13\xaa\x39\xde\xc2\xf3\xdd\x91\xa4\x38\xb8\xb8\xbe\xbc\xbf\x27\x8c\xf6\x71\xe6\x7b\xde\x72\x29\xbc\xe3\x38\x33\x6a\x85\xa3\xe3\x82\x15\x5a\x2c\x83\x9e\x84\x51\xb4\xc6\xd2\xf2\xc9\xa8\x47\x61\xa2\x17\x3a\x1c\x03\x9d\x56\x58\x7d\xc5\xd2\x6b\xad\x2f\xda\xdc\x09\x8e\xf6\xba\xd6\x4c\x58\x6d\x84\x0b\x8e\x89\x8e\x5e\xab\x39\xa4\x47\xc6\x79\x1e\x19\xe4\x60\x76\x9b\x34\x5c\x0b\x5f\x38\x3e\xe6\x88\xf0\x8d\xd3\xfa\x7a\x6e\xb4\xd6\x37\xe1\x8c\x65\x62\xd2\x13\xbd\x17\x24\x26\x2a\xc4\x04\x61\x12\xc9\x63\x5e\x9a\x52\xd2\xfc\x3e\xde\x3d\x8b\x89\x4d\x12\xc9\x53\x50\x6c\x1f\x82\xe2\xe6\x76\xb5\x5a\x48\x96\x1b\xc9\x0e\x49\xe2\xff\xaf\xd2\xa8\x2c\x83\xfd\x5f\xb7\xde\x2c\x7e\x91\xb7\xcd\xd3\x34\x36\xbe\xf7\x33\x00\xd9\x6f\xc6\x42\x28\x01\x00\x00")
+
+func _000006_charsetDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000006_charsetDownSql,
+		"000006_charset.down.sql",
+	)
+}
+
+func _000006_charsetDownSql() (*asset, error) {
+	bytes, err := _000006_charsetDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000006_charset.down.sql", size: 296, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000006_charsetUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xce\xbd\x4a\xc6\x30\x14\xc6\xf1\x3d\x57\x71\x46\xdd\x8a\x74\x28\x14\x87\x18\x03\x0e\xc5\x42\x1b\x5c\x4b\x9a\x9e\xd6\x48\x3e\x4a\x72\x22\x78\xf7\xa2\x0e\x4a\x91\xbe\xcb\xbb\x3e\x0f\xfc\xf8\xcf\xb8\xd9\xd0\x32\xc6\x3b\x25\x07\x78\xe4\x8a\x3f\xf0\x51\xc2\x9e\xe2\x1b\x1a\x02\xf1\xc4\x07\x2e\xbe\xae\x51\x2a\xb8\x87\x42\x6b\xe3\xe7\x1a\x44\xdf\x75\x5c\xc9\xdf\x65\x2a\xc1\x9a\xb8\xe0\x64\x6c\xcb\xb4\x23\x4c\x40\x7a\x76\x08\x7b\xcc\x94\x41\xf4\xcf\x2f\x72\x50\xa0\xfa\x03\x79\x04\x2f\x71\x25\x63\xba\x22\xf7\x53\xe7\xe3\x62\xd7\x0f\x20\x4b\x0e\xe1\x5d\x27\xf3\xaa\xd3\xcd\x5d\x55\xdd\x82\x89\xce\x69\xc2\x7f\x1c\x08\x91\x20\x14\xe7\x4e\x40\x8f\x39\xeb\xed\x2f\x59\x37\xe7\xe6\xb7\x67\xa2\xf7\x96\x5a\xc6\xd8\x67\x00\x00\x00\xff\xff\x2a\x15\x86\x74\x9e\x01\x00\x00")
 
 func _000006_charsetUpSqlBytes() ([]byte, error) {
@@ -199,6 +351,26 @@ func _000006_charsetUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000007_dtupdateDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5b\x00\xa4\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x70\x6f\x73\x74\x73\x20\x6d\x6f\x64\x69\x66\x79\x20\x75\x70\x64\x61\x74\x65\x64\x5f\x61\x74\x20\x44\x41\x54\x45\x54\x49\x4d\x45\x20\x4f\x4e\x20\x55\x50\x44\x41\x54\x45\x20\x43\x55\x52\x52\x45\x4e\x54\x5f\x54\x49\x4d\x45\x53\x54\x41\x4d\x50\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x5f\x37\xd2\xf0\x5b\x00\x00\x00")
+
+func _000007_dtupdateDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000007_dtupdateDownSql,
+		"000007_dtupdate.down.sql",
+	)
+}
+
+func _000007_dtupdateDownSql() (*asset, error) {
+	bytes, err := _000007_dtupdateDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000007_dtupdate.down.sql", size: 91, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000007_dtupdateUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xe1\x0a\x82\x30\x10\xc0\xf1\xef\x7b\x8a\x7b\x01\x9f\x60\xf4\x41\x6a\x41\xa0\x16\x3a\x3f\xcb\xd4\xab\x06\x3b\x27\xee\x46\xf4\xf6\x41\x69\x0c\xea\xdb\xd8\x1d\xbf\xff\xf5\x78\xb3\x93\x14\x42\x64\x19\x18\xc7\xb8\x00\x9b\xde\x21\xcc\x3e\x70\x58\x7f\x06\xef\x22\x4d\x10\xe7\xd1\x30\x8e\x9d\xe1\xf4\xd9\x28\x0d\xfa\x54\xaa\x46\xe7\xe5\x05\xaa\xb3\x86\xaa\x2d\x0a\x38\xa8\x63\xde\x16\x1a\xf6\x6d\x5d\xab\x4a\x77\xdf\x15\x29\x7e\x33\xe4\x47\x7b\x7d\xa6\x2a\x5b\xc2\xc0\x86\x66\x98\xa2\x73\xe0\xb7\xfa\x3f\x6f\x9d\x7c\xa8\x80\xe9\x75\xbb\x61\xc1\x8d\x7c\xdc\x71\xc1\xb4\x61\xc3\x1b\x97\x42\x0c\x9e\xc8\xb2\x7c\x05\x00\x00\xff\xff\xde\x47\xd5\xef\x0c\x01\x00\x00")
 
 func _000007_dtupdateUpSqlBytes() ([]byte, error) {
@@ -219,6 +391,26 @@ func _000007_dtupdateUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000008_delupdDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x41\x00\xbe\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x70\x6f\x73\x74\x73\x0a\x20\x20\x20\x20\x6d\x6f\x64\x69\x66\x79\x20\x64\x65\x6c\x65\x74\x65\x64\x20\x62\x6f\x6f\x6c\x20\x6e\x75\x6c\x6c\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x47\xa9\x25\xc4\x41\x00\x00\x00")
+
+func _000008_delupdDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000008_delupdDownSql,
+		"000008_delupd.down.sql",
+	)
+}
+
+func _000008_delupdDownSql() (*asset, error) {
+	bytes, err := _000008_delupdDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000008_delupd.down.sql", size: 65, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000008_delupdUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcd\xc1\xad\x02\x31\x0c\x84\xe1\xbb\xab\x98\x3e\xa2\x57\x4c\xf2\x3c\x81\x48\x4e\xbc\x5a\x3b\x42\x74\xcf\x01\x96\x02\xb8\xff\xf3\x4d\xe3\x6d\xac\x22\x22\xd5\x92\x27\xb2\x36\x23\x0e\x8f\x0c\x01\x80\xe9\x3a\xfa\x13\x4a\x63\x52\xd1\xdc\x0d\xca\x5e\xb7\x25\x7a\xb5\x60\x91\x7d\x68\xcd\x6b\x13\xcc\x2b\xfe\x7b\x07\xf2\xb8\xf3\xe4\x57\x18\x81\xb5\xcd\xca\xaf\x7f\x58\x9e\x1f\x41\xfe\x7d\xce\x91\xe5\x15\x00\x00\xff\xff\x5f\x20\x90\xba\xc3\x00\x00\x00")
 
 func _000008_delupdUpSqlBytes() ([]byte, error) {
@@ -239,6 +431,26 @@ func _000008_delupdUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000009_dialogsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x74\x00\x8b\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6d\x65\x73\x73\x61\x67\x65\x73\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x63\x68\x61\x74\x5f\x70\x61\x72\x74\x69\x63\x69\x70\x61\x6e\x74\x73\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x63\x68\x61\x74\x73\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\xa4\xb2\x71\xa8\x74\x00\x00\x00")
+
+func _000009_dialogsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000009_dialogsDownSql,
+		"000009_dialogs.down.sql",
+	)
+}
+
+func _000009_dialogsDownSql() (*asset, error) {
+	bytes, err := _000009_dialogsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000009_dialogs.down.sql", size: 116, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000009_dialogsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\xc1\x8a\xdb\x30\x10\x86\xef\x7e\x8a\x39\x26\x90\x43\xda\xd3\x42\x4e\xde\xb5\x5a\x02\x89\x5b\xbc\x0a\xf4\x26\xc6\xd6\xd8\x3b\x54\x96\x83\x34\x2e\xd9\xb7\x2f\x8e\x9d\xb4\x1b\x87\x5d\x68\x75\x32\x23\xe9\xf7\xa7\x4f\xa3\x92\x1a\xf6\x9b\x24\xa9\x02\xa1\x10\x08\x96\x8e\x80\x6b\xf0\x9d\x00\x9d\x38\x4a\x84\xea\x05\x25\x26\x8b\x04\x00\x80\x2d\x5c\x46\xc9\x0d\x7b\x81\x63\xe0\x16\xc3\x2b\xfc\xa4\x57\x48\x0f\xfa\x9b\xd9\xe6\x4f\x85\xda\xab\x5c\x03\xf6\xd2\x19\xf6\x55\xa0\x96\xbc\xac\xce\x01\xc2\xe2\x68\x0c\xf8\x85\xa1\x7a\xc1\xb0\xf8\xbc\x5e\x2f\xe1\xfe\x18\x28\x7c\xef\x1c\x54\x9d\x73\x03\x5f\x2f\xf5\x83\x69\xc8\x53\x40\x67\x2a\x1e\x33\x47\x76\x6b\x50\x20\x4b\xb5\xd2\xdb\xbd\x82\x4c\x7d\x49\x0f\x3b\x0d\x4f\x87\xa2\x50\xb9\x36\x43\xf1\x59\xa7\xfb\xef\xd7\xcc\x69\xaf\xeb\x22\x8d\x87\x2a\xbb\xce\x9d\x3f\x2c\xd5\xd8\x3b\x81\x1a\x5d\xa4\xbb\x3c\xc9\x72\x93\x24\xe9\x4e\xab\x02\x74\xfa\xb8\x53\x93\xa3\x61\x49\x9a\x65\xb0\xcd\x33\xf5\x63\xac\x99\x3e\x52\x88\x86\xed\x09\x16\x7f\x38\x97\x70\x78\xde\xe6\x5f\xe1\x51\x17\x4a\x6d\x92\x0f\xf5\x9b\x23\x06\xe1\x8a\x8f\xe8\x67\x57\xf1\x6f\xf7\x70\x4e\x65\x3b\xed\x1e\x6b\x03\xea\x6d\x2d\x0a\x4a\x1f\x01\xd8\xcb\xc3\x55\xcc\xa7\xb7\x22\xd0\x09\x85\x89\x7d\x4e\x3b\xa4\xa0\xb5\xc0\xde\xd2\x69\x3e\x3f\xa9\x19\x71\x56\x17\x86\xd5\xf4\xe3\x25\xf4\x91\x7d\x03\xa5\x04\xa2\xf7\xdb\xb4\xa5\x18\xb1\xa1\x79\xa7\xfe\x9f\xa0\xeb\xfe\x9b\x16\xf8\x4b\x59\x1d\xba\xf6\xdd\x55\x91\xfc\xb9\x3b\x01\x2c\x0a\x09\xb7\x74\x6f\xd5\x74\x80\xb7\x2f\x63\xbd\xfc\xf0\x11\x24\xb3\x4b\xb8\xaa\xb8\xe3\xfe\x32\x77\xe3\x7d\x42\x9c\xf9\xee\xda\x96\x65\xf3\x3b\x00\x00\xff\xff\x65\x58\xbd\x40\x26\x04\x00\x00")
 
 func _000009_dialogsUpSqlBytes() ([]byte, error) {
@@ -259,6 +471,26 @@ func _000009_dialogsUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000010_user_shardDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x39\x00\xc6\xff\x62\x65\x67\x69\x6e\x20\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x75\x73\x65\x72\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x73\x68\x61\x72\x64\x5f\x69\x64\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x0a\x03\x00\x60\xb9\x6f\x8c\x39\x00\x00\x00")
+
+func _000010_user_shardDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000010_user_shardDownSql,
+		"000010_user_shard.down.sql",
+	)
+}
+
+func _000010_user_shardDownSql() (*asset, error) {
+	bytes, err := _000010_user_shardDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000010_user_shard.down.sql", size: 57, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var __000010_user_shardUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\xcc\x31\xae\x03\x21\x0c\x84\xe1\x9e\x53\x4c\xb7\xef\x75\x49\x91\x0a\xe5\x2c\xc8\x0b\xd6\x62\xc9\xb0\x91\x31\xc9\xf5\x23\x94\x62\xa7\xfd\x34\xff\xce\x87\x74\xc4\x10\x48\x9d\x0d\x4e\xbb\x32\xe6\x60\x1b\xa0\x52\x90\x4f\x9d\xad\x63\x54\xb2\x92\xa4\xe0\x4d\x96\x2b\xd9\xdf\xe3\x7f\x91\x92\x33\x94\x5c\xfa\x3d\x1d\xdc\xd9\x48\x53\x96\x18\xe6\xab\x2c\xf9\x65\x06\xfb\xf5\x7f\x62\xbb\xad\x6d\xf8\x54\x36\xbe\x40\x06\xfa\x54\x8d\x21\xe4\xb3\x35\xf1\x6f\x00\x00\x00\xff\xff\xc7\x6e\x7b\x81\x98\x00\x00\x00")
 
 func _000010_user_shardUpSqlBytes() ([]byte, error) {
@@ -279,6 +511,446 @@ func _000010_user_shardUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000011_commentsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x67\x00\x98\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x70\x6f\x73\x74\x73\x0a\x20\x20\x20\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x5f\x63\x6f\x75\x6e\x74\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x70\x6f\x73\x74\x5f\x63\x6f\x6d\x6d\x65\x6e\x74\x73\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x5f\xa9\xbe\xd5\x67\x00\x00\x00")
+
+func _000011_commentsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000011_commentsDownSql,
+		"000011_comments.down.sql",
+	)
+}
+
+func _000011_commentsDownSql() (*asset, error) {
+	bytes, err := _000011_commentsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000011_comments.down.sql", size: 103, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000011_commentsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x4f\x8f\xdb\x20\x10\xc5\xef\x7c\x8a\x39\x26\xd2\x1e\x56\xd5\x1e\x56\xf2\xc9\xf2\x52\x75\xa5\xfc\xa9\x1c\x72\x46\x18\xc6\x2e\x2a\x7f\x22\x18\xaa\xe4\xdb\x57\x8e\xe3\x34\xa9\x7c\xda\xb9\x80\x80\xf9\xf1\xde\xbc\x0e\x07\x1b\x2a\xc6\x74\x42\x45\x08\xa4\x3a\x87\x60\x7b\x08\x91\x00\xcf\x36\x53\x86\x53\xcc\x24\x75\xf4\x1e\x03\x65\xb6\x62\x00\x00\xd6\xc0\x5c\x9d\x1d\x6c\x20\x38\x25\xeb\x55\xba\xc0\x6f\xbc\x40\x7d\x14\x7b\xf9\xb9\x6b\x5a\xbe\xe5\x3b\x01\xaa\x50\x94\x36\xe8\x84\x23\xe2\xe5\x0a\xb8\x42\xad\x79\x00\xcc\xbc\xa5\x1a\xd5\x84\xe2\xdc\xd4\x5b\x32\xa6\xaf\xf6\x7a\xcc\x59\x0d\x38\xde\xfc\x51\x49\xff\x52\x69\xf5\xed\xf5\xed\x7d\x3d\xbf\x5e\xee\x05\x1d\x9d\x1b\xe7\x53\xa8\x7f\xf7\xdd\x9b\x2c\xc1\xea\x68\x50\x6a\x3b\x49\x9a\xc6\x67\xa4\x22\xf8\xa8\x05\x17\x9f\x5b\x0e\x1f\xfc\x7b\x7d\xdc\x08\x68\x8e\x6d\xcb\x77\x42\x8e\x87\x07\x51\x6f\x7f\xfe\x27\xc9\xa0\x43\xc2\xc9\x4e\x8c\x6e\x5c\xc1\x60\xaf\x8a\x23\xe8\x95\xcb\xb8\x28\x89\xad\xa1\xf9\x51\xb7\x75\x23\x78\x0b\x07\x2e\x66\x69\xd0\xec\x37\x9b\x5a\xf0\x05\xa9\x15\x63\xca\x11\xa6\x5b\xca\xcf\xb9\x8e\x6c\x65\x0c\xd8\x60\xf0\xfc\x9c\xb9\xbc\x85\x75\x86\xd5\x6d\xf7\xf2\x60\x78\x0d\x25\xdb\x30\x40\x47\x09\x71\xe1\x8b\x7f\x68\x1d\x5d\xf1\x01\xee\x58\x1d\x4b\xa0\x39\xc2\xd9\xf1\xeb\xdd\x61\xc5\x98\x8e\xde\x5b\xaa\xd8\xdf\x01\x00\x77\xbc\x92\x87\xa6\x02\x00\x00")
+
+func _000011_commentsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000011_commentsUpSql,
+		"000011_comments.up.sql",
+	)
+}
+
+func _000011_commentsUpSql() (*asset, error) {
+	bytes, err := _000011_commentsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000011_comments.up.sql", size: 678, mode: os.FileMode(436), modTime: time.Unix(1792408466, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000012_reactionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x57\x00\xa8\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x72\x65\x61\x63\x74\x69\x6f\x6e\x5f\x63\x6f\x75\x6e\x74\x73\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x72\x65\x61\x63\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x27\x7b\x63\x57\x57\x00\x00\x00")
+
+func _000012_reactionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000012_reactionsDownSql,
+		"000012_reactions.down.sql",
+	)
+}
+
+func _000012_reactionsDownSql() (*asset, error) {
+	bytes, err := _000012_reactionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000012_reactions.down.sql", size: 87, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000012_reactionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\xc1\x4e\xac\x40\x10\x45\xf7\xfd\x15\x77\x07\x24\x2c\xde\xdb\xb8\x99\x15\x71\x30\x31\x71\x8c\x19\x99\x35\x69\xa0\x06\x2b\xd2\x0d\xe9\x2e\x8c\xfc\xbd\x41\x45\x67\x94\x8e\xc6\x5a\x11\x72\x4f\xa7\xea\xdc\x8a\x5a\xb6\x1b\xa5\x6a\x47\x5a\x08\xa2\xab\x8e\xc0\x47\xd8\x5e\x40\xcf\xec\xc5\xc3\x91\xae\x85\x7b\xeb\x55\xac\x00\x80\x85\x4c\x29\xd3\x40\x00\xd9\xd1\x20\x8e\x86\xde\x4b\x94\x46\x86\xbc\xd7\x2d\x45\x09\x4e\x66\x7e\xc8\x8e\x5d\x97\x7e\xb2\xdc\xcc\x9f\x15\xb7\x6c\x65\x89\xad\xce\x39\x3b\x7a\x72\x7f\x65\x97\x1b\x00\x3c\x69\x57\x3f\x68\x17\xff\xbf\x48\x56\xb0\x15\xf6\x4d\x4d\x53\x6a\xc1\x36\x2b\xf2\xe2\x7a\x97\x63\x9b\x5f\x65\x87\x9b\x02\x97\x87\xfd\x3e\xbf\x2d\xca\xf9\xe7\x7d\x91\xed\xee\xbe\xb0\x83\x63\xa3\xdd\x84\x47\x9a\x10\x7f\x88\x4b\x17\x0f\xe9\x72\x54\xa2\x92\xdf\x95\x50\xd6\xfd\x68\xe5\x7b\x15\xa1\x26\x42\xfa\x43\x06\x43\xda\x42\xd6\xce\xf3\xaf\xcb\xe1\xa4\xa1\x86\x8e\x7a\xec\x04\xff\xd6\xf3\x3f\xea\x59\x16\x78\xf7\xd3\x1b\xc3\xb2\x51\x2f\x03\x00\xa6\xda\x5a\x82\xb5\x02\x00\x00")
+
+func _000012_reactionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000012_reactionsUpSql,
+		"000012_reactions.up.sql",
+	)
+}
+
+func _000012_reactionsUpSql() (*asset, error) {
+	bytes, err := _000012_reactionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000012_reactions.up.sql", size: 693, mode: os.FileMode(436), modTime: time.Unix(1792408542, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000013_post_visibilityDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3f\x00\xc0\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x70\x6f\x73\x74\x73\x0a\x20\x20\x20\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x76\x69\x73\x69\x62\x69\x6c\x69\x74\x79\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x19\xe4\xb7\xd1\x3f\x00\x00\x00")
+
+func _000013_post_visibilityDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000013_post_visibilityDownSql,
+		"000013_post_visibility.down.sql",
+	)
+}
+
+func _000013_post_visibilityDownSql() (*asset, error) {
+	bytes, err := _000013_post_visibilityDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000013_post_visibility.down.sql", size: 63, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000013_post_visibilityUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7c\x00\x83\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x70\x6f\x73\x74\x73\x0a\x20\x20\x20\x20\x61\x64\x64\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x76\x69\x73\x69\x62\x69\x6c\x69\x74\x79\x20\x65\x6e\x75\x6d\x20\x28\x27\x70\x75\x62\x6c\x69\x63\x27\x2c\x27\x66\x72\x69\x65\x6e\x64\x73\x27\x2c\x27\x70\x72\x69\x76\x61\x74\x65\x27\x29\x20\x64\x65\x66\x61\x75\x6c\x74\x20\x27\x70\x75\x62\x6c\x69\x63\x27\x20\x6e\x6f\x74\x20\x6e\x75\x6c\x6c\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\xac\xe8\xfb\x44\x7c\x00\x00\x00")
+
+func _000013_post_visibilityUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000013_post_visibilityUpSql,
+		"000013_post_visibility.up.sql",
+	)
+}
+
+func _000013_post_visibilityUpSql() (*asset, error) {
+	bytes, err := _000013_post_visibilityUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000013_post_visibility.up.sql", size: 124, mode: os.FileMode(436), modTime: time.Unix(1792408658, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000014_post_draftsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x33\x00\xcc\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x70\x6f\x73\x74\x5f\x64\x72\x61\x66\x74\x73\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\xa4\xd2\xb7\xeb\x33\x00\x00\x00")
+
+func _000014_post_draftsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000014_post_draftsDownSql,
+		"000014_post_drafts.down.sql",
+	)
+}
+
+func _000014_post_draftsDownSql() (*asset, error) {
+	bytes, err := _000014_post_draftsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000014_post_drafts.down.sql", size: 51, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000014_post_draftsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x94\xc1\x6e\xdb\x30\x0c\x86\xef\x7e\x0a\xde\x9c\x00\x3e\x14\x43\x0f\x05\x72\x32\x52\x0f\x2b\x90\xa4\x43\xaa\x9c\x0d\x59\x62\x12\x62\xb2\x6c\x48\x54\x90\xbc\xfd\xa0\xd8\xda\x9c\xae\x3b\x34\xd8\x78\x22\x0c\xf2\xfb\x69\xe2\x17\x1b\x3c\x90\x5d\x64\x99\x72\x28\x19\x81\x65\x63\x10\x68\x0f\xb6\x63\xc0\x33\x79\xf6\xd0\x77\x9e\x6b\xed\xe4\x9e\x7d\x36\xcb\x00\x00\x48\x43\x8a\x86\x0e\x64\x19\x7a\x47\xad\x74\x17\xf8\x81\x17\x28\x77\xe2\xb5\x7e\xd9\x2c\xb7\xd5\xba\xda\x08\x90\x81\xbb\x9a\xac\x72\xd8\xa2\xe5\xe2\x0a\x08\x1e\x5d\x4d\x7a\x02\x48\xbc\x3b\x22\x8e\x6a\x83\x31\x03\x9a\x89\x0d\xc6\x04\xe0\x24\x9d\x3a\x4a\x37\xfb\xf2\xf0\x30\x4f\xc5\xf7\xa1\x41\x75\xc6\xc4\xfd\x04\xde\x3f\xb5\xcd\x63\x1d\x2c\xa9\x4e\x63\xad\x68\x50\x6d\xd1\x7b\x79\xc0\x5b\xd5\xc7\xa7\xf9\xff\x55\x3d\x91\xa7\x86\x0c\xf1\x05\xd0\x86\x16\x66\x79\x1f\x1a\x43\x2a\x2f\xf2\xbd\x23\xb4\xda\xe7\x45\xde\x3b\x3a\x49\xc6\x7c\x0e\x1a\xf7\x32\x18\x86\x54\xf5\xb1\xea\xf0\x43\x9e\x25\x07\x1f\xb3\x84\xbe\x3a\x20\x2f\x72\xaf\x8e\xa8\x83\x41\x1d\xd9\x11\xe4\x8f\xd7\x5c\x49\xab\xd0\xc4\xef\x13\xa5\xa1\xe9\x1d\x7b\xec\xaa\x25\xc3\x73\x29\x2a\xf1\xb2\xae\xd2\x10\x9f\x8f\x09\x36\xfa\xf4\x9f\x99\xea\x17\x76\x78\x19\xfa\x66\xda\xe7\xea\x6b\xb9\x5b\x09\x58\xee\xb6\xdb\x6a\x23\xea\xf8\xf1\x4d\x94\xeb\xef\xa9\xfd\x6f\x71\xbb\x88\xd0\xeb\x84\x66\x6a\xd1\xb3\x6c\xfb\x54\xf9\xe9\x88\x58\xe8\xec\x08\xfd\x73\xb4\x6c\x0e\xcb\x6f\xe5\xb6\x5c\x8a\x6a\x0b\x6f\x95\x48\xae\x82\xe5\xeb\x6a\x55\x8a\xea\x03\x97\x2d\xb2\x4c\x1a\x46\x37\x9e\x85\xe9\x21\x88\x92\x52\x6b\x20\xab\xf1\x3c\x3d\x11\xf5\xf8\xb6\xcf\x30\x1b\xb3\x62\x34\x53\x31\x59\xe5\x1c\x82\x27\x7b\x80\x86\x1d\xe2\xe2\x0e\x15\x1d\x70\x10\x49\xec\xdf\xa6\x7a\xc7\xce\x54\xd7\xb6\xc4\x8b\xec\xe7\x00\x76\x31\xcd\xf8\xeb\x04\x00\x00")
+
+func _000014_post_draftsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000014_post_draftsUpSql,
+		"000014_post_drafts.up.sql",
+	)
+}
+
+func _000014_post_draftsUpSql() (*asset, error) {
+	bytes, err := _000014_post_draftsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000014_post_drafts.up.sql", size: 1259, mode: os.FileMode(436), modTime: time.Unix(1792409314, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000015_friend_requestsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcc\x41\x6e\x83\x30\x10\x85\xe1\xfd\x9c\x62\x2e\x90\x13\xf8\x30\x16\x29\x93\xca\x52\x42\x12\xdb\x48\xd9\x9a\x2e\x23\xb5\x87\xe8\x05\x5c\x04\x12\x02\x19\xae\xf0\xe6\x46\x55\x69\x17\x5d\xbf\xef\xfd\x47\x79\x75\x8d\x21\x3a\x1c\x18\x9f\x18\x90\xb1\x60\x44\x41\xd1\x27\x46\xc6\xa0\x6f\xf8\xc2\x82\x09\x33\xb2\x76\xfa\x64\x6c\x58\x31\x60\xc3\xa4\x09\x2b\x66\x46\xf9\x81\x3d\x56\x4d\x9a\xb4\x43\x46\x41\x46\xbf\x9f\x7a\x64\x7d\xd7\x4e\x93\x7e\x50\xed\xaf\x37\x8e\xd5\xf1\x2c\xec\x4e\x2c\x0f\x17\x62\xe0\x93\x77\xd2\xd4\xd6\xcb\xbd\x95\x10\x83\xa1\xea\x1c\xc5\xff\xb1\x36\x88\xb7\xbf\x82\x98\x99\xf7\x82\x6b\x6a\x79\xfc\x9f\xec\xad\x72\xde\xb6\x77\x43\xf4\x72\xbd\x5c\x5c\x34\xf4\x3d\x00\xae\x2a\xb8\x10\xd5\x00\x00\x00")
+
+func _000015_friend_requestsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000015_friend_requestsDownSql,
+		"000015_friend_requests.down.sql",
+	)
+}
+
+func _000015_friend_requestsDownSql() (*asset, error) {
+	bytes, err := _000015_friend_requestsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000015_friend_requests.down.sql", size: 213, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000015_friend_requestsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x51\x6e\xdb\x46\x10\x86\xdf\xf7\x14\xf3\x26\x0b\x60\x7a\x80\xf2\xc9\x88\x55\x20\x80\xed\xb6\x8e\xfc\x4c\xd0\xdc\x91\xba\x08\xb9\xa4\x97\x4b\xd4\x7e\xb3\xa4\x36\x45\xe1\x36\x3a\x44\x2f\xc0\x1a\x16\x4a\x28\x24\x73\x85\x99\x1b\x15\xa4\x28\x4b\xb4\x5c\x20\x46\xf9\x44\xcc\xfe\xf3\xcd\x3f\x33\xbb\x57\x38\x55\xda\x15\xe2\xcd\x1b\xa0\xbf\xe8\x91\x72\xfa\xcc\x4b\x5a\x51\x09\xf4\xc8\x0b\xfa\x9b\x3e\x53\x41\x6b\xca\x79\xce\xf7\x40\x5f\xa8\xa6\x47\xfa\x42\x05\xcf\xa8\xa6\xf5\xb7\x70\x72\x72\x0a\xf4\x00\x67\xb7\xef\x7f\x3c\x05\xfe\xa5\xd1\xf2\x8c\x0a\xbe\xe3\x05\xad\x78\x0e\x3c\xe7\x3b\xca\xa9\xa2\x7f\x28\xa7\x35\x7f\xa4\x82\x3f\x01\x55\xb4\xe2\x25\x3d\x50\x45\xb5\xd3\x42\xf9\x4f\x9e\x53\x4d\x25\x2f\xa0\x61\xf3\xaf\x6d\x9d\x8a\x72\x5e\x02\xcf\x29\xdf\xf8\xe0\x8f\x94\xb7\xc9\x40\x35\xff\x46\x05\xff\x4e\x79\x53\x85\x67\xbc\xfc\xa6\xeb\xa0\x6a\x2c\xb4\x5d\xfc\x41\x15\xdf\xd3\xaa\x6f\x7a\x4d\x05\xd0\x9a\xea\xb6\x87\x3b\x5e\xf0\xa7\x4d\x7a\xd3\x44\x63\x87\x1e\x9a\x58\xaf\x26\x2f\x80\x67\xc0\x8b\x03\x74\x09\x54\x50\x45\x8f\xb4\x6a\x9b\xae\xa9\x74\xa0\xe9\x93\xe7\xed\xfc\x7a\x8c\x7b\x68\xe3\x35\x95\x94\xf3\x5d\x53\x09\xa8\xa4\x15\x55\xbc\xdc\x59\x28\x69\xc5\xb3\x36\xad\xa4\x42\x48\x13\x27\x60\xfd\xab\x10\x41\x4d\x00\x6f\x54\x6a\x53\xc8\x52\x34\xde\xc4\x28\xd4\xd2\x93\x28\xb3\xc4\x15\x81\x41\xdf\x62\xa7\x3c\x38\x87\x50\x7d\xe8\x85\x5d\xe1\x87\x16\xcd\x7f\xe9\x05\x00\x80\x2f\x25\x64\x5a\x5d\x67\x08\x4a\x4b\xbc\xe9\xc9\x12\x5f\x19\x2f\xbb\x86\xa3\x36\xa8\xa4\x03\xdd\x81\x92\x43\x57\x28\x9d\xa2\xb1\xa0\xa6\x3a\x36\x4d\xb6\x8d\x0f\x6b\xbc\x98\x2a\x52\x0c\x31\xb0\x70\x78\x24\x26\x26\x8e\xf6\x29\xe2\xe7\x9f\xd0\x74\xde\x95\x04\x95\x82\x8e\x2d\xe8\x2c\x0c\x05\x80\xaf\xe5\x2e\x75\xff\xcc\x15\x06\xb5\x1f\xbd\x30\x29\x78\x66\x32\x0e\xa5\xd3\x0b\x6c\x26\xd9\x57\xb9\xfb\x0b\xda\x17\xc7\xa1\x74\x45\x7f\x2b\x6a\xd2\x3a\xec\x76\xd8\xe9\x0c\x5e\x67\x98\xda\x54\x1c\xb5\x33\x57\x12\x76\xdf\x95\x9a\x2a\x6d\x21\x31\x2a\xf2\xcd\x2d\x7c\xc0\x5b\x38\xbe\x1c\x7f\xef\xbd\x3b\x7f\x7b\x31\x3a\x1b\x9d\x8f\xc1\xcf\x6c\xec\x29\x1d\x18\x8c\x50\x5b\xa7\x45\x34\x73\xf2\xb6\x53\xe9\x10\x5b\xe2\xeb\xbf\xed\xdc\x36\x6c\x1b\x3f\x91\x9f\xec\x6d\x95\xff\x97\x9d\x5a\xdf\x66\x69\x77\x84\x3a\x8b\xe0\x68\x90\xa0\x96\x4a\x4f\x07\xce\xc0\x0f\x02\x4c\x2c\xca\x81\x33\x90\x18\x84\x4a\xb7\xbf\x81\xaf\x03\x0c\x43\x94\x83\x21\x48\x9c\xf8\x59\x68\xe1\x29\xe9\x19\x7f\xb3\x0b\xe9\xf9\x16\x00\x4e\x8e\xc7\xa3\xf1\xbb\xb3\x11\x9c\x8c\xbe\x3b\xbe\x3c\x1d\xc3\xdb\xcb\x8b\x8b\xd1\xf9\xd8\x6b\x82\xef\xc7\xc7\x67\x3f\x74\x46\xbe\xce\x7b\x96\xc8\x1d\xdb\xaa\x08\x53\xeb\x47\xc9\x56\xfb\xda\xaf\xe1\x42\xac\x3b\xea\xa1\x35\x31\x74\x45\xef\xfd\x3e\xbf\x4b\x2f\xbe\xde\x67\xa2\xdd\x0b\xde\xbf\x30\xce\xde\x8a\x87\xee\x57\x15\x79\x99\xde\xdc\x4b\x79\x03\x47\x3b\x9c\xd3\x6d\xd8\xd9\xdb\xc4\x10\xb2\x54\xe9\x29\x5c\x59\x83\xe8\x0a\x11\xc4\x51\xa4\xac\x2b\xfe\x1d\x00\x7d\x3f\xd4\x81\x90\x06\x00\x00")
+
+func _000015_friend_requestsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000015_friend_requestsUpSql,
+		"000015_friend_requests.up.sql",
+	)
+}
+
+func _000015_friend_requestsUpSql() (*asset, error) {
+	bytes, err := _000015_friend_requestsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000015_friend_requests.up.sql", size: 1680, mode: os.FileMode(436), modTime: time.Unix(1792413674, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000016_user_blocksDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x33\x00\xcc\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x75\x73\x65\x72\x5f\x62\x6c\x6f\x63\x6b\x73\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x6c\x7d\xde\xbe\x33\x00\x00\x00")
+
+func _000016_user_blocksDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000016_user_blocksDownSql,
+		"000016_user_blocks.down.sql",
+	)
+}
+
+func _000016_user_blocksDownSql() (*asset, error) {
+	bytes, err := _000016_user_blocksDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000016_user_blocks.down.sql", size: 51, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000016_user_blocksUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\xc1\x6a\xc3\x30\x0c\x86\xef\x7a\x0a\xdd\x92\x40\xde\x20\xa7\xb0\x66\x30\x58\xc7\xe8\xd2\xb3\xb1\x63\x2d\x88\xd8\xce\xb0\x65\x48\xdf\x7e\x24\x0d\x5d\x19\x63\x87\xea\x24\x24\x3e\xa1\xef\x37\x34\x72\x68\x00\x86\x48\x5a\x08\x45\x1b\x47\xc8\x9f\x18\x66\x41\x5a\x38\x49\xc2\x9c\x28\x2a\xe3\xe6\x61\x4a\x50\x02\x22\x5e\x27\x6c\xd7\xd6\xf0\xc8\x41\xf0\xbf\x5a\x4f\x85\xec\x5c\xbd\xb1\xa2\xe3\x48\xa2\xd8\x3e\xc0\x4e\x1c\xec\xbe\xa0\x90\x3d\x96\xc5\xf6\x56\x51\x17\x3e\x0b\x15\xd5\xbe\xfb\x93\xbd\x0a\x5a\xa5\x05\x0f\x6d\xdf\xf5\x2f\xc7\x0e\x0f\xdd\x73\x7b\x7e\xed\xf1\xe9\x7c\x3a\x75\x6f\xbd\x5a\x87\x1f\x7d\x7b\x7c\xff\xc5\x7e\x45\xf6\x3a\x5e\x70\xa2\x0b\x96\xbb\x7c\xfd\x63\x52\x41\xd5\x00\x68\x27\x14\xf7\x00\xef\x23\x5b\x7f\xd1\xd6\x22\x07\x4b\xcb\x7d\x98\xea\x76\x60\xc1\xf2\xd6\xd7\x9b\x65\x85\x39\x71\x18\xd1\x48\x24\x6a\x00\x86\xd9\x7b\x96\x06\xbe\x07\x00\xf3\xb8\x22\x52\xaf\x01\x00\x00")
+
+func _000016_user_blocksUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000016_user_blocksUpSql,
+		"000016_user_blocks.up.sql",
+	)
+}
+
+func _000016_user_blocksUpSql() (*asset, error) {
+	bytes, err := _000016_user_blocksUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000016_user_blocks.up.sql", size: 431, mode: os.FileMode(436), modTime: time.Unix(1792409737, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000017_follow_countsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcb\xbb\x0d\x83\x31\x08\x06\xc0\x9e\x29\xbe\x01\xb2\x81\x87\x89\xfc\x20\x16\x12\x86\x08\x63\x65\xfd\x34\x7f\xeb\xfe\xae\xf1\x14\x2b\x44\x55\x93\x03\x59\x9b\x32\xce\xe6\xd8\x04\x00\x23\xfc\x8b\xee\x7a\x96\xe1\xe3\xaa\xfe\xe3\xd8\xef\xee\xc7\xf2\x75\x01\x62\xf3\x0a\x42\xd8\xc6\xf3\x0b\x51\xf7\xb5\x24\x0b\xfd\x07\x00\x1e\x25\xac\x6c\x84\x00\x00\x00")
+
+func _000017_follow_countsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000017_follow_countsDownSql,
+		"000017_follow_counts.down.sql",
+	)
+}
+
+func _000017_follow_countsDownSql() (*asset, error) {
+	bytes, err := _000017_follow_countsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000017_follow_counts.down.sql", size: 132, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000017_follow_countsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x50\x4b\x6e\xc2\x30\x14\xdc\xfb\x14\xb3\x84\xaa\xa0\xee\xa3\x9c\x05\x85\xd8\xa1\xae\x1c\xbb\x72\x6c\xb1\x06\x16\x88\x4d\xbb\xec\x35\x50\x55\xa4\x88\xd2\x72\x85\xf7\x6e\x54\x11\x4a\x5b\x3e\xa9\x44\xb2\xf1\xfb\xcc\xcc\x9b\x19\xaa\x91\xb6\x89\x10\x99\x09\xca\x23\x64\x43\xa3\x10\x2b\xe5\x2b\x01\x00\x99\x94\xc8\x9d\x89\xa5\x45\xe1\x8c\x71\x63\xe5\xab\x41\xee\xa2\x0d\x18\xea\x91\xb6\x01\x52\x15\x59\x34\x01\x77\xb0\x2e\xc0\x46\x63\x6e\x2f\x23\xb5\x1d\x5d\x8f\xf4\x5a\x59\x79\x50\x44\x3b\x32\x11\xa2\xd7\x03\xbd\xd0\x92\xb6\xf4\x49\xef\xf4\xc1\xcf\xb4\xa2\x0d\x78\xc2\x73\x5a\xf1\x94\xe7\x54\xd3\x9a\x6a\xec\xe6\xbb\xee\x8c\x17\xb4\xe2\x09\x4f\xe9\x95\x67\xfc\xc4\x0b\xaa\x69\xd3\x4c\xe9\x8d\xb6\x54\xf3\x84\xd6\xb4\xa4\x8d\x88\x8f\x32\x0b\xdf\x99\x20\x8a\x4a\x05\xc4\xfe\xa9\xa5\x14\x9d\x4a\x19\x95\x07\x34\x75\xe7\xa6\x8b\xc2\xbb\xb2\x41\x0d\xf6\x2e\x50\x60\x7c\xaf\xbc\x42\xd1\x6f\xba\x5a\x22\x45\x3c\xbc\xbb\x7b\xef\xb1\x7f\x1a\xf3\x75\xcc\x7b\xa9\x56\xee\x93\x38\xcf\xb9\x9b\xc5\x8b\xff\xb9\x68\xfb\xee\xcf\xf7\xe0\xb4\x3d\x02\x79\x38\x0b\x7f\x38\x0c\xe9\xd1\xc9\xd9\x6e\xe1\x4f\x9d\xfe\x46\xd5\xae\xf5\x5f\xa6\x89\x10\xb9\x2b\x4b\x1d\x12\xf1\x35\x00\xe6\x34\xf4\xfa\xe7\x02\x00\x00")
+
+func _000017_follow_countsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000017_follow_countsUpSql,
+		"000017_follow_counts.up.sql",
+	)
+}
+
+func _000017_follow_countsUpSql() (*asset, error) {
+	bytes, err := _000017_follow_countsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000017_follow_counts.up.sql", size: 743, mode: os.FileMode(436), modTime: time.Unix(1792409973, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000018_users_searchDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x77\x00\x88\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x75\x73\x65\x72\x73\x0a\x20\x20\x20\x20\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x75\x73\x65\x72\x73\x5f\x69\x6e\x74\x65\x72\x65\x73\x74\x73\x5f\x66\x74\x3b\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x75\x73\x65\x72\x73\x0a\x20\x20\x20\x20\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x75\x73\x65\x72\x73\x5f\x63\x69\x74\x79\x5f\x69\x64\x78\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x33\x76\x15\x92\x77\x00\x00\x00")
+
+func _000018_users_searchDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000018_users_searchDownSql,
+		"000018_users_search.down.sql",
+	)
+}
+
+func _000018_users_searchDownSql() (*asset, error) {
+	bytes, err := _000018_users_searchDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000018_users_search.down.sql", size: 119, mode: os.FileMode(436), modTime: time.Unix(1792410797, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000018_users_searchUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8c\x41\x0a\xc3\x20\x14\x44\xf7\xff\x14\xb3\x4c\xce\xe0\x61\x44\xe3\x24\x7c\x30\x16\x74\x04\x7b\xfb\x52\x0a\xa5\xab\xee\x66\x78\xbc\x97\x79\x79\x0b\x66\xa9\x8a\x1d\x4a\xb9\x12\x73\xb0\x0f\x03\x80\x54\x0a\xce\x59\xab\xb8\x04\x6f\x85\xeb\x03\xa3\x37\xb1\x73\x68\xc4\x53\xd8\xbe\x6f\x0f\x7f\x42\xbf\xfe\xe1\x7a\x46\x2f\x0b\xdb\x7b\xed\x98\xc3\xdb\x85\xac\x4e\x06\xb3\xe3\x71\xdf\xae\x60\xaf\x01\x00\xc6\xb7\x7d\x60\x9d\x00\x00\x00")
+
+func _000018_users_searchUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000018_users_searchUpSql,
+		"000018_users_search.up.sql",
+	)
+}
+
+func _000018_users_searchUpSql() (*asset, error) {
+	bytes, err := _000018_users_searchUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000018_users_search.up.sql", size: 157, mode: os.FileMode(436), modTime: time.Unix(1792410059, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000019_chat_managementDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6f\x00\x90\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6d\x65\x73\x73\x61\x67\x65\x73\x0a\x20\x20\x20\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x60\x73\x79\x73\x74\x65\x6d\x60\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x63\x68\x61\x74\x73\x0a\x20\x20\x20\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x62\x79\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x6d\xdf\xd1\x4f\x6f\x00\x00\x00")
+
+func _000019_chat_managementDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000019_chat_managementDownSql,
+		"000019_chat_management.down.sql",
+	)
+}
+
+func _000019_chat_managementDownSql() (*asset, error) {
+	bytes, err := _000019_chat_managementDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000019_chat_management.down.sql", size: 111, mode: os.FileMode(436), modTime: time.Unix(1792411874, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000019_chat_managementUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x91\xcf\xca\xda\x40\x14\xc5\xf7\xf3\x14\x77\xa9\x50\x83\x5d\x8b\xcf\xa2\x93\x99\xab\x0e\x4c\x32\x61\x66\x42\x71\xe7\x1f\x8a\xb8\x29\x7d\x94\x34\x6d\x40\x5a\x4d\x5f\xe1\xcc\x1b\x15\x83\x14\x69\xf9\x3e\xb9\xcb\x7b\xce\xb9\xf7\xf0\xcb\x79\x6d\xca\x99\x10\xd2\x46\xf6\x14\x65\x6e\x99\xd4\x46\xc6\x20\x88\x88\xa4\xd6\xa4\x9c\xad\x8b\x92\x94\x67\x19\x59\x2f\xf2\x2d\xe5\x66\x6d\xca\x48\x9a\x57\xb2\xb6\x91\xa6\x54\xba\x48\x65\x6d\xed\x4c\x88\xc9\x84\xf0\x15\x6d\x3a\xa0\x4f\x3b\xf4\xb8\x52\xda\xa7\x63\x3a\xa3\x4b\xfb\x74\x40\x9b\x8e\xe9\x4b\x3a\xe3\x92\x3e\x53\x3a\xa1\xb9\xeb\xd0\xde\x35\x27\x5c\xd2\x01\x0d\x3a\x5c\x09\xbf\xd1\xa5\x1d\x5a\xf4\xf8\x8e\x9e\xf0\x03\x3d\xbe\xa1\x41\x8b\x5f\xe8\x70\xc3\xed\xb1\x48\xc7\x21\xe3\x1e\x7c\xc3\x05\x3f\xd1\x88\xba\xd2\x32\x3e\x2a\x90\x12\x81\x23\xa9\xec\xe9\xf7\x39\x29\x27\x2d\x07\xc5\xa3\x51\x60\xcb\x2a\x52\x95\xd5\x81\xfd\xc2\xe8\xa1\xf2\x9b\xb3\xf2\xae\x18\x72\x17\x95\xf4\xd1\x28\x53\xc9\x32\x06\xaa\xde\x77\x7d\xda\xb0\x67\xaa\xb2\xc1\x68\x34\xcd\x49\x65\xaf\x2e\x39\xaf\xd9\x53\xbe\xa5\xea\xa5\xd4\x9a\xc2\x44\xfa\x38\xfe\x40\xd3\xf1\x3f\x14\x0b\x0e\x41\xae\xf9\x3f\x90\xcb\xb0\x0d\x91\x8b\x25\xe5\xce\xd9\xbf\x10\x57\xd2\x06\x7e\x06\xa9\x5c\x51\x98\x38\x13\x7f\x06\x00\xf2\x34\xf7\xb1\x21\x02\x00\x00")
+
+func _000019_chat_managementUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000019_chat_managementUpSql,
+		"000019_chat_management.up.sql",
+	)
+}
+
+func _000019_chat_managementUpSql() (*asset, error) {
+	bytes, err := _000019_chat_managementUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000019_chat_management.up.sql", size: 545, mode: os.FileMode(436), modTime: time.Unix(1792411874, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000020_chat_rolesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\xcd\xcb\x0d\xc2\x40\x0c\x06\xe1\xfb\x56\xf1\x17\x40\x07\x29\x26\x72\x16\x0b\x2c\xf9\x25\xc7\x50\x3f\x07\xb8\xb1\x39\x8f\x46\xdf\xc1\x0f\xf1\x6d\x0c\xd2\xe6\x42\xd3\xa1\x8c\xf9\xa4\x3e\x07\x00\xdc\x2b\x12\x33\xf4\x65\x0e\xf1\xb7\x34\xef\x15\xca\xb7\xbf\x98\x71\xf6\x45\x2a\x76\xb2\xef\xb7\x70\xf6\xa4\x6a\x99\x92\xe4\x0b\xf3\x37\xcd\x30\x93\xde\xc6\x67\x00\x9a\x5c\x86\x5f\xad\x00\x00\x00")
+
+func _000020_chat_rolesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000020_chat_rolesDownSql,
+		"000020_chat_roles.down.sql",
+	)
+}
+
+func _000020_chat_rolesDownSql() (*asset, error) {
+	bytes, err := _000020_chat_rolesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000020_chat_roles.down.sql", size: 173, mode: os.FileMode(436), modTime: time.Unix(1792412024, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000020_chat_rolesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x8f\x41\x4e\xec\x30\x10\x44\xf7\x3e\x45\xed\xfc\xbf\x14\xe5\x02\xd1\x9c\x25\xea\xb8\x1b\x68\x64\xb7\x2d\xbb\x0d\xe2\xf6\x88\x0c\xb0\x80\xd9\x20\xd8\x56\x49\xaf\xea\x1d\x72\xaf\xb6\x85\x40\xd9\xa5\xc3\xe9\xc8\x82\xf4\x40\xbe\x37\xea\xae\x49\x1b\x99\x8f\x00\x00\xc4\x8c\x54\xf3\x2c\x86\x5e\xb3\x40\x6c\x16\xfc\x8b\xf5\xd9\xa4\xc7\x25\x12\x17\xb5\xb8\xc4\x22\xe5\x90\x1e\xff\x83\xe5\x8e\x66\x76\x7c\x24\xb0\xea\xb0\x99\xf3\x16\xc2\x6c\x4c\x7e\x63\x08\xed\x9c\x7a\xac\x6a\x67\x39\x90\x50\x0d\x69\x55\xc6\x05\x6d\x7d\xcb\x76\x65\x90\x31\xd2\x9a\xba\x90\x0b\xef\xc7\xcb\x59\xce\x21\x7d\x57\x0e\x43\x1c\x6d\x3d\x3f\x5e\xf0\xfe\xef\x86\xe1\x37\x2b\xb5\x27\x75\xd9\x7f\x27\xb7\x7c\xa5\xb6\x3a\xfc\xca\xc4\x1f\x52\xbb\x18\x95\x9f\x7e\xbd\x36\x9f\xd0\x2d\x84\x54\x4b\x51\xdf\xc2\xeb\x00\xf0\x7f\xc3\xd1\x06\x02\x00\x00")
+
+func _000020_chat_rolesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000020_chat_rolesUpSql,
+		"000020_chat_roles.up.sql",
+	)
+}
+
+func _000020_chat_rolesUpSql() (*asset, error) {
+	bytes, err := _000020_chat_rolesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000020_chat_roles.up.sql", size: 518, mode: os.FileMode(436), modTime: time.Unix(1792412024, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000021_chat_dialogsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5f\x00\xa0\xff\x62\x65\x67\x69\x6e\x3b\x0a\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x63\x68\x61\x74\x5f\x64\x69\x61\x6c\x6f\x67\x73\x3b\x0a\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x63\x68\x61\x74\x73\x0a\x20\x20\x20\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x64\x69\x61\x6c\x6f\x67\x3b\x0a\x0a\x63\x6f\x6d\x6d\x69\x74\x3b\x0a\x03\x00\x71\xf2\xeb\xcb\x5f\x00\x00\x00")
+
+func _000021_chat_dialogsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000021_chat_dialogsDownSql,
+		"000021_chat_dialogs.down.sql",
+	)
+}
+
+func _000021_chat_dialogsDownSql() (*asset, error) {
+	bytes, err := _000021_chat_dialogsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000021_chat_dialogs.down.sql", size: 95, mode: os.FileMode(436), modTime: time.Unix(1792412181, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000021_chat_dialogsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x54\xcd\x6e\xdb\x46\x18\xbc\xef\x53\xcc\x2d\x52\xa1\x08\x68\x8f\x71\xd4\x57\x31\x28\x72\x2d\x6d\x4b\x91\x2c\x77\xd9\xd8\x37\xcb\x46\x60\x04\x2a\xe2\x4b\xcf\x05\xfa\x06\x8c\x62\x42\xf2\x8f\x98\x57\x98\xef\x8d\x8a\x5d\x51\x3f\x75\x55\x20\xd0\x81\x2b\x7e\xdf\xce\xce\x37\x33\xdc\xb1\x9e\x98\xec\x4c\xa9\x28\x75\xba\x84\x8b\xc6\xa9\x46\x3c\x8d\x9c\x55\x00\x10\x25\x09\xe2\x3c\xad\x66\x19\x12\x13\xa5\xf9\x04\xe3\x3c\x4f\x91\xe8\x8b\xa8\x4a\x1d\x2e\xa2\xd4\x6a\x64\xb9\x43\x56\xa5\xe9\x99\x52\x6f\xdf\x82\x7f\x72\xcd\x9a\xcf\x6c\xf9\x15\x7c\xe0\x52\x6e\xe5\x23\xf8\x8d\x2d\x9f\xe5\x0f\xae\xd8\x72\xc9\x5a\x6e\xd8\xf0\x99\x0d\x1f\xdf\xf9\x5a\x2d\xd7\xac\x21\x1f\xfd\x83\x1b\xae\xe5\x46\xe6\x72\x0f\xb9\xf5\xfb\xe4\x5a\xee\xf9\xc0\x56\xee\xd8\x70\xc3\x0d\x5b\x3e\xa2\x57\x59\x5d\x9e\xa7\xf9\x07\xbc\x47\x58\x4e\xcd\x64\xda\x57\x71\xa9\x23\xa7\xbb\x39\xcc\x45\xe0\xa6\x2f\x8d\x75\x36\x4c\x75\xbe\x9d\xc2\xaa\x5e\x18\x6f\x8f\x81\xb1\x99\x98\xcc\xed\x47\x19\x1c\xca\x1e\xf7\x74\x39\x00\x9a\x04\x38\x5d\x2e\x4a\x33\x8b\xca\x2b\xfc\xaa\xaf\x0e\x6c\x07\x47\x64\xbb\x43\x32\xf3\x5b\xa5\x43\xd7\x31\xc3\xf3\x0e\xfd\x12\xbd\x6e\xd5\x57\xfd\x4e\xe1\xbf\xe5\x56\x3e\xb1\x91\xb9\xdc\x04\x79\x3f\xcb\x27\xae\xd9\x40\xee\xbc\xb0\xb2\x78\xe7\x75\xdf\x9b\xc0\x96\x2f\x90\xb9\xdc\x79\x59\x59\xb3\xd9\x89\x3b\x67\xcd\x17\x59\xf0\x11\x9d\xec\x1b\xae\xfd\x9f\x80\x02\x7e\x61\xc3\x15\xb8\x61\xcd\x95\xb7\x2c\xf8\x72\x3f\x00\x97\xe0\x13\x5b\xb9\xf1\xce\x04\x70\xae\x58\x83\x4b\x99\xb3\xf1\x8f\x6b\x36\x7c\x91\xfb\x40\xf5\x8b\x2c\x7c\x14\xfc\x09\xde\xf7\x0d\x5b\xcf\x6d\xe9\xcd\xbe\x0d\x74\xfd\x0c\xfe\xdc\x27\xd6\xe8\xc9\x9c\x2d\x57\x7c\xf0\x87\xf9\x43\x6a\x3e\xf9\x74\x34\x01\xf2\x1b\xd7\x32\xf7\x2f\xbf\x27\x55\xfd\x21\xf8\x57\x10\xa8\x0e\x0d\x1b\x59\x1c\x09\x04\xb6\xdb\x9a\x7c\xee\xc4\xe0\x57\xb9\x0e\x69\xf3\x39\x5d\xca\x82\x2f\x5c\x2b\x93\x59\x5d\x3a\x98\xcc\xe5\xff\x4a\xcf\x49\x3b\x07\xbb\x3c\xf4\x95\xd5\xa9\x8e\x1d\xdc\xf0\xd0\xd6\xad\xbd\xef\x03\xcc\x4c\xd6\x73\xc3\x7d\xfb\x45\x99\xcf\xd0\xeb\x36\x15\xbb\xf7\xdb\xb6\x62\xbb\xcf\x24\x7d\x44\x16\x07\xbc\x59\x74\x79\xaa\xe6\xf1\x43\xaa\x80\x80\x1a\xb0\x8a\xa8\x74\x26\x36\x45\x94\x39\x8b\xa2\x2b\xef\x7f\xbf\xe4\x26\x0b\xd4\x2d\x62\xe4\x19\xe2\xa1\x49\x30\x3a\xf0\xe8\xfa\x3f\x4c\x75\xa9\x11\x0f\x9d\x71\xa9\xc6\x08\x6f\xde\xec\x81\xa2\x2c\x41\x3c\x8c\xd3\xdc\x6a\xbf\x33\x5c\x0a\x5d\x71\x52\xe6\x55\x81\xf1\xd5\x7f\xe0\xa6\xd1\xef\x26\x9b\x20\xce\xab\xcc\xf5\x7e\xe8\x63\x84\x9f\x14\x8e\x01\x6d\x35\xeb\x15\x43\xeb\x22\x57\x59\x8c\xf0\xe3\x89\x96\x57\xfa\xbc\xff\xf9\x95\x2a\x7d\x38\xb5\x27\xf0\x3f\x66\x9c\x29\x55\x15\x89\xbf\x32\xfc\xb8\x56\x59\xed\x76\x37\xdd\x08\xae\xac\xb4\xda\x0e\x6e\x12\x98\x6c\x6f\x52\x37\xcb\x91\xc6\x5d\x32\xfc\x17\x1a\xe7\xb3\x99\x71\x67\xea\x9f\x01\x00\x0c\x28\x18\x0d\x5a\x05\x00\x00")
+
+func _000021_chat_dialogsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000021_chat_dialogsUpSql,
+		"000021_chat_dialogs.up.sql",
+	)
+}
+
+func _000021_chat_dialogsUpSql() (*asset, error) {
+	bytes, err := _000021_chat_dialogsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000021_chat_dialogs.up.sql", size: 1370, mode: os.FileMode(436), modTime: time.Unix(1792413482, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _bindataGo = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x9a\xdf\x6e\xe3\xc6\x92\xc6\xaf\xa5\xa7\xe0\x31\x70\x0e\xa4\x85\xd7\x43\xb2\xf9\xd7\xc0\xdc\x9c\x24\x0b\xe4\x62\x73\x80\x4d\x72\xb5\xbd\x30\x9a\x64\xd3\x11\xd6\xb6\x1c\x49\xce\xf6\xcc\x60\xde\x7d\xf1\xeb\x2a\x59\xb2\x24\x27\x33\x1e\x0d\xa0\xb1\x44\xb2\xbb\xab\xaa\xab\xbe\xfa\xaa\x9a\xef\xde\x25\xdf\x2d\x07\x9f\xdc\xfa\x07\xbf\x72\x1b\x3f\x24\xdd\x87\xe4\x76\xf9\xef\xdd\xe2\x61\x70\x1b\x77\x35\x7d\xf7\x2e\x59\x2f\x9f\x56\xbd\x5f\x5f\xf3\x3d\xe5\x5f\x76\xb3\x78\x58\x6c\xae\x9e\x1e\xaf\xd6\xbf\xdf\x3d\x5f\xcd\x6f\x16\x0f\x83\x0f\x7e\x7d\x78\xc3\xbc\x76\xa3\xb8\x79\x5c\xae\x37\x47\x97\xcb\x9b\x7e\x79\x77\xe7\x36\xfe\xf0\x46\x75\xd3\xff\xe6\x56\x6b\x7f\xb4\x74\x7d\x33\x6c\x9e\x1e\x87\x13\x43\x9a\x9b\xc1\xdf\x3d\x3d\x0e\x87\xd7\xdb\x9b\x61\xe1\xee\x96\xb7\x87\x8b\x67\xe9\xcd\xd3\xda\xaf\x6e\xd6\xbf\xb9\xd5\xfe\xa0\xad\x3d\x6e\x97\xfc\xba\x5f\xdc\xae\xdc\x66\xb1\x7c\x58\xeb\x85\xef\xff\x95\xfc\xf4\xaf\x5f\x92\x1f\xbe\xff\xf1\x97\xbf\x4d\xa7\x8f\xae\xff\x5f\x77\xeb\xf7\x1e\x9b\x4e\x17\xf7\x8f\xcb\xd5\x26\x99\x4d\x27\x17\xdd\x87\x8d\x5f\x5f\x4c\x27\x17\xfd\xf2\xfe\x71\xe5\xd7\xeb\x77\xb7\x1f\x17\x8f\x5c\x18\xef\x37\xfc\x59\x2c\xe5\xff\x77\x8b\xe5\xd3\x66\x71\xc7\x8f\x65\x1c\xf0\xe8\x36\xbf\xbd\x1b\x17\x77\x9e\x2f\x5c\x58\x6f\x56\x8b\x87\xdb\x78\x6f\xb3\xb8\xf7\x17\xd3\xf9\x74\x3a\x3e\x3d\xf4\x5b\x79\xff\xcb\xbb\x61\xc6\x97\xe4\xbf\xff\x87\x65\x2f\x93\x07\x77\xef\x13\x19\x36\x4f\x66\xdb\xab\x7e\xb5\x5a\xae\xe6\xc9\xa7\xe9\xe4\xf6\x63\xfc\x95\x5c\xbf\x4f\x90\xea\xea\x27\xff\x7f\x4c\xe2\x57\xb3\x28\x36\xbf\xff\xf9\x34\x8e\x7e\x15\xa7\x9d\xcf\xa7\x93\xc5\x18\x07\xfc\xed\x7d\xf2\xb0\xb8\x63\x8a\xc9\xca\x6f\x9e\x56\x0f\xfc\xbc\x4c\xc6\xfb\xcd\xd5\x0f\xcc\x3e\xce\x2e\x98\x28\xf9\xfb\xef\xd7\xc9\xdf\xff\xb8\x10\x49\xe2\x5a\xf3\xe9\xe4\xf3\x74\x3a\xf9\xc3\xad\x92\xee\x69\x4c\x64\x1d\x59\x64\x3a\xb9\x11\x71\xde\x27\x8b\xe5\xd5\x77\xcb\xc7\x0f\xb3\x7f\x74\x4f\xe3\x65\x72\xfb\x71\x3e\x9d\xf4\x77\x3f\x6c\x25\xbd\xfa\xee\x6e\xb9\xf6\xb3\xf9\xf4\x5c\xf2\x30\x8d\xcc\xff\xca\x44\x7e\xb5\x12\xb9\xf5\x62\xf7\x34\x5e\xfd\x13\xd1\x67\xf3\x4b\x9e\x98\x7e\x9e\x4e\x37\x1f\x1e\x7d\xe2\xd6\x6b\xbf\xc1\xe4\x4f\xfd\x86\x59\xa2\x7e\xba\x1f\xd3\xc9\xe2\x61\x5c\x26\xc9\x72\x7d\xf5\x1f\x8b\x3b\xff\xe3\xc3\xb8\x7c\x1e\xa7\x5b\xb8\xbd\xbe\x37\x43\xdc\xc3\x24\xd1\x6d\x9c\x4e\xd6\x8b\x8f\xf1\xf7\xe2\x61\x53\x15\xd3\xc9\x3d\x01\x9d\x3c\x4f\xfa\x9f\xcb\xc1\xc7\x8b\xbf\x2c\xee\x7d\x82\x9b\x5c\xf1\x8d\x75\xa2\xab\xcc\xc6\xc5\xe1\x5a\xf3\xe4\x27\x77\xef\x67\x73\x5d\x81\x35\x55\xcb\x71\x71\xc5\xea\xd3\xcf\x7f\x32\xf6\xe7\xc5\x47\xc6\x46\x69\x5e\x0e\x45\xd0\x3f\x1d\x8a\xac\xb3\xf9\xbe\xe4\x2f\x27\x40\xb5\xbf\x9a\x00\xe5\x66\xf3\x9d\xa2\x47\x33\xa8\xf6\xaf\x4f\xf2\xe3\xfa\xfb\xc5\x6a\x36\x4f\xba\xe5\xf2\x6e\x7f\xb4\xbb\x5b\xff\x85\xe6\x1f\xd6\xa2\xb8\x5f\x8d\xae\xf7\x9f\x3e\xef\x8d\x56\x97\xc0\xcb\x6f\x6e\xf6\x60\xf4\xd7\xc7\x9f\x7f\xbf\x4b\xde\xab\x43\xcc\x2e\x6c\xc8\x46\x1b\x9a\xce\x86\xb4\xb1\x21\x4d\x4f\x7f\x46\x9e\x29\x6c\x68\x33\x1b\xfa\xcc\x86\xc2\xdb\xd0\x1b\x1b\x0c\xf7\x7b\x1b\x9a\xca\x06\x3f\xda\x50\xb7\x36\xa4\xce\x86\x61\xb4\x61\xa8\x6c\x28\x9c\x0d\xa6\xb3\xa1\x2d\x6c\xa8\x5a\x1b\x5c\x6a\x43\xd1\xca\xb5\x3c\xb3\xa1\x2b\x6c\x48\x8d\x0d\x69\x2d\x73\xb0\x46\x5f\xd9\xd0\xb5\x32\xb6\xec\x6c\xe8\x6a\x1b\x3a\x63\x43\xd1\xd8\xd0\xf6\x36\xf4\xad\xcc\x51\xa5\x36\xd4\x83\x0d\x75\x67\xc3\x50\xd8\xe0\x2a\x1b\x4a\x64\x2a\xe5\x9e\xcf\x6d\xf0\x95\x0d\xa3\xb3\x61\x34\x36\x8c\xb5\x0d\x86\x75\x5a\x1b\xf2\xce\x06\x8f\xdc\x8d\xcc\xcf\x5a\x43\x69\x43\x93\xdb\x60\x9c\x0d\x39\x7a\x15\x36\x94\x83\x0d\x59\x2b\xdf\x2b\x67\x43\x93\xc9\x35\x6c\x62\x7a\x1b\x5a\x64\x1f\x6d\xc8\xbc\x0d\x2e\xb7\xa1\xa8\x6d\x18\x33\x1b\x72\x27\xb2\xc4\xe7\x52\xb1\x45\x5e\x8a\x6c\x5c\x2b\xf9\x64\xf2\x7c\xd6\xdb\xe0\x53\x1b\x72\xd6\x28\x6c\xe8\x4a\x1b\xc6\xc2\x86\x31\x95\xf5\xcc\x20\x6b\x75\x5e\xf6\xaa\xc4\xf6\xc8\xcf\x5a\x83\x0d\x83\xb1\x61\xe0\xb7\xb7\xa1\x2a\x45\x1f\xc3\x7e\x31\xde\xcb\x7e\xb5\xa5\x0d\xbd\xce\x1d\xf7\x00\x39\x74\x9e\x21\x13\xbb\x38\x6f\x43\x6e\x44\x17\xf6\x70\x6c\xc4\xae\x65\x2e\xeb\x32\x16\xf9\x5c\x27\xba\xf6\x8d\x0d\x4d\x2d\xfb\xee\x33\xf9\x8e\x2e\xcd\x20\xfb\x53\x1b\x1b\xaa\x46\x74\x6e\x5b\x19\xc7\xbe\x76\x7b\xe3\x33\x23\xbe\x90\x0d\xf2\xf1\xba\x7f\x3c\xd3\x8d\xb2\x0f\x7e\x10\x3d\xdb\x5a\xec\x5d\xe1\x57\x95\xd8\xdd\x77\x36\x8c\xbd\xd8\xd1\x60\x3f\x7c\x4d\xf7\xb6\x6c\x6d\x28\x47\x1b\xaa\xc1\x86\xbc\x12\x9f\xe4\x39\x64\xc1\xb6\xd5\x28\x3e\xc3\x5a\xc8\x8b\x1f\x76\xf8\x41\x2f\x3e\x88\x2c\xf8\x33\xfb\x9e\xeb\x5e\xa5\xd8\xab\xb5\xa1\xcf\xd5\x1f\x8c\xc4\x8e\x2f\x55\x27\x64\xc7\xde\x8d\xd8\x7b\x70\x3b\x5b\x0f\xb9\xc4\x11\xfe\x54\xaa\x7f\xf8\x46\xe4\x40\x77\xfc\xdf\x34\xb2\x3f\xf8\x43\xa7\xfb\x3f\x62\xbb\x41\x7c\x08\xdd\xca\xde\x06\xd7\x8a\xde\xcc\x47\x0c\xb0\xbf\x3c\x93\x11\x13\xb9\xda\xde\x88\x3d\xf2\x56\xfd\x61\x90\x58\x8d\x3e\x53\xd8\x50\x0c\xb2\x1f\xbd\x17\x79\x52\x8d\xb7\xb1\x14\x79\xf6\x63\x9f\x4f\xda\x8a\xbc\x3d\x76\x4c\x6d\xc8\xc0\x8b\x7c\xfb\xdc\xc5\x96\x0a\x1c\x81\x8d\x66\xa9\x53\xd9\x7f\x9b\xcb\xf6\xd8\xc3\x74\x32\x39\xc6\xab\xcb\xe9\x64\x72\x71\xcc\x05\x2f\x2e\xa7\x93\xf9\x73\x62\x39\x1a\xc5\x9a\xff\x16\xd3\xe1\xfe\x9a\x31\x1f\x3e\x93\x8e\xd7\xa4\xfd\xab\xbc\xfe\x9c\x8e\x63\x42\xbd\x7e\x7f\x08\xce\x9f\x48\x5b\xd7\xc9\x49\xa1\x13\xf2\xd2\x75\x52\x9a\xea\x32\x21\xc3\x5c\xef\x27\xa0\x59\x61\xaa\x79\xbc\x4e\xde\xb8\x96\xbc\xf2\xeb\xc3\x22\xcc\xb2\xaa\xac\xb3\x22\xcf\x4c\x79\x99\xa4\xf3\xcf\xd3\x89\x63\xdd\x7f\x44\x05\x3f\x45\xad\xae\x13\x55\x0e\xa1\xae\xe3\xff\x9f\x9f\x8d\xec\x2e\x4f\xe4\x84\x67\x12\xfd\xf6\xb4\x00\x24\x37\xa3\x84\x51\xaf\x21\x11\xdd\x23\x95\x30\x1d\x3b\x71\xd1\xc6\x89\x2b\xd6\x0a\x17\xfc\xe5\x59\xa7\x6e\x19\x5d\xb0\x10\x18\x24\x64\x70\xff\x42\xe1\xd9\xf5\xe2\xa6\x79\xb3\x0b\x53\x42\x11\xe8\x4f\x33\x85\x77\x60\x01\xe8\xad\x05\xea\xdb\x54\xc3\x7e\x90\x39\x2a\x20\xa2\x95\x54\x43\xd8\x3b\x23\xe9\xac\x1d\x6c\x28\xf9\xed\x6c\xa8\x7a\x09\x59\x52\x06\x7a\x57\x1a\x22\x84\x11\xe1\x0e\x64\x31\xa6\xc8\x6d\x28\x8b\x9d\x1d\x80\xdd\xb2\x14\xa8\x1d\xbd\xc0\x3b\xe9\x04\x59\xaa\x4c\xe0\x04\xd8\x00\x7a\xd0\x15\x68\x21\x74\xea\x2d\x84\x39\x81\xcf\x08\x1b\x46\x52\x50\x84\xe0\x41\x6c\xd5\x6a\xba\x24\x95\xa0\x43\x87\xbd\x6a\x1b\x86\x46\xae\xe7\x83\x84\x25\x61\x0e\x9c\x03\xd3\xd8\x9e\x94\x8b\x0e\xa4\x5f\xd2\x12\x76\x60\x8f\xea\x54\xe0\x18\x1d\x5b\x4d\x13\xd8\x21\x23\x9c\x4b\xb5\xbb\xc2\x0d\x21\x0f\x04\xb7\x4e\x20\x90\xf5\xf8\x4e\x1a\x66\xdf\x81\xda\x02\xa8\xf3\x92\x6e\xa3\x5c\xc8\x94\x4b\xca\x8a\x29\x56\xe5\xcb\xf9\x5d\x4a\x3a\x77\xa4\x97\x5a\xe6\x74\x9a\xa2\xbc\xea\x4c\xba\x03\xc2\x80\x4c\xae\x65\xf9\x31\x1c\x19\xd5\x13\x1f\xf0\xad\xd8\x1f\x5f\x38\x09\x47\x2f\xfd\xfc\xad\x88\xf4\x72\x96\x1d\x28\x1d\x96\xa2\xa7\x70\xe9\xe5\xd8\x2f\x87\xa6\x93\x92\x9f\x15\x9d\x8e\xa5\x57\x80\x32\x45\xf6\xb5\x00\xd5\x96\x65\x95\x35\xf9\xf9\x00\xca\x7c\x3b\x40\xb9\x42\x9c\xbc\x57\x10\x6a\x94\xb7\xc2\x55\xc8\xd1\x5b\xde\xca\xbd\xad\x63\xf5\x5b\xe7\x55\x40\x81\xbb\x11\xc8\x59\x26\x63\xe0\x80\xe4\xe1\xac\xb6\xa1\x56\x30\x02\x20\xe0\x00\x80\x18\x5c\x04\x90\x30\x5e\xf2\x39\x00\x08\x10\xc2\x49\x19\x83\xb3\x03\x20\x00\x06\x81\x16\x03\xb3\x15\xb9\x00\x33\x78\x58\x94\xbd\x95\xe0\x82\xaf\xe2\xe0\x04\x13\xe0\x42\x40\x67\x95\xac\x03\x70\x10\x6c\xcd\x96\x73\x3a\x01\xcb\x2d\x80\xc1\x03\x7d\x21\xe0\x00\xa7\xc2\x16\x04\x0e\x5c\x1b\x2e\x09\x9f\x8a\x40\x0c\xb0\xa8\x7c\x05\x3c\x21\x17\xe0\x83\x07\x66\x85\x3e\x07\x10\x54\xa2\x5b\x9d\xc9\x7c\x8c\x01\x88\x22\x7f\x69\x45\x0f\xb8\x16\x32\x9b\x4a\xe6\x80\x13\x01\x14\x00\xf9\x90\x0a\x3f\x87\x7b\x02\xba\x70\x40\x40\x60\x50\x30\x64\x9e\x2d\x20\xc3\x6d\x09\xee\x5a\x41\x0b\xf0\x02\x10\xe0\xbb\x00\x2d\x76\x4b\x15\x80\xd0\x27\xef\x45\xae\xb8\xa7\x80\x69\x2a\x40\x1f\x01\x3d\x17\xe0\x85\x5b\xe7\xca\x5d\xd0\x0d\x3b\x17\x9d\xe8\x01\x17\xc3\xc6\xf8\x15\x89\x6d\xd8\x8e\xe9\x84\x17\x77\x0a\x66\xdb\x1a\xa0\x18\x85\x37\x63\x5f\xc0\xa7\xee\x45\x0e\x74\x46\x07\xb8\x24\x49\x85\xb9\x78\x3e\x73\xc2\x5b\xe1\xd2\xf0\x53\xfc\x69\x54\xee\x0f\x57\x8d\x09\xa3\x17\x1b\x03\xc4\x00\x7d\x04\xc3\x5e\xf6\xd5\x69\xa2\xc0\x37\xe1\xc3\xd8\x17\xdd\x49\x78\x87\x7e\x1f\xb9\x66\x2f\xdc\x1e\x7b\x67\x9a\x70\x5e\xe5\x6c\xe6\x2c\x20\x69\x5e\x01\xc9\xc3\xb6\xdc\x29\x90\x34\x6f\x04\xc9\x93\x92\x9f\x15\x24\x8f\xa5\x57\x90\xac\x4c\xfb\x16\x90\x2c\xce\xc9\xe2\xb4\xb1\xf9\x76\x88\xac\xb5\xb4\x8f\x39\xb5\x13\x08\x32\xca\xe1\x08\xc7\x5a\x4b\x4d\x20\x86\xef\x4d\xaf\x25\x91\xd1\x10\xd2\xf0\x07\x16\x72\x2d\x75\x71\xff\x46\x79\x0b\x21\x49\x69\x0d\xac\x31\xc6\x28\x8f\xe8\x6b\xe1\x00\x40\x21\x25\x09\x50\xc8\x73\xc8\x93\x2b\x94\xc4\x32\x70\x94\x7b\xb1\x0c\x2e\xb4\x9d\xa0\xf0\xe0\x94\x07\xc2\x61\x28\x89\x19\x9b\x6a\x88\x12\x7e\xf0\x48\xa0\x32\x42\x6f\x2f\x1c\xce\xe4\x02\xed\x95\x96\xcf\x84\x21\xcf\x66\xd8\xaa\x12\xae\x02\x6c\xc3\x69\xd1\x21\x53\x58\xa1\x34\xc2\x4e\x40\x4a\xd9\xec\xec\x0a\x34\xc1\xc1\xe0\x3f\xcc\xd7\x29\x84\x30\x5f\xa6\x3c\x87\x92\x0e\x18\xcb\x14\x4a\x46\x2d\xf5\xe0\xa3\xb1\xac\x1e\x04\x1a\xe0\xa6\xb9\xf2\x5e\xd2\x04\x7c\x14\x1d\x29\xc3\xe1\x86\xf0\x2c\xe0\x96\x79\xb0\x63\x84\x16\x23\xb2\x19\x6d\x1b\x50\x02\x7a\x85\xb3\x56\x6d\x0f\xf7\x84\xb7\x01\x35\x31\x2d\x95\x02\xc9\xc8\xea\x14\xfe\x81\x5f\x57\x8b\xfd\xa2\x2d\x6b\x95\xb7\x12\x3f\x89\xb0\xdc\x09\x44\xb1\x06\xba\x55\x5e\x6c\x0d\x1c\x45\xff\x70\xf2\x17\x0e\x48\x7a\x81\x3f\x16\xca\x09\x63\x4a\xab\x05\xa6\x8d\x96\xe5\xa3\x96\xb6\xd8\x13\xce\x0a\x47\x04\x1e\x07\x2d\xa7\x99\x03\x5f\x81\x57\xf6\xdb\x74\xe3\x95\xdb\xf6\x2a\xb3\x13\xfb\xa0\x53\x4c\x39\x46\xe0\x9f\x39\x6a\x85\x3d\xec\x44\x0a\x8d\x70\x3d\x88\x4f\x01\xdb\xa4\x53\xa7\xa9\xba\xd1\x94\xd2\xe9\xfe\x03\xc3\x70\xf1\x4a\xcb\x7d\x74\x88\xf3\x8e\xd2\x46\x62\x7d\xf6\x1d\xc8\x8f\x50\xaf\x3c\x94\x3a\x84\xf4\x06\xe5\x28\xd4\xd6\x70\x65\xf6\xa1\x57\x6e\x1b\x53\xaa\xca\x83\x8e\xec\x13\xd0\x6c\x8c\xa4\xef\x6d\x2b\x85\x78\x24\x9d\x61\x9f\x5e\x6b\x87\x5c\x53\xf9\xd8\x4a\x5a\x86\x4b\x93\x8a\xa8\x9b\xb8\xc6\x3a\x8c\x65\xee\x48\x15\x9c\xa4\x75\x6a\x09\x52\x19\xf6\xa3\x1e\xa0\xe6\x8a\xa9\xa5\x3c\x4e\x1d\x85\xa6\xcc\x58\x63\x0d\x5a\xbb\xbc\xc6\xaf\xf7\x11\xe8\xad\x89\x63\x7f\x8e\x5d\xda\x78\x79\x68\x73\x2a\x69\xec\x8f\xfb\xf2\x94\x71\x42\xe2\xb3\x26\x8c\x43\xb9\x35\x5d\x14\xe9\xd7\xa7\x8b\xda\x94\x69\x55\x9f\x2f\x5d\x3c\x1f\x78\x7d\x5b\x2f\x18\x2e\x46\xc2\x48\xbd\x70\xcc\x5c\x7b\xc1\x38\x13\x1c\x0f\x9e\x44\xb0\x7b\x2d\x52\x71\xe8\x42\x7b\xae\x95\x26\x80\x52\x0b\xd1\x18\x38\xb9\x38\x32\xc0\x4c\xa0\xc7\x9e\x9f\x7e\x08\x2e\xf8\x11\x45\x35\xdc\x0f\x90\x81\xf3\xe6\x4e\xd6\x8f\xfd\xb3\x5e\xe6\x00\x8c\x62\x10\xe8\x87\x24\x02\xf7\xf1\x5e\x64\x43\x6e\x40\x97\x20\x82\xc3\xe3\xe0\x11\x60\x3b\x09\xc4\x61\xd0\x26\x84\x97\x00\x26\xf8\xe1\xa0\x04\x1e\x40\x3e\x68\x31\x0d\x40\x16\xad\x82\x47\x29\x89\x0d\x99\xe2\xef\xfa\x38\xa0\x00\x6f\x78\x1b\xf5\x01\x81\x59\x9b\x7d\xbb\x1e\x04\xd4\xcb\x3d\x7a\x6b\x48\xbd\x9c\x65\x17\x54\x87\x47\x9e\xa7\xc2\xea\xe5\xd8\x2f\x0f\xac\x93\x92\x9f\x35\xb4\x8e\xa5\xd7\xe0\xca\xb2\xf2\xab\x83\xab\x4d\x0b\x93\x9e\x91\x8b\x3d\x1f\x1a\x7f\x43\xc1\xaa\xdd\x2e\x02\x08\x14\xee\x2b\x65\x63\x7a\x38\x32\xea\xa1\x00\x19\x11\x56\x52\x68\x97\x2b\x66\x5f\x2d\x6e\x32\x2d\x0c\x33\xed\x98\xc5\x66\x7f\x29\x0c\x2d\xd3\x26\x32\x45\x0f\x8c\xa0\xf5\x7a\x58\xd3\x48\x16\x64\x4d\xb2\x26\xce\x4c\xb6\xa3\xb8\x88\x85\xad\xde\x23\x53\x75\x7a\x78\x00\x43\x62\x0c\xcc\x25\x36\xa4\xb5\x41\x0c\xab\x18\x5a\x01\x02\xd8\x56\xd4\xa1\x13\x66\x48\xf0\x31\x2f\xd9\x17\x99\x29\x4c\x47\x3d\x10\xa1\xf0\x46\x1e\xaf\x72\x66\x9a\x85\x46\x2d\xbc\x63\xe0\x7a\x59\x3f\x66\x4e\x3d\x6c\xe9\xf4\x30\xa2\xd0\xa2\x95\x60\x25\x40\x19\x1f\xbb\x53\xb0\xdc\x52\xf4\xde\x16\xa8\x64\x5f\xc6\xf6\xda\xd1\x62\x0e\xaf\xc5\x31\x99\x3d\xea\x57\x28\x6b\xed\xa5\xab\x19\x0b\xd1\x4a\xf6\x10\xe0\x20\x23\x93\x61\xc9\xf8\x14\x96\xbd\xb2\x55\xec\x17\xc1\x2d\xd5\xe2\x53\x0f\x13\x62\x87\x73\x94\x7d\x8b\x4d\x05\xed\x66\x62\x6b\xec\x5c\xaa\xdc\xc8\x0f\xf0\x00\x38\xa9\x1e\x84\xb0\x5f\xec\x81\xd7\x43\xa8\xd8\xe9\x34\xbb\x42\xb9\x57\xd6\x0b\x03\x89\xfe\x90\x2b\x00\x2a\x5b\xea\x95\x09\x01\xc4\xa9\x76\x32\x73\xf5\xab\x08\x66\x5e\x74\x84\xa9\x18\x65\x83\x5b\xa6\x44\xb1\x0a\xa8\x45\xd9\xaa\x5d\x47\xd5\xa9\xdc\x30\xa5\x52\x7d\x75\x50\x80\x3f\x55\x90\x62\xef\xac\xd4\xaa\xa2\x90\xbd\x7e\x95\x55\xbc\x8c\xa5\xb7\x82\xe0\xcb\x59\x76\x20\x78\xf8\x7a\xc7\x29\x10\x7c\x39\xf6\xcb\x41\xf0\xa4\xe4\x67\x05\xc1\x63\xe9\xb7\x0c\x23\x2b\xde\x02\x82\x75\x9e\x9d\x0f\x04\x77\x2f\xc8\xbc\x1d\x05\x2b\x45\x41\xbc\x3d\x75\x7a\x34\xab\x35\x69\x9f\x6a\x0a\x1e\xb5\xf6\x73\xbb\xb3\x80\x76\x94\x76\xc7\xa8\x51\x09\x6f\xe7\xaf\xd3\x3a\xd0\x28\xe2\xf4\xdb\x63\x63\xad\xf9\x22\x3a\xd5\x42\x13\xe2\xb9\x45\xb1\x3b\x53\xa8\xb6\x7d\x7b\x6d\x3d\xc5\xfa\x6b\x50\x0e\x3e\x4a\xed\x05\xa2\x75\x46\x8e\x28\x33\x6d\xff\x81\x24\x59\x2b\x28\x4c\xad\xdb\xa9\xce\xad\x72\xf0\x58\x03\xf7\x82\xa0\xd4\x90\x70\xfc\xd8\xa2\xd1\xe3\x74\x50\xa1\xd0\x63\x3d\xaf\x35\x94\xd9\xeb\xd7\x7b\x45\x1d\x90\x36\xb6\xb4\x06\x41\x1b\xea\x69\x50\x01\x3d\x62\xc4\x96\x42\x7d\xb8\x86\x8c\x8d\xb6\xd7\xa8\x5d\xb0\x6d\x3c\x76\x6c\x04\x8d\x90\x0b\x3b\x81\x9c\xe8\x5f\x29\xaa\x60\xa7\x42\xeb\x7f\xd0\x93\x28\x8e\xe7\x39\x5e\x6a\x16\xd0\x9c\x3a\xaf\xd6\xf3\x9e\x58\xc7\xea\x91\x22\xc8\xd3\x6b\x2f\x81\x7a\xa5\x6d\x04\x39\xa0\x40\xf1\xa8\xb3\x16\xb9\xd9\x97\x58\xb3\x0e\x52\x97\x44\x3a\x98\x6a\x0b\x50\x8f\x85\x2b\x7d\x05\xa1\xd1\x67\x06\xad\xcd\x7b\x7d\x9d\x20\xde\x37\x92\x3d\x5a\xcd\x08\xec\x1d\x48\x43\xed\x4f\xfd\xf8\x5a\xcd\x83\xee\xb1\x56\x2d\xc5\xfe\x91\xce\xbe\x86\x4e\x07\x4e\xfe\x56\x78\x3a\x98\x66\x87\x4f\x47\x6f\x99\x9d\x02\xa8\x83\xd1\x5f\x8e\x50\xa7\xa5\x3f\x2b\x44\x9d\x50\x40\x31\x2a\xaf\x9a\xaf\xc4\xa8\x2a\xaf\xf3\xb6\xad\xcd\xf9\x30\x6a\xfb\xaa\xde\xb7\x9d\x7c\x92\x73\xf1\xbc\xd8\xbd\xc9\xb5\x20\xd2\x26\x30\xd1\xd9\x69\x71\xd1\x2a\xb7\xc2\xeb\xcb\xbd\x06\xbb\xe9\xa5\xe0\x81\x7b\x91\x7f\x3b\x3d\x09\x05\x8d\x0a\xed\x84\xc1\x29\x22\xb2\x55\xb2\x46\xa7\x4d\x6b\x72\x7f\xa1\x2f\x4d\x10\xa5\xf0\xc6\x5c\x79\x1b\x1e\xdc\xe6\x82\x68\x78\xbc\xa9\x84\xc7\xc4\x62\x6d\xcf\xb3\xb7\xd1\x65\xf4\xd4\x33\x36\xe3\x9d\x20\x01\xc5\xd4\xa0\x9d\x31\x90\x85\xc2\xa9\xd4\x0e\x09\xdc\x05\x2e\xd4\xe9\xcb\x36\x70\x09\xa2\xbe\x6a\xb4\x68\xea\x64\x2e\xd0\x2d\xd7\x66\x7d\x44\xe8\x5a\xf4\x8e\x3a\x28\x6a\x94\xca\x53\xb1\x03\xf3\xf5\xba\x96\x1b\x05\x0d\x41\xc5\x56\x5f\x7e\x29\xf4\x84\x95\xb5\x7a\xed\x9a\x80\x6e\xd9\x89\x68\x2e\x95\x37\xb5\xa9\x70\xac\xfe\xcf\x0a\xae\x17\xee\xf0\xd6\x58\x7e\x31\xc9\x2e\x92\x0f\xde\x0a\x3d\x15\xc7\x2f\x46\x7e\x79\x14\x9f\x92\xfa\xac\x31\x7c\x24\xfa\xb6\xd4\x6a\xdf\x52\x6a\x55\x69\x5a\x9d\x2f\x82\x9f\x5f\xaa\xfd\xc6\xb3\x41\xa3\x09\x45\x93\xfb\x7e\xe3\x3b\x12\x0c\xaf\x65\x4a\x2b\x0d\x4b\xdc\x89\x5a\x7f\x50\xda\x4d\x98\x12\xba\x83\x36\x2e\x4b\x2d\x59\xa0\xde\xf1\xfd\x35\x3d\xbb\x89\xe7\x4e\x95\x52\x64\xa5\xc9\x84\x39\xc9\xba\xd5\x33\x38\x53\x48\x79\x43\x42\xef\xb4\x47\xb1\x4d\xf6\x24\x37\x92\x1a\x65\x4e\xa9\xa1\x46\x52\x34\xda\x58\x27\x8c\x63\xa9\x56\xeb\xb9\x94\x11\x08\xd8\x26\xf2\xf8\x12\x41\x21\x8d\x5e\xe4\x73\x99\xe8\x19\x4b\x81\x4a\xd6\x01\x0e\xe2\x01\x40\x2a\x6b\xa6\xfa\x17\x82\x00\x2c\x44\x58\x73\x92\x3c\x81\x80\x5c\x9b\xfc\xa9\xbe\x0c\xd1\x68\x23\x3c\x96\x97\x95\x94\x5a\xa9\xbe\xa3\x17\x1b\xee\x9a\xf0\x21\x18\x84\x63\xae\xef\x6f\x39\x85\x01\x60\x30\x96\x92\x4e\x49\x52\x2b\xe5\x06\xfa\x34\xfb\xcd\xf0\x6d\xd3\xbb\x92\x26\x2f\x24\x8b\xef\x65\xb9\x6b\xdc\x76\xfd\xae\x94\x4e\xb5\xb4\x8c\x4d\xf1\x46\xdf\x83\x6a\x64\x4e\xec\x50\x6b\x19\xd8\xa5\x72\x3d\xc2\xb0\xd7\x26\xb1\x42\x0e\x10\x05\xc9\x82\x3c\x78\x2d\xa3\xe2\xde\x0d\xda\xcb\x31\x0a\x39\x4a\x08\xd9\xcf\x58\x76\x35\x52\xd6\x51\x5a\xc5\xbe\x97\x93\x03\x00\xa3\xe7\x9e\xb5\x9e\x91\x96\xe9\xae\xcc\xdb\x36\xc0\xf1\xc7\x4e\x4b\x39\x52\x46\xad\xef\xd6\x61\x23\xa3\x8d\xd9\x68\x1b\x2d\xc5\x98\xa3\x6b\xf5\x5c\xd0\x68\x93\xb7\x15\x9f\x18\xb5\x91\x4c\x49\xd9\x6b\xb3\xda\xe9\x99\x6b\xae\x0d\xe3\x46\xe5\x8b\x2f\xf3\xa8\xdf\x41\xca\xf2\x5e\x7b\x6f\xea\xb3\xec\x25\x7e\x15\x89\x61\xa1\x6d\x00\x2d\xad\x29\x7d\x89\x29\x7c\x0d\x9f\x8c\x0d\xf0\x4e\x7c\xee\xff\x03\x00\x00\xff\xff\xfe\x4b\x7d\x91\x00\x30\x00\x00")
 
 func bindataGoBytes() ([]byte, error) {
@@ -371,18 +1043,50 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"000001_init.up.sql":       _000001_initUpSql,
-	"000002_indexes.up.sql":    _000002_indexesUpSql,
-	"000003_indexes.up.sql":    _000003_indexesUpSql,
-	"000004_posts.up.sql":      _000004_postsUpSql,
-	"000005_collate.up.sql":    _000005_collateUpSql,
-	"000006_charset.up.sql":    _000006_charsetUpSql,
-	"000007_dtupdate.up.sql":   _000007_dtupdateUpSql,
-	"000008_delupd.up.sql":     _000008_delupdUpSql,
-	"000009_dialogs.up.sql":    _000009_dialogsUpSql,
-	"000010_user_shard.up.sql": _000010_user_shardUpSql,
-	"bindata.go":               bindataGo,
-	"migrations.go":            migrationsGo,
+	"000001_init.down.sql":            _000001_initDownSql,
+	"000001_init.up.sql":              _000001_initUpSql,
+	"000002_indexes.down.sql":         _000002_indexesDownSql,
+	"000002_indexes.up.sql":           _000002_indexesUpSql,
+	"000003_indexes.down.sql":         _000003_indexesDownSql,
+	"000003_indexes.up.sql":           _000003_indexesUpSql,
+	"000004_posts.down.sql":           _000004_postsDownSql,
+	"000004_posts.up.sql":             _000004_postsUpSql,
+	"000005_collate.down.sql":         _000005_collateDownSql,
+	"000005_collate.up.sql":           _000005_collateUpSql,
+	"000006_charset.down.sql":         _000006_charsetDownSql,
+	"000006_charset.up.sql":           _000006_charsetUpSql,
+	"000007_dtupdate.down.sql":        _000007_dtupdateDownSql,
+	"000007_dtupdate.up.sql":          _000007_dtupdateUpSql,
+	"000008_delupd.down.sql":          _000008_delupdDownSql,
+	"000008_delupd.up.sql":            _000008_delupdUpSql,
+	"000009_dialogs.down.sql":         _000009_dialogsDownSql,
+	"000009_dialogs.up.sql":           _000009_dialogsUpSql,
+	"000010_user_shard.down.sql":      _000010_user_shardDownSql,
+	"000010_user_shard.up.sql":        _000010_user_shardUpSql,
+	"000011_comments.down.sql":        _000011_commentsDownSql,
+	"000011_comments.up.sql":          _000011_commentsUpSql,
+	"000012_reactions.down.sql":       _000012_reactionsDownSql,
+	"000012_reactions.up.sql":         _000012_reactionsUpSql,
+	"000013_post_visibility.down.sql": _000013_post_visibilityDownSql,
+	"000013_post_visibility.up.sql":   _000013_post_visibilityUpSql,
+	"000014_post_drafts.down.sql":     _000014_post_draftsDownSql,
+	"000014_post_drafts.up.sql":       _000014_post_draftsUpSql,
+	"000015_friend_requests.down.sql": _000015_friend_requestsDownSql,
+	"000015_friend_requests.up.sql":   _000015_friend_requestsUpSql,
+	"000016_user_blocks.down.sql":     _000016_user_blocksDownSql,
+	"000016_user_blocks.up.sql":       _000016_user_blocksUpSql,
+	"000017_follow_counts.down.sql":   _000017_follow_countsDownSql,
+	"000017_follow_counts.up.sql":     _000017_follow_countsUpSql,
+	"000018_users_search.down.sql":    _000018_users_searchDownSql,
+	"000018_users_search.up.sql":      _000018_users_searchUpSql,
+	"000019_chat_management.down.sql": _000019_chat_managementDownSql,
+	"000019_chat_management.up.sql":   _000019_chat_managementUpSql,
+	"000020_chat_roles.down.sql":      _000020_chat_rolesDownSql,
+	"000020_chat_roles.up.sql":        _000020_chat_rolesUpSql,
+	"000021_chat_dialogs.down.sql":    _000021_chat_dialogsDownSql,
+	"000021_chat_dialogs.up.sql":      _000021_chat_dialogsUpSql,
+	"bindata.go":                      bindataGo,
+	"migrations.go":                   migrationsGo,
 }
 
 // AssetDir returns the file names below a certain
@@ -426,18 +1130,50 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"000001_init.up.sql":       &bintree{_000001_initUpSql, map[string]*bintree{}},
-	"000002_indexes.up.sql":    &bintree{_000002_indexesUpSql, map[string]*bintree{}},
-	"000003_indexes.up.sql":    &bintree{_000003_indexesUpSql, map[string]*bintree{}},
-	"000004_posts.up.sql":      &bintree{_000004_postsUpSql, map[string]*bintree{}},
-	"000005_collate.up.sql":    &bintree{_000005_collateUpSql, map[string]*bintree{}},
-	"000006_charset.up.sql":    &bintree{_000006_charsetUpSql, map[string]*bintree{}},
-	"000007_dtupdate.up.sql":   &bintree{_000007_dtupdateUpSql, map[string]*bintree{}},
-	"000008_delupd.up.sql":     &bintree{_000008_delupdUpSql, map[string]*bintree{}},
-	"000009_dialogs.up.sql":    &bintree{_000009_dialogsUpSql, map[string]*bintree{}},
-	"000010_user_shard.up.sql": &bintree{_000010_user_shardUpSql, map[string]*bintree{}},
-	"bindata.go":               &bintree{bindataGo, map[string]*bintree{}},
-	"migrations.go":            &bintree{migrationsGo, map[string]*bintree{}},
+	"000001_init.down.sql":            &bintree{_000001_initDownSql, map[string]*bintree{}},
+	"000001_init.up.sql":              &bintree{_000001_initUpSql, map[string]*bintree{}},
+	"000002_indexes.down.sql":         &bintree{_000002_indexesDownSql, map[string]*bintree{}},
+	"000002_indexes.up.sql":           &bintree{_000002_indexesUpSql, map[string]*bintree{}},
+	"000003_indexes.down.sql":         &bintree{_000003_indexesDownSql, map[string]*bintree{}},
+	"000003_indexes.up.sql":           &bintree{_000003_indexesUpSql, map[string]*bintree{}},
+	"000004_posts.down.sql":           &bintree{_000004_postsDownSql, map[string]*bintree{}},
+	"000004_posts.up.sql":             &bintree{_000004_postsUpSql, map[string]*bintree{}},
+	"000005_collate.down.sql":         &bintree{_000005_collateDownSql, map[string]*bintree{}},
+	"000005_collate.up.sql":           &bintree{_000005_collateUpSql, map[string]*bintree{}},
+	"000006_charset.down.sql":         &bintree{_000006_charsetDownSql, map[string]*bintree{}},
+	"000006_charset.up.sql":           &bintree{_000006_charsetUpSql, map[string]*bintree{}},
+	"000007_dtupdate.down.sql":        &bintree{_000007_dtupdateDownSql, map[string]*bintree{}},
+	"000007_dtupdate.up.sql":          &bintree{_000007_dtupdateUpSql, map[string]*bintree{}},
+	"000008_delupd.down.sql":          &bintree{_000008_delupdDownSql, map[string]*bintree{}},
+	"000008_delupd.up.sql":            &bintree{_000008_delupdUpSql, map[string]*bintree{}},
+	"000009_dialogs.down.sql":         &bintree{_000009_dialogsDownSql, map[string]*bintree{}},
+	"000009_dialogs.up.sql":           &bintree{_000009_dialogsUpSql, map[string]*bintree{}},
+	"000010_user_shard.down.sql":      &bintree{_000010_user_shardDownSql, map[string]*bintree{}},
+	"000010_user_shard.up.sql":        &bintree{_000010_user_shardUpSql, map[string]*bintree{}},
+	"000011_comments.down.sql":        &bintree{_000011_commentsDownSql, map[string]*bintree{}},
+	"000011_comments.up.sql":          &bintree{_000011_commentsUpSql, map[string]*bintree{}},
+	"000012_reactions.down.sql":       &bintree{_000012_reactionsDownSql, map[string]*bintree{}},
+	"000012_reactions.up.sql":         &bintree{_000012_reactionsUpSql, map[string]*bintree{}},
+	"000013_post_visibility.down.sql": &bintree{_000013_post_visibilityDownSql, map[string]*bintree{}},
+	"000013_post_visibility.up.sql":   &bintree{_000013_post_visibilityUpSql, map[string]*bintree{}},
+	"000014_post_drafts.down.sql":     &bintree{_000014_post_draftsDownSql, map[string]*bintree{}},
+	"000014_post_drafts.up.sql":       &bintree{_000014_post_draftsUpSql, map[string]*bintree{}},
+	"000015_friend_requests.down.sql": &bintree{_000015_friend_requestsDownSql, map[string]*bintree{}},
+	"000015_friend_requests.up.sql":   &bintree{_000015_friend_requestsUpSql, map[string]*bintree{}},
+	"000016_user_blocks.down.sql":     &bintree{_000016_user_blocksDownSql, map[string]*bintree{}},
+	"000016_user_blocks.up.sql":       &bintree{_000016_user_blocksUpSql, map[string]*bintree{}},
+	"000017_follow_counts.down.sql":   &bintree{_000017_follow_countsDownSql, map[string]*bintree{}},
+	"000017_follow_counts.up.sql":     &bintree{_000017_follow_countsUpSql, map[string]*bintree{}},
+	"000018_users_search.down.sql":    &bintree{_000018_users_searchDownSql, map[string]*bintree{}},
+	"000018_users_search.up.sql":      &bintree{_000018_users_searchUpSql, map[string]*bintree{}},
+	"000019_chat_management.down.sql": &bintree{_000019_chat_managementDownSql, map[string]*bintree{}},
+	"000019_chat_management.up.sql":   &bintree{_000019_chat_managementUpSql, map[string]*bintree{}},
+	"000020_chat_roles.down.sql":      &bintree{_000020_chat_rolesDownSql, map[string]*bintree{}},
+	"000020_chat_roles.up.sql":        &bintree{_000020_chat_rolesUpSql, map[string]*bintree{}},
+	"000021_chat_dialogs.down.sql":    &bintree{_000021_chat_dialogsDownSql, map[string]*bintree{}},
+	"000021_chat_dialogs.up.sql":      &bintree{_000021_chat_dialogsUpSql, map[string]*bintree{}},
+	"bindata.go":                      &bintree{bindataGo, map[string]*bintree{}},
+	"migrations.go":                   &bintree{migrationsGo, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
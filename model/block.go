@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+const (
+	// BlockKindBlock Блокировка: пользователи не видят друг друга и не могут взаимодействовать
+	BlockKindBlock string = "block"
+	// BlockKindMute Скрытие: публикации пользователя не попадают в ленту, взаимодействие разрешено
+	BlockKindMute string = "mute"
+)
+
+// UserBlock Блокировка (или скрытие) пользователя TargetId пользователем UserId
+type UserBlock struct {
+	UserId    int64     `json:"user_id" db:"user_id"`
+	TargetId  int64     `json:"target_id" db:"target_id"`
+	Kind      string    `json:"kind" db:"kind"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ValidBlockKind Проверка, что тип блокировки известен
+func ValidBlockKind(kind string) bool {
+	return kind == BlockKindBlock || kind == BlockKindMute
+}
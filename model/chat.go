@@ -4,11 +4,50 @@ import "time"
 
 // Chat Чат общение между двумя и более участниками
 type Chat struct {
-	Id        int64     `db:"id" json:"id,omitempty"`
-	Title     string    `db:"title" json:"title,omitempty"`
-	CreatedAt time.Time `db:"created_at" json:"created_at,omitempty"`
-	Closed    bool      `db:"closed" json:"closed,omitempty"`
-	// Добавить автора и права
+	Id           int64     `db:"id" json:"id,omitempty"`
+	Title        string    `db:"title" json:"title,omitempty"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at,omitempty"`
+	Closed       bool      `db:"closed" json:"closed,omitempty"`
+	CreatedBy    int64     `db:"created_by" json:"created_by,omitempty"`
+	Dialog       bool      `db:"dialog" json:"dialog,omitempty"`
+	ChatSettings `json:"settings"`
+}
+
+// ChatSettings Настройки чата: минимальная роль участника для действия
+type ChatSettings struct {
+	Invite string `db:"invite_role" json:"invite"`
+	Post   string `db:"post_role" json:"post"`
+	Rename string `db:"rename_role" json:"rename"`
+}
+
+// Роли участников чата
+const (
+	ChatRoleOwner  string = "owner"
+	ChatRoleAdmin  string = "admin"
+	ChatRoleMember string = "member"
+)
+
+// ChatRoles Допустимые роли и их старшинство
+var ChatRoles = map[string]int{
+	ChatRoleMember: 1,
+	ChatRoleAdmin:  2,
+	ChatRoleOwner:  3,
+}
+
+// ValidChatRole Проверка, что роль допустима
+func ValidChatRole(role string) bool {
+	_, ok := ChatRoles[role]
+	return ok
+}
+
+// RoleAllows Роль role не младше требуемой required
+func RoleAllows(role, required string) bool {
+	return ChatRoles[role] >= ChatRoles[required]
+}
+
+// Valid Все настройки указывают допустимые роли
+func (s ChatSettings) Valid() bool {
+	return ValidChatRole(s.Invite) && ValidChatRole(s.Post) && ValidChatRole(s.Rename)
 }
 
 type ChatCreateDTO struct {
@@ -16,12 +55,37 @@ type ChatCreateDTO struct {
 	Users []int64 `json:"users"`
 }
 
+// ChatParticipantsUpdateDTO Изменение списка участников чата
+type ChatParticipantsUpdateDTO struct {
+	Users []int64 `json:"users"`
+}
+
+// ChatRenameDTO Переименование чата
+type ChatRenameDTO struct {
+	Title string `json:"title"`
+}
+
+// ChatRoleDTO Смена роли участника чата
+type ChatRoleDTO struct {
+	Role string `json:"role"`
+}
+
+// ParticipantStatus Статус участника чата
+type ParticipantStatus int8
+
+const (
+	ParticipantActive  ParticipantStatus = 1 // Участник чата
+	ParticipantLeft    ParticipantStatus = 2 // Покинул чат
+	ParticipantRemoved ParticipantStatus = 3 // Исключен из чата
+)
+
 // ChatParticipant Список участников чата
 type ChatParticipant struct {
-	Id     int64 `json:"id,omitempty" db:"id"`
-	ChatId int64 `json:"chat_id" db:"chat_id"`
-	UserId int64 `json:"user_id" db:"user_id"`
-	Status int8  `json:"status" db:"status"`
+	Id     int64             `json:"id,omitempty" db:"id"`
+	ChatId int64             `json:"chat_id" db:"chat_id"`
+	UserId int64             `json:"user_id" db:"user_id"`
+	Status ParticipantStatus `json:"status" db:"status"`
+	Role   string            `json:"role" db:"role"`
 }
 
 // Message Сообщение, которое сохраняется в БД
@@ -31,6 +95,7 @@ type Message struct {
 	UserFrom int64     `json:"user_from" db:"user_from"`
 	SendAt   time.Time `json:"send_at" db:"send_at"`
 	Message  string    `json:"message" db:"message"`
+	System   bool      `json:"system" db:"system"`
 }
 
 type MessageSendDTO struct {
@@ -46,10 +111,12 @@ type ChatParticipantsDTO struct {
 
 // MessageDTO Сообщение (для отображения Клиентом)
 type MessageDTO struct {
-	Id       int64     `json:"id"`
-	UserFrom string    `json:"user_from"`
-	Date     time.Time `json:"date"`
-	Message  string    `json:"message"`
+	Id        int64          `json:"id"`
+	UserFrom  string         `json:"user_from"`
+	Date      time.Time      `json:"date"`
+	Message   string         `json:"message"`
+	System    bool           `json:"system,omitempty"`
+	Reactions ReactionCounts `json:"reactions,omitempty"`
 }
 
 // MessageList Список сообщений чата
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Comment Комментарий к публикации
+type Comment struct {
+	Id        int64     `json:"id" db:"id"`
+	PostId    int64     `json:"post_id" db:"post_id"`
+	UserId    int64     `json:"user_id" db:"user_id"`
+	Message   string    `json:"message" db:"message"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Deleted   bool      `json:"-" db:"deleted"`
+}
+
+// CommentPojo Новый комментарий (от клиента)
+type CommentPojo struct {
+	Message string `json:"message"`
+}
+
+// CommentDTO Комментарий (для отображения на клиенте)
+type CommentDTO struct {
+	Id       int64  `json:"id"`
+	PostId   int64  `json:"post_id"`
+	UserFrom string `json:"user_from"`
+	Message  string `json:"message"`
+}
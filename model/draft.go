@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+const (
+	// DraftStatusDraft Черновик, не запланирован к публикации
+	DraftStatusDraft string = "draft"
+	// DraftStatusScheduled Запланирован к публикации на время publish_at
+	DraftStatusScheduled string = "scheduled"
+	// DraftStatusPublished Опубликован
+	DraftStatusPublished string = "published"
+	// DraftStatusCancelled Отменен пользователем
+	DraftStatusCancelled string = "cancelled"
+)
+
+// Draft Черновик или запланированная публикация
+type Draft struct {
+	Id         int64      `json:"id" db:"id"`
+	UserId     int64      `json:"user_id" db:"user_id"`
+	Title      string     `json:"title" db:"title"`
+	Message    string     `json:"message" db:"message"`
+	Visibility string     `json:"visibility" db:"visibility"`
+	Status     string     `json:"status" db:"status"`
+	PublishAt  *time.Time `json:"publish_at,omitempty" db:"publish_at"`
+	PostId     *int64     `json:"post_id,omitempty" db:"post_id"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdateAt   *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// DraftPojo Черновик (от клиента). Если задан publish_at, публикация планируется на это время
+type DraftPojo struct {
+	Title      string     `json:"title"`
+	Message    string     `json:"message"`
+	Visibility string     `json:"visibility"`
+	PublishAt  *time.Time `json:"publish_at"`
+}
+
+// Editable Можно ли изменять черновик
+func (d *Draft) Editable() bool {
+	return d.Status == DraftStatusDraft || d.Status == DraftStatusScheduled
+}
@@ -3,9 +3,13 @@ package model
 import "encoding/json"
 
 const (
-	EventTypeInvite  string = "invite"
-	EventTypePost    string = "post"
-	EventTypeMessage string = "message"
+	EventTypeInvite   string = "invite"
+	EventTypePost     string = "post"
+	EventTypeMessage  string = "message"
+	EventTypeComment  string = "comment"
+	EventTypeReaction string = "reaction"
+	// EventTypeFriendRequest Заявка в друзья или ответ на нее
+	EventTypeFriendRequest string = "friend_request"
 )
 
 // Модели данных необходимых для отправки в очередь
@@ -15,6 +19,12 @@ type Event interface {
 	GetType() string
 }
 
+// EventTarget Получатель события
+type EventTarget struct {
+	UserId  int64
+	ShardId string
+}
+
 // EventPost Событие Публикация
 type EventPost struct {
 	EventType string  `json:"event"`
@@ -72,3 +82,60 @@ func (e *EventChatInvite) String() string {
 func (e *EventChatInvite) GetType() string {
 	return EventTypeInvite
 }
+
+// EventComment Событие Новый комментарий к публикации
+type EventComment struct {
+	EventType string     `json:"event"`
+	Data      CommentDTO `json:"data"`
+}
+
+func (e *EventComment) String() string {
+	e.EventType = EventTypeComment
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
+
+func (e *EventComment) GetType() string {
+	return EventTypeComment
+}
+
+// EventReaction Событие Реакция на публикацию или сообщение
+type EventReaction struct {
+	EventType string      `json:"event"`
+	Data      ReactionDTO `json:"data"`
+}
+
+func (e *EventReaction) String() string {
+	e.EventType = EventTypeReaction
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
+
+func (e *EventReaction) GetType() string {
+	return EventTypeReaction
+}
+
+// EventFriendRequest Событие Заявка в друзья
+type EventFriendRequest struct {
+	EventType string           `json:"event"`
+	Data      FriendRequestDTO `json:"data"`
+}
+
+func (e *EventFriendRequest) String() string {
+	e.EventType = EventTypeFriendRequest
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return ""
+	}
+	return string(bytes)
+}
+
+func (e *EventFriendRequest) GetType() string {
+	return EventTypeFriendRequest
+}
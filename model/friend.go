@@ -1,7 +1,38 @@
 package model
 
-// Friend Друг друга
+import "time"
+
+const (
+	// FriendRequestPending Заявка ожидает ответа
+	FriendRequestPending string = "pending"
+	// FriendRequestAccepted Заявка принята, пользователи стали друзьями
+	FriendRequestAccepted string = "accepted"
+	// FriendRequestDeclined Заявка отклонена получателем
+	FriendRequestDeclined string = "declined"
+	// FriendRequestCancelled Заявка отозвана отправителем
+	FriendRequestCancelled string = "cancelled"
+)
+
+// Friend Подписка пользователя UserId на пользователя FriendId. Взаимная подписка означает дружбу
 type Friend struct {
 	UserId   int64 `json:"user_id" db:"user_id"`
 	FriendId int64 `json:"friend_id" db:"friend_id"`
 }
+
+// FriendRequest Заявка в друзья
+type FriendRequest struct {
+	Id         int64      `json:"id" db:"id"`
+	FromUserId int64      `json:"from_user_id" db:"from_user_id"`
+	ToUserId   int64      `json:"to_user_id" db:"to_user_id"`
+	Status     string     `json:"status" db:"status"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdateAt   *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// FriendRequestDTO Заявка в друзья (для отображения на клиенте)
+type FriendRequestDTO struct {
+	Id         int64  `json:"id"`
+	FromUserId int64  `json:"from_user_id"`
+	UserFrom   string `json:"user_from"`
+	Status     string `json:"status"`
+}
@@ -2,22 +2,73 @@ package model
 
 import "time"
 
+const (
+	// PostVisibilityPublic Публикация доступна всем
+	PostVisibilityPublic string = "public"
+	// PostVisibilityFriends Публикация доступна только друзьям автора
+	PostVisibilityFriends string = "friends"
+	// PostVisibilityPrivate Публикация доступна только автору
+	PostVisibilityPrivate string = "private"
+)
+
 // Post model
 type Post struct {
-	Id        int64     `json:"id" db:"id" fake:"skip"`
-	UserId    int64     `json:"user_id" db:"user_id" fake:"skip"`
-	Title     string    `json:"title" db:"title" fake:"{sentence}"`
-	Message   string    `json:"message" db:"message" fake:"{sentence}"`
-	CreatedAt time.Time `json:"created_at" db:"created_at" fake:"skip"`
-	UpdateAt  time.Time `json:"updated_at" db:"updated_at" fake:"skip"`
-	Deleted   bool      `json:"deleted" db:"deleted" fake:"skip"`
+	Id            int64          `json:"id" db:"id" fake:"skip"`
+	UserId        int64          `json:"user_id" db:"user_id" fake:"skip"`
+	Title         string         `json:"title" db:"title" fake:"{sentence}"`
+	Message       string         `json:"message" db:"message" fake:"{sentence}"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at" fake:"skip"`
+	UpdateAt      time.Time      `json:"updated_at" db:"updated_at" fake:"skip"`
+	Deleted       bool           `json:"deleted" db:"deleted" fake:"skip"`
+	CommentsCount int64          `json:"comments_count" db:"comments_count" fake:"skip"`
+	Visibility    string         `json:"visibility" db:"visibility" fake:"skip"`
+	Reactions     ReactionCounts `json:"reactions,omitempty" db:"-" fake:"skip"`
 }
 
 // PostPojo model
 type PostPojo struct {
-	UserId  int64  `fake:"skip"`
-	Title   string `json:"title" db:"title" fake:"{sentence}"`
-	Message string `json:"message" db:"message" fake:"{sentence}"`
+	UserId     int64  `fake:"skip"`
+	Title      string `json:"title" db:"title" fake:"{sentence}"`
+	Message    string `json:"message" db:"message" fake:"{sentence}"`
+	Visibility string `json:"visibility" db:"visibility" fake:"skip"`
+}
+
+// VisibleTo Проверка доступности публикации пользователю viewerId (0 - анонимный пользователь).
+// friends - являются ли viewerId и автор публикации друзьями
+func (p *Post) VisibleTo(viewerId int64, friends bool) bool {
+	if p.Deleted {
+		return false
+	}
+	if viewerId != 0 && viewerId == p.UserId {
+		return true
+	}
+	switch p.Visibility {
+	case PostVisibilityPublic, "":
+		return true
+	case PostVisibilityFriends:
+		return viewerId != 0 && friends
+	}
+	return false
+}
+
+// PostVisibilityFor Список уровней видимости публикаций автора, доступных пользователю
+func PostVisibilityFor(viewerId, authorId int64, friends bool) []string {
+	if viewerId != 0 && viewerId == authorId {
+		return []string{PostVisibilityPublic, PostVisibilityFriends, PostVisibilityPrivate}
+	}
+	if viewerId != 0 && friends {
+		return []string{PostVisibilityPublic, PostVisibilityFriends}
+	}
+	return []string{PostVisibilityPublic}
+}
+
+// ValidPostVisibility Проверка уровня видимости публикации
+func ValidPostVisibility(visibility string) bool {
+	switch visibility {
+	case PostVisibilityPublic, PostVisibilityFriends, PostVisibilityPrivate:
+		return true
+	}
+	return false
 }
 
 // PostDTO Публикация (для отображения на клиенте)
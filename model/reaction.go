@@ -0,0 +1,45 @@
+package model
+
+const (
+	ReactionItemPost    string = "post"
+	ReactionItemMessage string = "message"
+
+	ReactionLike  string = "like"
+	ReactionLove  string = "love"
+	ReactionLaugh string = "laugh"
+	ReactionWow   string = "wow"
+	ReactionSad   string = "sad"
+	ReactionAngry string = "angry"
+)
+
+// Reactions Допустимый набор реакций
+var Reactions = map[string]struct{}{
+	ReactionLike:  {},
+	ReactionLove:  {},
+	ReactionLaugh: {},
+	ReactionWow:   {},
+	ReactionSad:   {},
+	ReactionAngry: {},
+}
+
+// ReactionCounts Количество реакций по типам (агрегат)
+type ReactionCounts map[string]int64
+
+// ReactionPojo Реакция (от клиента)
+type ReactionPojo struct {
+	Reaction string `json:"reaction"`
+}
+
+// ReactionDTO Реакция (для отображения на клиенте)
+type ReactionDTO struct {
+	ItemType string `json:"item_type"`
+	ItemId   int64  `json:"item_id"`
+	UserFrom string `json:"user_from"`
+	Reaction string `json:"reaction"`
+}
+
+// ValidReaction Проверка, что реакция входит в допустимый набор
+func ValidReaction(reaction string) bool {
+	_, ok := Reactions[reaction]
+	return ok
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// Поля сортировки результатов поиска пользователей
+const (
+	SearchSortId      = "user_id"
+	SearchSortName    = "name"
+	SearchSortSurname = "surname"
+	SearchSortAge     = "age"
+	SearchSortCity    = "city"
+	SearchSortCountry = "country"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// UserSearch Параметры поиска пользователей. Пустые поля не участвуют в фильтрации
+type UserSearch struct {
+	Name      string // Префикс имени
+	Surname   string // Префикс фамилии
+	City      string
+	Country   string
+	Sex       string
+	AgeFrom   int
+	AgeTo     int
+	Interests string // Слова для полнотекстового поиска по интересам
+	Sort      string // Поле сортировки, одно из SearchSort*
+	Desc      bool
+	Limit     int64
+	Offset    int64         // Количество пропускаемых пользователей (после позиции After, если она задана)
+	After     *SearchCursor // Позиция, после которой возвращается страница
+}
+
+// SearchCursor Позиция в результатах поиска (keyset): значение поля сортировки и id пользователя
+type SearchCursor struct {
+	Value string `json:"v,omitempty"`
+	Id    int64  `json:"id"`
+}
+
+// UserSearchResult Страница результатов поиска с общим количеством найденных пользователей
+type UserSearchResult struct {
+	Count      int64  `json:"count"`
+	Users      []User `json:"users"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ValidSearchSort Проверка, что по полю разрешена сортировка
+func ValidSearchSort(field string) bool {
+	switch field {
+	case SearchSortId, SearchSortName, SearchSortSurname, SearchSortAge, SearchSortCity, SearchSortCountry:
+		return true
+	}
+	return false
+}
+
+// CursorFor Позиция пользователя user в результатах поиска
+func (s UserSearch) CursorFor(user User) SearchCursor {
+	cursor := SearchCursor{Id: user.UserId}
+	switch s.Sort {
+	case SearchSortName:
+		cursor.Value = user.Name
+	case SearchSortSurname:
+		cursor.Value = user.Surname
+	case SearchSortAge:
+		cursor.Value = strconv.Itoa(user.Age)
+	case SearchSortCity:
+		cursor.Value = user.City
+	case SearchSortCountry:
+		cursor.Value = user.Country
+	}
+	return cursor
+}
+
+// Encode Представление позиции для передачи клиенту
+func (c SearchCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeSearchCursor Разбор позиции, полученной от клиента
+func DecodeSearchCursor(value string) (*SearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor SearchCursor
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.Id <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
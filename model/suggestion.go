@@ -0,0 +1,56 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FriendSuggestion Рекомендуемый пользователь (друг друзей)
+type FriendSuggestion struct {
+	UserId        int64   `json:"id" db:"user_id"`
+	Name          string  `json:"name" db:"name"`
+	Surname       string  `json:"surname" db:"surname"`
+	Country       string  `json:"country" db:"country"`
+	City          string  `json:"city" db:"city"`
+	Interests     string  `json:"interests" db:"interests"`
+	MutualFriends int     `json:"mutual_friends" db:"mutual_friends"`
+	Score         float64 `json:"score" db:"-"`
+}
+
+// Веса признаков при ранжировании рекомендаций
+const (
+	SuggestionWeightMutual   = 3.0
+	SuggestionWeightCity     = 2.0
+	SuggestionWeightCountry  = 1.0
+	SuggestionWeightInterest = 1.0
+)
+
+// interestMinLength Минимальная длина слова, учитываемого при сравнении интересов
+const interestMinLength = 3
+
+// InterestsOverlap Количество общих слов в интересах пользователей
+func InterestsOverlap(a, b string) int {
+	words := interestWords(a)
+	if len(words) == 0 {
+		return 0
+	}
+	overlap := 0
+	for word := range interestWords(b) {
+		if _, ok := words[word]; ok {
+			overlap++
+		}
+	}
+	return overlap
+}
+
+func interestWords(interests string) map[string]struct{} {
+	words := make(map[string]struct{})
+	for _, word := range strings.FieldsFunc(strings.ToLower(interests), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) >= interestMinLength {
+			words[word] = struct{}{}
+		}
+	}
+	return words
+}
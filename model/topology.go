@@ -0,0 +1,24 @@
+package model
+
+// Роли узлов БД
+const (
+	NodeRolePrimary   = "primary"
+	NodeRoleCandidate = "candidate"
+	NodeRoleReplica   = "replica"
+)
+
+// StorageNode Узел БД и его состояние по последней проверке
+type StorageNode struct {
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+	Available  bool   `json:"available"`
+	ReadOnly   bool   `json:"read_only"`
+	LagSeconds int64  `json:"lag_seconds,omitempty"`
+}
+
+// StorageTopology Топология кластера БД
+type StorageTopology struct {
+	Primary   string        `json:"primary"`
+	Failovers int64         `json:"failovers"`
+	Nodes     []StorageNode `json:"nodes"`
+}
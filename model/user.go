@@ -19,6 +19,16 @@ type User struct {
 	PasswordHash string       `json:"-" db:"password" fake:"skip"`
 	ShardId      string       `json:"-" db:"shard_id"`
 	Settings     UserSettings `json:"settings,omitempty" db:"-"`
+	// Денормализованные счетчики подписок
+	FollowersCount int64 `json:"followers_count" db:"followers_count" fake:"skip"`
+	FollowingCount int64 `json:"following_count" db:"following_count" fake:"skip"`
+	FriendsCount   int64 `json:"friends_count" db:"friends_count" fake:"skip"`
+}
+
+// UserList Страница списка пользователей с общим количеством
+type UserList struct {
+	Count int64  `json:"count"`
+	Users []User `json:"users"`
 }
 
 type UserSettings struct {
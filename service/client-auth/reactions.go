@@ -0,0 +1,34 @@
+package client_auth
+
+import (
+	"context"
+	auth_api "github.com/basicus/hla-course/grpc/auth"
+	"github.com/basicus/hla-course/model"
+)
+
+// PublishFunc Отправка события пользователю на его шард
+type PublishFunc func(ctx context.Context, userId int64, shardId string, event model.Event) error
+
+// NotifyReaction Отправка автору сообщения события о реакции пользователя userId.
+// О реакции на собственное сообщение автор не уведомляется
+func NotifyReaction(ctx context.Context, client auth_api.AuthServiceClient, publish PublishFunc, message model.Message, userId int64, reaction string) error {
+	if message.UserFrom == userId {
+		return nil
+	}
+	shard, err := client.UserShard(ctx, &auth_api.UserShardRequest{UserId: message.UserFrom})
+	if err != nil {
+		return err
+	}
+	userName, err := client.UserName(ctx, &auth_api.UserNameRequest{UserId: userId})
+	if err != nil {
+		return err
+	}
+	return publish(ctx, message.UserFrom, shard.GetShardId(), &model.EventReaction{
+		Data: model.ReactionDTO{
+			ItemType: model.ReactionItemMessage,
+			ItemId:   message.Id,
+			UserFrom: userName.GetUserName(),
+			Reaction: reaction,
+		},
+	})
+}
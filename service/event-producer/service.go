@@ -2,16 +2,22 @@ package eventproducer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/basicus/hla-course/log"
 	"github.com/basicus/hla-course/model"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/sirupsen/logrus"
+	"sync"
 )
 
+var ErrNotConfirmed = errors.New("events are not confirmed by broker")
+
 type Service struct {
 	config Config
 	log    *logrus.Logger
 	conn   *amqp.Connection
+	m      sync.Mutex
 	ch     *amqp.Channel
 	close  chan struct{}
 }
@@ -27,32 +33,92 @@ func New(config Config, log *logrus.Logger) (*Service, error) {
 
 func (s *Service) PublishEvent(ctx context.Context, userId int64, shardId string, event model.Event) error {
 	s.log.Infof("request for publish event for user_id %d type %s body: %s", userId, event.GetType(), event.String())
-	headers := make(map[string]interface{})
-	const EventType = "type"
-	const UserIdField = "user_id"
+	ch, err := s.channel()
+	if err != nil {
+		return err
+	}
+	_, err = s.publish(ctx, ch, userId, shardId, event.GetType(), []byte(event.String()))
+	return err
+}
+
+// PublishEventBatch Отправка одного события списку получателей. Публикации отправляются в канал подряд,
+// после чего ожидаются подтверждения брокера (publisher confirms) сразу для всей пачки
+func (s *Service) PublishEventBatch(ctx context.Context, targets []model.EventTarget, event model.Event) error {
+	s.log.Infof("request for publish event for %d users type %s body: %s", len(targets), event.GetType(), event.String())
+	ch, err := s.channel()
+	if err != nil {
+		return err
+	}
+	body := []byte(event.String())
+	confirms := make([]*amqp.DeferredConfirmation, 0, len(targets))
+	for _, target := range targets {
+		confirm, err := s.publish(ctx, ch, target.UserId, target.ShardId, event.GetType(), body)
+		if err != nil {
+			s.log.WithError(err).Errorf("Failed to publish event for user_id %d", target.UserId)
+			return err
+		}
+		confirms = append(confirms, confirm)
+	}
+
+	failed := 0
+	for i, confirm := range confirms {
+		// Ожидание завершается подтверждением, отказом брокера, закрытием канала или отменой ctx
+		if confirm != nil && !confirm.Wait() {
+			s.log.Errorf("Event for user_id %d is not confirmed", targets[i].UserId)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", ErrNotConfirmed, failed, len(targets))
+	}
+	return nil
+}
+
+// channel Возвращает открытый канал, переоткрывая его при необходимости
+func (s *Service) channel() (*amqp.Channel, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
 	if s.ch.IsClosed() {
-		// Define channel
-		ch, err := s.conn.Channel()
 		s.log.Error("Channel closed opening it again.")
+		ch, err := s.openChannel()
 		if err != nil {
 			s.log.WithError(err).Error("Failed to create channel")
-			return err
+			return nil, err
 		}
 		s.ch = ch
 	}
-	headers[EventType] = event.GetType()
+	return s.ch, nil
+}
+
+// openChannel Открытие канала в режиме подтверждения публикаций
+func (s *Service) openChannel() (*amqp.Channel, error) {
+	ch, err := s.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	if err = ch.Confirm(false); err != nil {
+		_ = ch.Close()
+		return nil, err
+	}
+	return ch, nil
+}
+
+func (s *Service) publish(ctx context.Context, ch *amqp.Channel, userId int64, shardId string, eventType string, body []byte) (*amqp.DeferredConfirmation, error) {
+	headers := make(map[string]interface{})
+	const EventType = "type"
+	const UserIdField = "user_id"
+	headers[EventType] = eventType
 	headers[UserIdField] = userId
-	_, err := s.ch.PublishWithDeferredConfirmWithContext(ctx,
+	return ch.PublishWithDeferredConfirmWithContext(ctx,
 		s.config.QueueEventExchange, // exchange
 		shardId,                     // routing key by user shard
 		false,                       // mandatory
 		false,                       // immediate
 		amqp.Publishing{
 			ContentType: "text/plain",
-			Body:        []byte(event.String()),
+			Body:        body,
 			Headers:     headers,
 		})
-	return err
 }
 
 // Run Group task
@@ -73,14 +139,16 @@ func (s *Service) Run(ctx context.Context) error {
 	logger.Info("Start connection established")
 
 	// Define channel
-	ch, err := s.conn.Channel()
+	ch, err := s.openChannel()
 	if err != nil {
 		logger.WithError(err).Error("Failed to create channel")
 		return err
 	}
+	s.m.Lock()
 	s.ch = ch
+	s.m.Unlock()
 
-	err = s.ch.ExchangeDeclare(
+	err = ch.ExchangeDeclare(
 		s.config.QueueEventExchange, // Exchange
 		"direct",                    // type
 		true,                        // durable
@@ -69,6 +69,22 @@ func (s *service) UserShard(ctx context.Context, request *authapi.UserShardReque
 	return &authapi.UserShardResponse{ShardId: user.ShardId}, nil
 }
 
+func (s *service) UserNames(ctx context.Context, request *authapi.UserNamesRequest) (*authapi.UserNamesResponse, error) {
+	names, err := s.storage.GetUserNames(ctx, request.GetUserIds())
+	if err != nil {
+		return nil, err
+	}
+	return &authapi.UserNamesResponse{UserNames: names}, nil
+}
+
+func (s *service) UserShards(ctx context.Context, request *authapi.UserShardsRequest) (*authapi.UserShardsResponse, error) {
+	shards, err := s.storage.GetUserShards(ctx, request.GetUserIds())
+	if err != nil {
+		return nil, err
+	}
+	return &authapi.UserShardsResponse{ShardIds: shards}, nil
+}
+
 func (s *service) Run(ctx context.Context) error {
 	logger := log.Ctx(ctx)
 
@@ -0,0 +1,137 @@
+package grpc_chats
+
+import (
+	"context"
+	chatsapi "github.com/basicus/hla-course/grpc/chats"
+	"github.com/basicus/hla-course/model"
+)
+
+func (s *service) AddParticipants(ctx context.Context, request *chatsapi.ChatParticipantsRequest) (*chatsapi.ChatParticipantsResponse, error) {
+	s.log.Infof("Request AddParticipants request_id %s", request.RequestId)
+	err := s.storage.ChatAddParticipants(ctx, request.GetChatId(), request.GetUserId(), request.GetUsers())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return s.participantsResponse(ctx, request.GetChatId())
+}
+
+func (s *service) RemoveParticipants(ctx context.Context, request *chatsapi.ChatParticipantsRequest) (*chatsapi.ChatParticipantsResponse, error) {
+	s.log.Infof("Request RemoveParticipants request_id %s", request.RequestId)
+	err := s.storage.ChatRemoveParticipants(ctx, request.GetChatId(), request.GetUserId(), request.GetUsers())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return s.participantsResponse(ctx, request.GetChatId())
+}
+
+func (s *service) LeaveChat(ctx context.Context, request *chatsapi.ChatActionRequest) (*chatsapi.ChatActionResponse, error) {
+	s.log.Infof("Request LeaveChat request_id %s", request.RequestId)
+	if err := s.storage.ChatLeave(ctx, request.GetChatId(), request.GetUserId()); err != nil {
+		return nil, storageError(err)
+	}
+	chat, err := s.storage.GetChat(ctx, request.GetChatId())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.ChatActionResponse{Chat: chatInfo(chat)}, nil
+}
+
+func (s *service) RenameChat(ctx context.Context, request *chatsapi.RenameChatRequest) (*chatsapi.ChatActionResponse, error) {
+	s.log.Infof("Request RenameChat request_id %s", request.RequestId)
+	chat, err := s.storage.ChatRename(ctx, request.GetChatId(), request.GetUserId(), request.GetTitle())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.ChatActionResponse{Chat: chatInfo(chat)}, nil
+}
+
+func (s *service) CloseChat(ctx context.Context, request *chatsapi.ChatActionRequest) (*chatsapi.ChatActionResponse, error) {
+	s.log.Infof("Request CloseChat request_id %s", request.RequestId)
+	chat, err := s.storage.ChatClose(ctx, request.GetChatId(), request.GetUserId())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.ChatActionResponse{Chat: chatInfo(chat)}, nil
+}
+
+func (s *service) DeleteChat(ctx context.Context, request *chatsapi.ChatActionRequest) (*chatsapi.ChatActionResponse, error) {
+	s.log.Infof("Request DeleteChat request_id %s", request.RequestId)
+	chat, err := s.storage.ChatDelete(ctx, request.GetChatId(), request.GetUserId())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.ChatActionResponse{Chat: chatInfo(chat)}, nil
+}
+
+func (s *service) ListMembers(ctx context.Context, request *chatsapi.ChatActionRequest) (*chatsapi.ChatMembersResponse, error) {
+	s.log.Infof("Request ListMembers request_id %s", request.RequestId)
+	if _, _, err := s.storage.ChatAccess(ctx, request.GetChatId(), request.GetUserId()); err != nil {
+		return nil, storageError(err)
+	}
+	return s.membersResponse(ctx, request.GetChatId())
+}
+
+func (s *service) SetMemberRole(ctx context.Context, request *chatsapi.SetMemberRoleRequest) (*chatsapi.ChatMembersResponse, error) {
+	s.log.Infof("Request SetMemberRole request_id %s", request.RequestId)
+	err := s.storage.ChatSetRole(ctx, request.GetChatId(), request.GetUserId(), request.GetMemberId(), request.GetRole())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return s.membersResponse(ctx, request.GetChatId())
+}
+
+func (s *service) UpdateChatSettings(ctx context.Context, request *chatsapi.UpdateChatSettingsRequest) (*chatsapi.ChatActionResponse, error) {
+	s.log.Infof("Request UpdateChatSettings request_id %s", request.RequestId)
+	chat, err := s.storage.ChatUpdateSettings(ctx, request.GetChatId(), request.GetUserId(), model.ChatSettings{
+		Invite: request.GetSettings().GetInvite(),
+		Post:   request.GetSettings().GetPost(),
+		Rename: request.GetSettings().GetRename(),
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.ChatActionResponse{Chat: chatInfo(chat)}, nil
+}
+
+func (s *service) GetDialog(ctx context.Context, request *chatsapi.GetDialogRequest) (*chatsapi.GetChatResponse, error) {
+	s.log.Infof("Request GetDialog request_id %s", request.RequestId)
+	chat, err := s.storage.ChatGetDialog(ctx, request.GetUserId(), request.GetPeerId())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	users, err := s.storage.ChatGetParticipants(ctx, chat.Id)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.GetChatResponse{Chat: chatInfo(chat), Users: users}, nil
+}
+
+// membersResponse Чат и его участники с ролями
+func (s *service) membersResponse(ctx context.Context, chatId int64) (*chatsapi.ChatMembersResponse, error) {
+	chat, err := s.storage.GetChat(ctx, chatId)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	members, err := s.storage.ChatGetMembers(ctx, chatId)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	response := &chatsapi.ChatMembersResponse{Chat: chatInfo(chat), Members: make([]*chatsapi.ChatMember, len(members))}
+	for i, member := range members {
+		response.Members[i] = &chatsapi.ChatMember{UserId: member.UserId, Role: member.Role}
+	}
+	return response, nil
+}
+
+// participantsResponse Чат и его участники после изменения
+func (s *service) participantsResponse(ctx context.Context, chatId int64) (*chatsapi.ChatParticipantsResponse, error) {
+	chat, err := s.storage.GetChat(ctx, chatId)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	users, err := s.storage.ChatGetParticipants(ctx, chatId)
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.ChatParticipantsResponse{Chat: chatInfo(chat), Users: users}, nil
+}
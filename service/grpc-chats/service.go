@@ -2,16 +2,20 @@ package grpc_chats
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	auth_api "github.com/basicus/hla-course/grpc/auth"
 	chatsapi "github.com/basicus/hla-course/grpc/chats"
 	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/model"
 	client_auth "github.com/basicus/hla-course/service/client-auth"
 	"github.com/basicus/hla-course/storage"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"net"
 	"net/http"
@@ -23,18 +27,20 @@ type service struct {
 	storage storage.ChatsService
 	auth    *client_auth.Service
 	srv     *grpc.Server
+	publish func(ctx context.Context, userId int64, shardId string, event model.Event) error
 	chatsapi.UnsafeChatServiceServer
 }
 
-func New(config Config, storage *storage.ChatsService, auth *client_auth.Service, loggersLogger *logrus.Logger) (*service, error) {
+func New(config Config, storage *storage.ChatsService, auth *client_auth.Service, loggersLogger *logrus.Logger, publish func(ctx context.Context, userId int64, shardId string, event model.Event) error) (*service, error) {
 	return &service{
 		config:  config,
 		storage: *storage,
 		srv: grpc.NewServer(grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			grpc_logrus.UnaryServerInterceptor(loggersLogger.WithField("role", "grpc")),
 		))),
-		log:  loggersLogger,
-		auth: auth,
+		log:     loggersLogger,
+		auth:    auth,
+		publish: publish,
 	}, nil
 }
 
@@ -73,23 +79,18 @@ func (s *service) Shutdown(_ context.Context) error {
 func (s *service) Get(ctx context.Context, request *chatsapi.GetChatRequest) (*chatsapi.GetChatResponse, error) {
 	s.log.Infof("Request Get Chat Info request_id %s", request.RequestId)
 
-	chat, err := s.storage.GetChat(ctx, request.GetChatId())
+	chat, _, err := s.storage.ChatAccess(ctx, request.GetChatId(), request.GetUserId())
 	if err != nil {
-		return nil, err
+		return nil, storageError(err)
 	}
 
 	users, err := s.storage.ChatGetParticipants(ctx, request.GetChatId())
 	if err != nil {
-		return nil, err
+		return nil, storageError(err)
 	}
 
 	return &chatsapi.GetChatResponse{
-		Chat: &chatsapi.ChatInfo{
-			ChatId:    chat.Id,
-			Title:     chat.Title,
-			CreatedAt: timestamppb.New(chat.CreatedAt),
-			Closed:    chat.Closed,
-		},
+		Chat:  chatInfo(chat),
 		Users: users,
 	}, nil
 }
@@ -99,18 +100,13 @@ func (s *service) ListChats(ctx context.Context, request *chatsapi.ListUserChats
 
 	chats, err := s.storage.UserGetChats(ctx, request.GetUserId())
 	if err != nil {
-		return nil, err
+		return nil, storageError(err)
 	}
 
 	// Convert to grpc response
 	chatResponse := make([]*chatsapi.ChatInfo, len(chats))
 	for i, chat := range chats {
-		chatResponse[i] = &chatsapi.ChatInfo{
-			ChatId:    chat.Id,
-			Title:     chat.Title,
-			CreatedAt: timestamppb.New(chat.CreatedAt),
-			Closed:    chat.Closed,
-		}
+		chatResponse[i] = chatInfo(chat)
 	}
 	return &chatsapi.ListUserChatsResponse{Chats: chatResponse}, nil
 }
@@ -118,69 +114,72 @@ func (s *service) ListChats(ctx context.Context, request *chatsapi.ListUserChats
 func (s *service) Messages(ctx context.Context, request *chatsapi.ChatMessagesRequest) (*chatsapi.ChatMessagesResponse, error) {
 	s.log.Infof("Request Chat Messages request_id %s", request.RequestId)
 
-	chat, err := s.storage.GetChat(ctx, request.GetChatId())
+	chat, _, err := s.storage.ChatAccess(ctx, request.GetChatId(), request.GetUserId())
 	if err != nil {
-		return nil, err
+		return nil, storageError(err)
 	}
 
 	messages, err := s.storage.ChatMessages(ctx, request.GetChatId(), 0, 0)
 	if err != nil {
-		return nil, err
+		return nil, storageError(err)
 	}
 
-	userNames := make(map[int64]string)
+	messageIds := make([]int64, len(messages))
+	senders := make([]int64, 0)
+	seen := make(map[int64]struct{})
+	for i, msg := range messages {
+		messageIds[i] = msg.Id
+		if _, ok := seen[msg.UserFrom]; !ok {
+			seen[msg.UserFrom] = struct{}{}
+			senders = append(senders, msg.UserFrom)
+		}
+	}
+	reactions, err := s.storage.GetMessagesReactions(ctx, messageIds)
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	// Имена всех отправителей одним запросом
+	names, err := s.auth.Client.UserNames(ctx, &auth_api.UserNamesRequest{UserIds: senders})
+	if err != nil {
+		return nil, err
+	}
+	userNames := names.GetUserNames()
 
 	// Convert to grpc response
 	messageResponse := make([]*chatsapi.ChatMessage, len(messages))
 	for i, msg := range messages {
-		// Check if we already have username in map
-		_, ok := userNames[msg.UserFrom]
-		if !ok {
-			usrName, err := s.auth.Client.UserName(ctx, &auth_api.UserNameRequest{UserId: msg.UserFrom})
-			if err != nil {
-				return nil, err
-			}
-			userNames[msg.UserFrom] = usrName.UserName
-		}
 		messageResponse[i] = &chatsapi.ChatMessage{
 			MessageId: msg.Id,
 			UserFrom:  userNames[msg.UserFrom],
 			Date:      timestamppb.New(msg.SendAt),
 			Message:   msg.Message,
+			System:    msg.System,
+			Reactions: reactions[msg.Id],
 		}
 	}
 
 	return &chatsapi.ChatMessagesResponse{
-		Chat: &chatsapi.ChatInfo{
-			ChatId:    chat.Id,
-			Title:     chat.Title,
-			CreatedAt: timestamppb.New(chat.CreatedAt),
-			Closed:    chat.Closed,
-		},
+		Chat:     chatInfo(chat),
 		Messages: messageResponse,
 	}, nil
 }
 
 func (s *service) CreateChat(ctx context.Context, request *chatsapi.CreateChatRequest) (*chatsapi.CreateChatResponse, error) {
 	s.log.Infof("Request CreateChat request_id %s", request.RequestId)
-	chat, err := s.storage.ChatCreate(ctx, request.Title, request.GetUsers()...)
+	chat, err := s.storage.ChatCreate(ctx, request.GetUserId(), request.Title, request.GetUsers()...)
 	if err != nil {
-		return nil, err
+		return nil, storageError(err)
 	}
 
-	return &chatsapi.CreateChatResponse{Chat: &chatsapi.ChatInfo{
-		ChatId:    chat.Id,
-		Title:     chat.Title,
-		CreatedAt: timestamppb.New(chat.CreatedAt),
-		Closed:    chat.Closed,
-	}}, nil
+	return &chatsapi.CreateChatResponse{Chat: chatInfo(chat)}, nil
 }
 
 func (s *service) PostMessage(ctx context.Context, request *chatsapi.PostMessageRequest) (*chatsapi.PostMessageResponse, error) {
 	s.log.Infof("Request PostMessage request_id %s", request.RequestId)
 	message, err := s.storage.MessageSave(ctx, request.ChatId, request.GetUserId(), request.Date.AsTime(), request.GetMessage())
 	if err != nil {
-		return nil, err
+		return nil, storageError(err)
 	}
 	userfrom, err := s.auth.Client.UserName(ctx, &auth_api.UserNameRequest{UserId: message.UserFrom})
 	if err != nil {
@@ -194,3 +193,71 @@ func (s *service) PostMessage(ctx context.Context, request *chatsapi.PostMessage
 		Message:   message.Message,
 	}}, nil
 }
+
+func (s *service) ReactMessage(ctx context.Context, request *chatsapi.ReactMessageRequest) (*chatsapi.ReactMessageResponse, error) {
+	s.log.Infof("Request ReactMessage request_id %s", request.RequestId)
+	message, err := s.storage.ChatMessage(ctx, request.GetChatId(), request.GetMessageId(), request.GetUserId())
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	reactions, err := s.storage.ReactMessage(ctx, message.Id, request.GetUserId(), request.GetReaction())
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	// Уведомляем автора сообщения о реакции
+	err = client_auth.NotifyReaction(ctx, s.auth.Client, s.publish, message, request.GetUserId(), request.GetReaction())
+	if err != nil {
+		s.log.Errorf("error when publish reaction info: %s", err)
+	}
+
+	return &chatsapi.ReactMessageResponse{MessageId: message.Id, Reactions: reactions}, nil
+}
+
+func (s *service) UnreactMessage(ctx context.Context, request *chatsapi.ReactMessageRequest) (*chatsapi.ReactMessageResponse, error) {
+	s.log.Infof("Request UnreactMessage request_id %s", request.RequestId)
+	message, err := s.storage.ChatMessage(ctx, request.GetChatId(), request.GetMessageId(), request.GetUserId())
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	reactions, err := s.storage.UnreactMessage(ctx, message.Id, request.GetUserId())
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &chatsapi.ReactMessageResponse{MessageId: message.Id, Reactions: reactions}, nil
+}
+
+// chatInfo Информация о чате для ответа
+func chatInfo(chat model.Chat) *chatsapi.ChatInfo {
+	return &chatsapi.ChatInfo{
+		ChatId:    chat.Id,
+		Title:     chat.Title,
+		CreatedAt: timestamppb.New(chat.CreatedAt),
+		Closed:    chat.Closed,
+		CreatedBy: chat.CreatedBy,
+		Dialog:    chat.Dialog,
+		Settings: &chatsapi.ChatSettings{
+			Invite: chat.Invite,
+			Post:   chat.Post,
+			Rename: chat.Rename,
+		},
+	}
+}
+
+// storageError Преобразование ошибок хранилища в коды gRPC
+func storageError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrBlocked), errors.Is(err, storage.ErrAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, storage.ErrChatNotFound), errors.Is(err, storage.ErrNotParticipant),
+		errors.Is(err, storage.ErrMessageNotFound), errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, storage.ErrChatClosed), errors.Is(err, storage.ErrDialogChat):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, storage.ErrInvalidChatRole), errors.Is(err, storage.ErrInvalidReaction):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
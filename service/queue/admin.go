@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// QueuesStats Состояние всех очередей сервиса
+func (s *Service) QueuesStats(ctx context.Context) ([]ServiceDataResponse, error) {
+	result := make([]ServiceDataResponse, 0, len(queueNames))
+	for _, queue := range queueNames {
+		stats, err := s.QueueStats(ctx, queue)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+	return result, nil
+}
+
+// QueueStats Состояние очереди: количество готовых, отклоненных, отложенных и обрабатываемых задач
+func (s *Service) QueueStats(ctx context.Context, queue string) (ServiceDataResponse, error) {
+	if !knownQueue(queue) {
+		return ServiceDataResponse{}, ErrUnknownQueue
+	}
+	stats, err := s.getQueueStats(queue)
+	if err != nil && !errors.Is(err, ErrNoOrEmptyQueue) {
+		return ServiceDataResponse{}, err
+	}
+	retries, err := s.redisClient.ZCard(ctx, fmt.Sprintf(retryKeyTemplate, queue)).Result()
+	if err != nil {
+		return ServiceDataResponse{}, err
+	}
+	return ServiceDataResponse{
+		Name:        queue,
+		Rejected:    stats.RejectedCount,
+		New:         stats.ReadyCount,
+		Consumers:   stats.ConsumerCount(),
+		Connections: stats.ConnectionCount(),
+		InWork:      stats.UnackedCount(),
+		Retries:     retries,
+	}, nil
+}
+
+// RejectedTasks Просмотр отклоненных задач (dead-letter) очереди
+func (s *Service) RejectedTasks(ctx context.Context, queue string, limit, offset int64) ([]string, error) {
+	if !knownQueue(queue) {
+		return nil, ErrUnknownQueue
+	}
+	return s.redisClient.LRange(ctx, fmt.Sprintf(rejectedKeyTemplate, queue), offset, offset+limit-1).Result()
+}
+
+// RequeueRejected Возврат не более count отклоненных задач в очередь
+func (s *Service) RequeueRejected(_ context.Context, queue string, count int64) (int64, error) {
+	if !knownQueue(queue) {
+		return 0, ErrUnknownQueue
+	}
+	taskQueue, err := s.getQueue(queue)
+	if err != nil {
+		return 0, err
+	}
+	returned, err := taskQueue.ReturnRejected(count)
+	if err != nil {
+		return 0, err
+	}
+	tasksProcessed.WithLabelValues(queue, outcomeRequeue).Add(float64(returned))
+	return returned, nil
+}
+
+// PurgeRejected Удаление всех отклоненных задач очереди
+func (s *Service) PurgeRejected(_ context.Context, queue string) (int64, error) {
+	if !knownQueue(queue) {
+		return 0, ErrUnknownQueue
+	}
+	taskQueue, err := s.getQueue(queue)
+	if err != nil {
+		return 0, err
+	}
+	purged, err := taskQueue.PurgeRejected()
+	if err != nil {
+		return 0, err
+	}
+	tasksProcessed.WithLabelValues(queue, outcomePurge).Add(float64(purged))
+	return purged, nil
+}
+
+func knownQueue(queue string) bool {
+	for _, name := range queueNames {
+		if name == queue {
+			return true
+		}
+	}
+	return false
+}
+
+// PurgeReady Удаление всех готовых к обработке задач очереди
+func (s *Service) PurgeReady(_ context.Context, queue string) (int64, error) {
+	if !knownQueue(queue) {
+		return 0, ErrUnknownQueue
+	}
+	taskQueue, err := s.getQueue(queue)
+	if err != nil {
+		return 0, err
+	}
+	purged, err := taskQueue.PurgeReady()
+	if err != nil {
+		return 0, err
+	}
+	tasksProcessed.WithLabelValues(queue, outcomePurge).Add(float64(purged))
+	return purged, nil
+}
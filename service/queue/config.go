@@ -3,11 +3,27 @@ package queue
 import "time"
 
 type Config struct {
-	Address                 string        `env:"REDIS_ADDRESS,default=localhost:6379"`
-	UserName                string        `env:"REDIS_USERNAME"`
-	Password                string        `env:"REDIS_PASSWORD,default=pass"`
-	Database                int           `env:"REDIS_DATABASE,default=0"`
-	PoolSize                int           `env:"REDIS_POOL_SIZE,default=5"`
-	CleanPeriod             time.Duration `env:"QUEUE_CLEANUP_PERIOD,default=300s"`
-	NumberConsumersForQueue int           `env:"CONSUMERS_PER_QUEUE,default=5"`
+	Address                    string        `env:"REDIS_ADDRESS,default=localhost:6379"`
+	UserName                   string        `env:"REDIS_USERNAME"`
+	Password                   string        `env:"REDIS_PASSWORD,default=pass"`
+	Database                   int           `env:"REDIS_DATABASE,default=0"`
+	PoolSize                   int           `env:"REDIS_POOL_SIZE,default=5"`
+	CleanPeriod                time.Duration `env:"QUEUE_CLEANUP_PERIOD,default=300s"`
+	NumberConsumersForQueue    int           `env:"CONSUMERS_PER_QUEUE,default=5"`
+	FanoutBatchSize            int           `env:"FANOUT_BATCH_SIZE,default=500"`
+	NumberFanoutConsumers      int           `env:"FANOUT_CONSUMERS,default=10"`
+	FanoutProgressTTL          time.Duration `env:"FANOUT_PROGRESS_TTL,default=24h"`
+	FanoutTimeout              time.Duration `env:"FANOUT_TIMEOUT,default=30s"`
+	MaxAttempts                int           `env:"QUEUE_MAX_ATTEMPTS,default=5"`
+	RetryBaseDelay             time.Duration `env:"QUEUE_RETRY_BASE_DELAY,default=1s"`
+	RetryMaxDelay              time.Duration `env:"QUEUE_RETRY_MAX_DELAY,default=5m"`
+	RetryPollPeriod            time.Duration `env:"QUEUE_RETRY_POLL_PERIOD,default=1s"`
+	FeedCoalesceWindow         time.Duration `env:"FEED_COALESCE_WINDOW,default=2s"`
+	FeedCacheTTL               time.Duration `env:"FEED_CACHE_TTL,default=24h"`
+	FeedPendingTTL             time.Duration `env:"FEED_PENDING_TTL,default=5m"`
+	MetricsPeriod              time.Duration `env:"QUEUE_METRICS_PERIOD,default=30s"`
+	SuggestionsLimit           int           `env:"SUGGESTIONS_LIMIT,default=20"`
+	SuggestionsCandidates      int64         `env:"SUGGESTIONS_CANDIDATES,default=200"`
+	SuggestionsCacheTTL        time.Duration `env:"SUGGESTIONS_CACHE_TTL,default=1h"`
+	NumberSuggestionsConsumers int           `env:"SUGGESTIONS_CONSUMERS,default=2"`
 }
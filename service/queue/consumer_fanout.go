@@ -0,0 +1,128 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/adjust/rmq/v4"
+	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// ConsumerFanout Рассылка публикации пакету подписчиков: обновление лент и отправка событий.
+// Выполненные пакеты отмечаются в Redis, поэтому при повторе задачи публикации повторяются только невыполненные пакеты,
+// а при ошибке рассылки повторяется только этот пакет
+type ConsumerFanout struct {
+	name    string
+	count   int
+	before  time.Time
+	logger  *logrus.Logger
+	storage storage.UserService
+	publish func(ctx context.Context, targets []model.EventTarget, event model.Event) error
+	feeds   *TaskQueue
+	redis   *redis.Client
+	config  Config
+	retry   *retryPolicy
+}
+
+func NewConsumerFanout(tag string, logger *logrus.Logger, service *storage.UserService, feeds *TaskQueue, publish func(ctx context.Context, targets []model.EventTarget, event model.Event) error, redis *redis.Client, config Config, retry *retryPolicy) *ConsumerFanout {
+	return &ConsumerFanout{
+		name:    fmt.Sprintf("consumer-%s", tag),
+		count:   0,
+		before:  time.Now(),
+		logger:  logger,
+		storage: *service,
+		feeds:   feeds,
+		publish: publish,
+		redis:   redis,
+		config:  config,
+		retry:   retry,
+	}
+}
+
+func (c *ConsumerFanout) Consume(delivery rmq.Delivery) {
+	var task TaskPostFanout
+
+	if err := json.Unmarshal([]byte(delivery.Payload()), &task); err != nil {
+		c.logger.WithField("consumer", c.name).Errorf("cant unmarshall task: %s", err)
+		c.retry.Reject(delivery, c.logger.WithField("consumer", c.name))
+		return
+	}
+
+	c.logger.WithField("consumer", c.name).Infof("consume fanout post_id %d to %d followers", task.Post.Id, len(task.Followers))
+	c.count++
+
+	fields := logrus.Fields{
+		"consumer": c.name,
+		"post_id":  task.Post.Id,
+		"batch":    task.Batch,
+	}
+	base := log.WithContext(context.Background(), c.logger.WithFields(fields))
+	// Без подтверждения брокера к сроку пакет повторяется, а не блокирует консьюмер
+	ctx, cancel := context.WithTimeout(base, c.config.FanoutTimeout)
+	defer cancel()
+
+	// Пакет уже разослан при предыдущем выполнении задачи публикации
+	doneKey := fmt.Sprintf(fanoutDoneKeyTemplate, task.Post.Id, task.Batch)
+	done, err := c.redis.Exists(ctx, doneKey).Result()
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on check fanout progress for post_id %d: %s", task.Post.Id, err)
+		c.retry.Retry(base, delivery, c.logger.WithFields(fields))
+		return
+	}
+	if done > 0 {
+		c.logger.WithFields(fields).Infof("fanout batch %d of post_id %d already done", task.Batch, task.Post.Id)
+		c.retry.Ack(delivery, c.logger.WithFields(fields))
+		return
+	}
+
+	followers, err := c.storage.GetByIds(ctx, task.Followers)
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on get followers info for post_id %d: %s", task.Post.Id, err)
+		c.retry.Retry(base, delivery, c.logger.WithFields(fields))
+		return
+	}
+
+	// Ставим на обновление кэши пользователей
+	targets := make([]model.EventTarget, 0, len(followers))
+	userIds := make([]int64, 0, len(followers))
+	for _, follower := range followers {
+		userIds = append(userIds, follower.UserId)
+		targets = append(targets, model.EventTarget{UserId: follower.UserId, ShardId: follower.ShardId})
+	}
+	if err := c.feeds.AddTasksUpdateUserIdFeed(ctx, userIds); err != nil {
+		c.logger.WithFields(fields).Errorf("error on queue for update feed of %d followers: %s", len(userIds), err)
+	}
+
+	// Отправляем событие о публикации всем подписчикам пакета
+	eventPost := model.EventPost{
+		Data: model.PostDTO{
+			UserFrom: task.UserFrom,
+			Title:    task.Post.Title,
+			Message:  task.Post.Message,
+		},
+	}
+	if err := c.publish(ctx, targets, &eventPost); err != nil {
+		c.logger.WithFields(fields).Errorf("error when publish post info: %s", err)
+		c.retry.Retry(base, delivery, c.logger.WithFields(fields))
+		return
+	}
+
+	if err := c.redis.Set(base, doneKey, 1, c.config.FanoutProgressTTL).Err(); err != nil {
+		c.logger.WithFields(fields).Errorf("error on save fanout progress for post_id %d: %s", task.Post.Id, err)
+	}
+	c.retry.Ack(delivery, c.logger.WithFields(fields))
+	c.logger.WithFields(fields).Infof("processed fanout post_id %d to %d followers", task.Post.Id, len(targets))
+
+	// Сообщает о скорости обработки запросов
+	if c.count%consumerReportBatchSize == 0 {
+		duration := time.Now().Sub(c.before)
+		c.before = time.Now()
+		perSecond := time.Second / (duration / consumerReportBatchSize)
+		c.logger.WithField("consumer", c.name).Infof("consumed %d %d r/s", c.count, perSecond)
+	}
+}
@@ -20,9 +20,10 @@ type ConsumerUserFeed struct {
 	logger  *logrus.Logger
 	storage storage.UserService
 	redis   *redis.Client
+	retry   *retryPolicy
 }
 
-func NewConsumerUserFeed(tag string, logger *logrus.Logger, service *storage.UserService, redis *redis.Client) *ConsumerUserFeed {
+func NewConsumerUserFeed(tag string, logger *logrus.Logger, service *storage.UserService, redis *redis.Client, retry *retryPolicy) *ConsumerUserFeed {
 	return &ConsumerUserFeed{
 		name:    fmt.Sprintf("consumer-%s", tag),
 		count:   0,
@@ -30,6 +31,7 @@ func NewConsumerUserFeed(tag string, logger *logrus.Logger, service *storage.Use
 		logger:  logger,
 		storage: *service,
 		redis:   redis,
+		retry:   retry,
 	}
 }
 
@@ -37,9 +39,8 @@ func (c *ConsumerUserFeed) Consume(delivery rmq.Delivery) {
 	var task TaskUpdateUserIdFeed
 
 	if err := json.Unmarshal([]byte(delivery.Payload()), &task); err != nil {
-		if err := delivery.Reject(); err != nil {
-			c.logger.WithField("consumer", c.name).Errorf("cant unmarshall task with user_id %d: %e", task.UserId, err)
-		}
+		c.logger.WithField("consumer", c.name).Errorf("cant unmarshall task: %s", err)
+		c.retry.Reject(delivery, c.logger.WithField("consumer", c.name))
 		return
 	}
 
@@ -53,27 +54,36 @@ func (c *ConsumerUserFeed) Consume(delivery rmq.Delivery) {
 	}
 	ctx := log.WithContext(context.Background(), c.logger.WithFields(fields))
 
+	// Снимаем признак ожидающего обновления до чтения данных: более поздние изменения запланируют новое обновление
+	if task.Key != "" {
+		if err := c.redis.Del(ctx, task.Key).Err(); err != nil {
+			c.logger.WithFields(fields).Errorf("error on release pending key for user_id %d: %s", task.UserId, err)
+		}
+	}
+
 	// Обновляем кэш пользователя
 	friendsPosts, err := c.storage.GetFriendsPosts(ctx, task.UserId, 1000)
 	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on get friends posts for user_id %d: %s", task.UserId, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
 		return
 	}
 	friendPostsJson, err := json.Marshal(friendsPosts)
 	if err != nil {
-		c.logger.WithField("consumer", c.name).Errorf("error on marshalling friends posts %e", err)
+		c.logger.WithFields(fields).Errorf("error on marshalling friends posts %s", err)
+		c.retry.Reject(delivery, c.logger.WithFields(fields))
+		return
 	}
 
 	_, err = c.redis.Set(ctx, "user_feed"+strconv.FormatInt(task.UserId, 10), friendPostsJson, 0).Result()
 	if err != nil {
-		c.logger.WithField("consumer", c.name).Errorf("error on set cached value for feed user_id %d  %e", task.UserId, err)
+		c.logger.WithFields(fields).Errorf("error on set cached value for feed user_id %d: %s", task.UserId, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
+		return
 	}
 
-	if err := delivery.Ack(); err != nil {
-		c.logger.WithFields(fields).Errorf("post error ack queue update feed for user_id %d: %e", task.UserId, err)
-
-	} else {
-		c.logger.WithFields(fields).Infof("acked task update feed for user_id %d", task.UserId)
-	}
+	c.retry.Ack(delivery, c.logger.WithFields(fields))
+	c.logger.WithFields(fields).Infof("processed task update feed for user_id %d", task.UserId)
 
 	// Сообщает о скорости обработки запросов
 	if c.count%consumerReportBatchSize == 0 {
@@ -9,28 +9,31 @@ import (
 	"github.com/basicus/hla-course/model"
 	"github.com/basicus/hla-course/storage"
 	"github.com/sirupsen/logrus"
+	"sort"
 	"time"
 )
 
 type ConsumerPost struct {
-	name    string
-	count   int
-	before  time.Time
-	logger  *logrus.Logger
-	storage storage.UserService
-	publish func(ctx context.Context, userId int64, shardId string, event model.Event) error
-	queue   *TaskQueue
+	name      string
+	count     int
+	before    time.Time
+	logger    *logrus.Logger
+	storage   storage.UserService
+	fanout    *TaskQueue
+	batchSize int
+	retry     *retryPolicy
 }
 
-func NewConsumerPost(tag string, logger *logrus.Logger, service *storage.UserService, queue *TaskQueue, publish func(ctx context.Context, userId int64, shardId string, event model.Event) error) *ConsumerPost {
+func NewConsumerPost(tag string, logger *logrus.Logger, service *storage.UserService, fanout *TaskQueue, batchSize int, retry *retryPolicy) *ConsumerPost {
 	return &ConsumerPost{
-		name:    fmt.Sprintf("consumer-%s", tag),
-		count:   0,
-		before:  time.Now(),
-		logger:  logger,
-		storage: *service,
-		queue:   queue,
-		publish: publish,
+		name:      fmt.Sprintf("consumer-%s", tag),
+		count:     0,
+		before:    time.Now(),
+		logger:    logger,
+		storage:   *service,
+		fanout:    fanout,
+		batchSize: batchSize,
+		retry:     retry,
 	}
 }
 
@@ -38,9 +41,8 @@ func (c *ConsumerPost) Consume(delivery rmq.Delivery) {
 	var task TaskPost
 
 	if err := json.Unmarshal([]byte(delivery.Payload()), &task); err != nil {
-		if err := delivery.Reject(); err != nil {
-			c.logger.WithField("consumer", c.name).Errorf("cant unmarshall task with post id  %d for user_id %d: %e", task.Post.Id, task.Post.UserId, err)
-		}
+		c.logger.WithField("consumer", c.name).Errorf("cant unmarshall task: %s", err)
+		c.retry.Reject(delivery, c.logger.WithField("consumer", c.name))
 		return
 	}
 
@@ -54,53 +56,55 @@ func (c *ConsumerPost) Consume(delivery rmq.Delivery) {
 	}
 	ctx := log.WithContext(context.Background(), c.logger.WithFields(fields))
 
-	followers, err := c.storage.GetUserFollowers(ctx, task.Post.UserId)
+	// Приватные публикации не попадают в ленты подписчиков
+	if task.Post.Visibility == model.PostVisibilityPrivate {
+		c.retry.Ack(delivery, c.logger.WithFields(fields))
+		return
+	}
+
+	var followers []int64
+	var err error
+	if task.Post.Visibility == model.PostVisibilityFriends {
+		// Публикации только для друзей рассылаются друзьям, а не всем подписчикам
+		followers, err = c.storage.GetFriendIds(ctx, task.Post.UserId)
+	} else {
+		followers, err = c.storage.GetUserFollowers(ctx, task.Post.UserId)
+	}
 
 	if err != nil {
 		// Cant get user followers
-		c.logger.WithFields(fields).Infof("consume post_id %d error for user_id %d  when get follower list: %e", task.Post.Id, task.Post.UserId, err)
-		_ = delivery.Reject()
+		c.logger.WithFields(fields).Errorf("consume post_id %d error for user_id %d when get follower list: %s", task.Post.Id, task.Post.UserId, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
+		return
 	}
 
+	// Пользователи, скрывшие или заблокировавшие автора, не получают публикацию
+	blockedBy, err := c.storage.GetBlockedByIds(ctx, task.Post.UserId)
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("consume post_id %d error for user_id %d when get blocked list: %s", task.Post.Id, task.Post.UserId, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
+		return
+	}
+	followers = excludeIds(followers, blockedBy)
+
 	var userName string
 	userName, err = c.storage.GetUserName(ctx, task.Post.UserId)
 	if err != nil {
 		userName = ""
 	}
 
-	// Ставим на обновление кэши пользователей
-	for _, followerId := range followers {
-		// Queue follower id for update feed
-		err := c.queue.AddTaskUpdateUserIdFeed(followerId)
-		if err != nil {
-			c.logger.WithFields(fields).Errorf("error on queue for update feed for follower_id %d: %s", followerId, err)
-		} else {
-			c.logger.WithFields(fields).Infof("successfully queued for update feed for follower_id %d", followerId)
-		}
-		// Пытаемся отправить событие (не самое оптимальное место)
-		userInfo, err := c.storage.GetById(ctx, followerId)
-		if err == nil {
-			// User found
-			eventPost := model.EventPost{
-				Data: model.PostDTO{
-					UserFrom: userName,
-					Title:    task.Post.Title,
-					Message:  task.Post.Message,
-				},
-			}
-			err := c.publish(ctx, userInfo.UserId, userInfo.ShardId, &eventPost)
-			if err != nil {
-				c.logger.Errorf("error when publish post info: %s", err.Error())
-			}
-		}
+	// Разбиваем подписчиков на пакеты, которые обрабатываются параллельно консьюмерами рассылки.
+	// Порядок подписчиков фиксирован, чтобы при повторе задачи пакеты совпадали с уже выполненными
+	sort.Slice(followers, func(i, j int) bool { return followers[i] < followers[j] })
+	err = c.fanout.AddTasksPostFanout(task.Post, userName, followers, c.batchSize)
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on queue fanout of post_id %d: %s", task.Post.Id, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
+		return
 	}
 
-	if err := delivery.Ack(); err != nil {
-		c.logger.WithFields(fields).Errorf("post error ack queue update followers post_id %d for user_id %d: %e", task.Post.Id, task.Post.UserId, err)
-
-	} else {
-		c.logger.WithFields(fields).Infof("acked task post_id %d for user_id %d", task.Post.Id, task.Post.UserId)
-	}
+	c.retry.Ack(delivery, c.logger.WithFields(fields))
+	c.logger.WithFields(fields).Infof("processed task post_id %d for user_id %d", task.Post.Id, task.Post.UserId)
 
 	// Сообщает по скорости обработки запросов
 	if c.count%consumerReportBatchSize == 0 {
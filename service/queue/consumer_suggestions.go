@@ -0,0 +1,106 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/adjust/rmq/v4"
+	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// ConsumerSuggestions Пересчет рекомендаций друзей пользователя и сохранение их в кэш
+type ConsumerSuggestions struct {
+	name    string
+	count   int
+	before  time.Time
+	logger  *logrus.Logger
+	storage storage.UserService
+	redis   *redis.Client
+	config  Config
+	retry   *retryPolicy
+}
+
+func NewConsumerSuggestions(tag string, logger *logrus.Logger, service *storage.UserService, redis *redis.Client, config Config, retry *retryPolicy) *ConsumerSuggestions {
+	return &ConsumerSuggestions{
+		name:    fmt.Sprintf("consumer-%s", tag),
+		count:   0,
+		before:  time.Now(),
+		logger:  logger,
+		storage: *service,
+		redis:   redis,
+		config:  config,
+		retry:   retry,
+	}
+}
+
+func (c *ConsumerSuggestions) Consume(delivery rmq.Delivery) {
+	var task TaskUpdateSuggestions
+
+	if err := json.Unmarshal([]byte(delivery.Payload()), &task); err != nil {
+		c.logger.WithField("consumer", c.name).Errorf("cant unmarshall task: %s", err)
+		c.retry.Reject(delivery, c.logger.WithField("consumer", c.name))
+		return
+	}
+
+	c.logger.WithField("consumer", c.name).Infof("consume update suggestions for user_id %d", task.UserId)
+	c.count++
+
+	fields := logrus.Fields{
+		"consumer": c.name,
+		"user_id":  task.UserId,
+	}
+	ctx := log.WithContext(context.Background(), c.logger.WithFields(fields))
+
+	// Снимаем признак ожидающего пересчета до чтения данных: более поздние изменения запланируют новый пересчет
+	if task.Key != "" {
+		if err := c.redis.Del(ctx, task.Key).Err(); err != nil {
+			c.logger.WithFields(fields).Errorf("error on release pending key for user_id %d: %s", task.UserId, err)
+		}
+	}
+
+	user, err := c.storage.GetById(ctx, task.UserId)
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on get user_id %d: %s", task.UserId, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
+		return
+	}
+	candidates, err := c.storage.GetSuggestionCandidates(ctx, task.UserId, c.config.SuggestionsCandidates)
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on get suggestion candidates for user_id %d: %s", task.UserId, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
+		return
+	}
+	suggestions := rankSuggestions(user, candidates, c.config.SuggestionsLimit)
+	if suggestions == nil {
+		suggestions = []model.FriendSuggestion{}
+	}
+
+	suggestionsJson, err := json.Marshal(suggestions)
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on marshalling suggestions %s", err)
+		c.retry.Reject(delivery, c.logger.WithFields(fields))
+		return
+	}
+	err = c.redis.Set(ctx, fmt.Sprintf(suggestionsCacheKeyTemplate, task.UserId), suggestionsJson, c.config.SuggestionsCacheTTL).Err()
+	if err != nil {
+		c.logger.WithFields(fields).Errorf("error on set cached suggestions for user_id %d: %s", task.UserId, err)
+		c.retry.Retry(ctx, delivery, c.logger.WithFields(fields))
+		return
+	}
+
+	c.retry.Ack(delivery, c.logger.WithFields(fields))
+	c.logger.WithFields(fields).Infof("processed task update suggestions for user_id %d", task.UserId)
+
+	// Сообщает о скорости обработки запросов
+	if c.count%consumerReportBatchSize == 0 {
+		duration := time.Now().Sub(c.before)
+		c.before = time.Now()
+		perSecond := time.Second / (duration / consumerReportBatchSize)
+		c.logger.WithField("consumer", c.name).Infof("consumed %d %d r/s", c.count, perSecond)
+	}
+}
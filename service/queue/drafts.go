@@ -0,0 +1,64 @@
+package queue
+
+import (
+	"context"
+	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"time"
+)
+
+const scheduledPostsBatchSize = 100
+
+// PublishDraft Публикация черновика тем же путем, что и обычная публикация (PublishPost и рассылка подписчикам)
+func (s *Service) PublishDraft(ctx context.Context, draft model.Draft) (model.Post, error) {
+	// Захват черновика, публикация и привязка поста выполняются в одной транзакции:
+	// при ошибке черновик остается в прежнем статусе
+	var post model.Post
+	err := (*s.storage).WithTx(ctx, func(ctx context.Context) error {
+		claimed, err := (*s.storage).ClaimDraft(ctx, draft.Id)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return storage.ErrDraftNotEditable
+		}
+		post, err = (*s.storage).PublishPost(ctx, draft.UserId, draft.Title, draft.Message, draft.Visibility)
+		if err != nil {
+			return err
+		}
+		return (*s.storage).SetDraftPost(ctx, draft.Id, post.Id)
+	})
+	if err != nil {
+		return model.Post{}, err
+	}
+
+	// Добавляем в очередь обновление лент пользователей после добавления нового поста
+	_ = s.NewPost(ctx, post)
+	return post, nil
+}
+
+// PublishScheduledPosts Публикация запланированных черновиков, время которых наступило
+func (s *Service) PublishScheduledPosts(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+	for {
+		drafts, err := (*s.storage).GetDueDrafts(ctx, time.Now(), scheduledPostsBatchSize)
+		if err != nil {
+			return err
+		}
+		published := 0
+		for _, draft := range drafts {
+			post, err := s.PublishDraft(ctx, draft)
+			if err != nil {
+				logger.WithError(err).Errorf("cant publish scheduled draft %d", draft.Id)
+				continue
+			}
+			published++
+			logger.Infof("scheduled draft %d published as post %d", draft.Id, post.Id)
+		}
+		// Неудачные публикации повторятся при следующем запуске
+		if len(drafts) < scheduledPostsBatchSize || published == 0 {
+			return nil
+		}
+	}
+}
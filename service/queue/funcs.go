@@ -1 +1,19 @@
 package queue
+
+// excludeIds Удаление из списка ids идентификаторов из списка exclude
+func excludeIds(ids []int64, exclude []int64) []int64 {
+	if len(exclude) == 0 {
+		return ids
+	}
+	skip := make(map[int64]struct{}, len(exclude))
+	for _, id := range exclude {
+		skip[id] = struct{}{}
+	}
+	result := ids[:0]
+	for _, id := range ids {
+		if _, ok := skip[id]; !ok {
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -1,81 +1,103 @@
 package queue
 
 import (
+	"context"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
 	"time"
 )
 
-type qStatCounters struct {
-	readyCount      prometheus.Gauge
-	rejectedCount   prometheus.Gauge
-	connectionCount prometheus.Gauge
-	consumerCount   prometheus.Gauge
-	unackedCount    prometheus.Gauge
-}
+const (
+	outcomeAck     = "ack"
+	outcomeRetry   = "retry"
+	outcomeReject  = "reject"
+	outcomeRequeue = "requeue"
+	outcomePurge   = "purge"
+)
+
+const (
+	resultPublished = "published"
+	resultCoalesced = "coalesced"
+)
+
+// tasksPublished Количество поставленных в очередь и объединенных с ожидающими задач
+var tasksPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "queue",
+	Name:      "tasks_published_total",
+	Help:      "Number of published and coalesced tasks by queue",
+}, []string{"queue", "result"})
+
+// tasksProcessed Количество обработанных задач в разрезе очереди и результата
+var tasksProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "queue",
+	Name:      "tasks_total",
+	Help:      "Number of processed tasks by queue and outcome",
+}, []string{"queue", "outcome"})
 
-// TODO добавить в экспортер метрик
-func (s *Service) setupCollectMetrics() {
-	go func() {
-		for {
-			queues, _ := s.connection.GetOpenQueues()
-			stats, _ := s.connection.CollectStats(queues)
-			for queue, queueStats := range stats.QueueStats {
-				var counter *qStatCounters
-				if c, ok := s.counters[queue]; ok {
-					counter = c
-				} else {
-					counter = getPromCounter(queue)
-					s.counters[queue] = counter
-					prometheus.MustRegister(counter.readyCount)
-					prometheus.MustRegister(counter.rejectedCount)
-					prometheus.MustRegister(counter.connectionCount)
-					prometheus.MustRegister(counter.consumerCount)
-					prometheus.MustRegister(counter.unackedCount)
-				}
+// Состояние очередей, обновляется периодически из статистики rmq
+var (
+	readyCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "queue",
+		Name:      "new",
+		Help:      "Number of ready messages on queue",
+	}, []string{"queue"})
+	rejectedCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "queue",
+		Name:      "rejected",
+		Help:      "Number of rejected messages on queue",
+	}, []string{"queue"})
+	connectionCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "queue",
+		Name:      "connection",
+		Help:      "Number of connections consuming a queue",
+	}, []string{"queue"})
+	consumerCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "queue",
+		Name:      "consumer",
+		Help:      "Number of consumers consuming messages for a queue",
+	}, []string{"queue"})
+	unackedCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "queue",
+		Name:      "in_work",
+		Help:      "Number of unacked (in work) messages on a consumer",
+	}, []string{"queue"})
+)
+
+func init() {
+	prometheus.MustRegister(tasksPublished, tasksProcessed, readyCount, rejectedCount, connectionCount, consumerCount, unackedCount)
+}
 
-				counter.readyCount.Set(float64(queueStats.ReadyCount))
-				counter.rejectedCount.Set(float64(queueStats.RejectedCount))
-				counter.connectionCount.Set(float64(queueStats.ConnectionCount()))
-				counter.consumerCount.Set(float64(queueStats.ConsumerCount()))
-				counter.unackedCount.Set(float64(queueStats.UnackedCount()))
-			}
+// collectMetrics Периодически экспортирует состояние очередей в метрики
+func (s *Service) collectMetrics(ctx context.Context, logger *logrus.Entry) {
+	ticker := time.NewTicker(s.config.MetricsPeriod)
+	defer ticker.Stop()
 
-			time.Sleep(30 * time.Second)
+	for {
+		s.updateMetrics(logger)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
 		}
-	}()
+	}
 }
 
-func getPromCounter(queue string) *qStatCounters {
-	return &qStatCounters{
-		readyCount: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace:   "queue",
-			Name:        "new",
-			Help:        "Number of ready messages on queue",
-			ConstLabels: prometheus.Labels{"queue": queue},
-		}),
-		rejectedCount: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace:   "queue",
-			Name:        "rejected",
-			Help:        "Number of rejected messages on queue",
-			ConstLabels: prometheus.Labels{"queue": queue},
-		}),
-		connectionCount: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace:   "queue",
-			Name:        "connection",
-			Help:        "Number of connections consuming a queue",
-			ConstLabels: prometheus.Labels{"queue": queue},
-		}),
-		consumerCount: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace:   "queue",
-			Name:        "consumer",
-			Help:        "Number of consumers consuming messages for a queue",
-			ConstLabels: prometheus.Labels{"queue": queue},
-		}),
-		unackedCount: prometheus.NewGauge(prometheus.GaugeOpts{
-			Namespace:   "queue",
-			Name:        "in_work",
-			Help:        "Number of unacked (in work) messages on a consumer",
-			ConstLabels: prometheus.Labels{"queue": queue},
-		}),
+func (s *Service) updateMetrics(logger *logrus.Entry) {
+	queues, err := s.connection.GetOpenQueues()
+	if err != nil {
+		logger.Errorf("failed to get open queues: %s", err)
+		return
+	}
+	stats, err := s.connection.CollectStats(queues)
+	if err != nil {
+		logger.Errorf("failed to collect queues stats: %s", err)
+		return
+	}
+	for queue, queueStats := range stats.QueueStats {
+		readyCount.WithLabelValues(queue).Set(float64(queueStats.ReadyCount))
+		rejectedCount.WithLabelValues(queue).Set(float64(queueStats.RejectedCount))
+		connectionCount.WithLabelValues(queue).Set(float64(queueStats.ConnectionCount()))
+		consumerCount.WithLabelValues(queue).Set(float64(queueStats.ConsumerCount()))
+		unackedCount.WithLabelValues(queue).Set(float64(queueStats.UnackedCount()))
 	}
 }
@@ -0,0 +1,22 @@
+package queue
+
+import (
+	"context"
+	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/model"
+)
+
+// Notify Отправить событие пользователю через очередь событий (с учетом шарда пользователя)
+func (s *Service) Notify(ctx context.Context, userId int64, event model.Event) error {
+	logger := log.Ctx(ctx)
+	user, err := (*s.storage).GetById(ctx, userId)
+	if err != nil {
+		logger.WithError(err).Errorf("cant get user_id %d for notify event %s", userId, event.GetType())
+		return err
+	}
+	if err := s.publish(ctx, user.UserId, user.ShardId, event); err != nil {
+		logger.WithError(err).Errorf("error when publish event %s for user_id %d", event.GetType(), userId)
+		return err
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package queue
 
 type ServiceDataResponse struct {
-	Rejected  int64 `json:"rejected"`
-	New       int64 `json:"new"`
-	Consumers int64 `json:"consumers"`
-	InWork    int64 `json:"in_work"`
+	Name        string `json:"name"`
+	Rejected    int64  `json:"rejected"`
+	New         int64  `json:"new"`
+	Consumers   int64  `json:"consumers"`
+	Connections int64  `json:"connections"`
+	InWork      int64  `json:"in_work"`
+	Retries     int64  `json:"retries"`
 }
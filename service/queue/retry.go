@@ -0,0 +1,172 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/adjust/rmq/v4"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+const (
+	// retryKeyTemplate ZSET отложенных повторов задач очереди (score - время повтора).
+	// В нем же откладываются объединяемые обновления ленты
+	retryKeyTemplate = "timeline::queue::[%s]::retry"
+	// readyKeyTemplate Список готовых к обработке задач очереди rmq
+	readyKeyTemplate = "rmq::queue::[%s]::ready"
+	// rejectedKeyTemplate Список отклоненных задач очереди rmq (dead-letter)
+	rejectedKeyTemplate = "rmq::queue::[%s]::rejected"
+
+	retryMoveBatchSize = 100
+)
+
+// moveDueScript Атомарно переносит наступившие отложенные задачи из ZSET в очередь rmq
+var moveDueScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, item in ipairs(items) do
+	redis.call('ZREM', KEYS[1], item)
+	redis.call('LPUSH', KEYS[2], item)
+end
+return #items
+`)
+
+// taskAttempt Номер попытки выполнения задачи
+type taskAttempt struct {
+	Attempt int `json:"attempt"`
+}
+
+// retryPolicy Повторное выполнение задач с экспоненциальной задержкой
+type retryPolicy struct {
+	queue       string
+	redis       *redis.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+func newRetryPolicy(queue string, redis *redis.Client, config Config) *retryPolicy {
+	return &retryPolicy{
+		queue:       queue,
+		redis:       redis,
+		maxAttempts: config.MaxAttempts,
+		baseDelay:   config.RetryBaseDelay,
+		maxDelay:    config.RetryMaxDelay,
+	}
+}
+
+// Ack Подтверждение успешной обработки задачи
+func (r *retryPolicy) Ack(delivery rmq.Delivery, logger *logrus.Entry) {
+	if err := delivery.Ack(); err != nil {
+		logger.Errorf("error ack task: %s", err)
+		return
+	}
+	tasksProcessed.WithLabelValues(r.queue, outcomeAck).Inc()
+}
+
+// Reject Отклонение задачи без повторов (попадает в dead-letter)
+func (r *retryPolicy) Reject(delivery rmq.Delivery, logger *logrus.Entry) {
+	if err := delivery.Reject(); err != nil {
+		logger.Errorf("error reject task: %s", err)
+		return
+	}
+	tasksProcessed.WithLabelValues(r.queue, outcomeReject).Inc()
+}
+
+// Retry Планирует повторное выполнение задачи, либо отклоняет ее при исчерпании попыток
+func (r *retryPolicy) Retry(ctx context.Context, delivery rmq.Delivery, logger *logrus.Entry) {
+	payload, attempt, err := nextAttempt(delivery.Payload())
+	if err != nil || attempt >= r.maxAttempts {
+		logger.Warnf("task rejected after %d attempts", attempt)
+		r.Reject(delivery, logger)
+		return
+	}
+
+	delay := r.backoff(attempt)
+	err = publishDelayed(ctx, r.redis, r.queue, delay, payload)
+	if err != nil {
+		// Не удалось отложить задачу, оставляем ее в unacked до очистки
+		logger.Errorf("error schedule task retry: %s", err)
+		return
+	}
+	if err := delivery.Ack(); err != nil {
+		logger.Errorf("error ack retried task: %s", err)
+		return
+	}
+	logger.Infof("task retry %d scheduled in %s", attempt, delay)
+	tasksProcessed.WithLabelValues(r.queue, outcomeRetry).Inc()
+}
+
+// backoff Задержка перед попыткой attempt (экспоненциальная, с ограничением и разбросом до 10%)
+func (r *retryPolicy) backoff(attempt int) time.Duration {
+	delay := r.baseDelay
+	for i := 1; i < attempt && delay < r.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.maxDelay {
+		delay = r.maxDelay
+	}
+	if jitter := int64(delay / 10); jitter > 0 {
+		delay += time.Duration(rand.Int63n(jitter))
+	}
+	return delay
+}
+
+// nextAttempt Увеличивает номер попытки в теле задачи
+func nextAttempt(payload string) (string, int, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(payload), &fields); err != nil {
+		return "", 0, err
+	}
+	var current taskAttempt
+	if err := json.Unmarshal([]byte(payload), &current); err != nil {
+		return "", 0, err
+	}
+	attempt := current.Attempt + 1
+	fields["attempt"] = json.RawMessage(strconv.Itoa(attempt))
+	result, err := json.Marshal(fields)
+	if err != nil {
+		return "", attempt, err
+	}
+	return string(result), attempt, nil
+}
+
+// publishDelayed Откладывает постановку задач в очередь на время delay одной командой
+func publishDelayed(ctx context.Context, client *redis.Client, queue string, delay time.Duration, payloads ...string) error {
+	score := float64(time.Now().Add(delay).UnixMilli())
+	members := make([]*redis.Z, 0, len(payloads))
+	for _, payload := range payloads {
+		members = append(members, &redis.Z{Score: score, Member: payload})
+	}
+	return client.ZAdd(ctx, fmt.Sprintf(retryKeyTemplate, queue), members...).Err()
+}
+
+// RetryMover Периодически возвращает в очереди задачи, время повтора которых наступило
+// (в том числе отложенные объединенные обновления ленты)
+func (s *Service) RetryMover(ctx context.Context, logger *logrus.Entry) {
+	ticker := time.NewTicker(s.config.RetryPollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, queue := range queueNames {
+			moved, err := moveDueScript.Run(ctx, s.redisClient,
+				[]string{fmt.Sprintf(retryKeyTemplate, queue), fmt.Sprintf(readyKeyTemplate, queue)},
+				time.Now().UnixMilli(), retryMoveBatchSize).Int64()
+			if err != nil {
+				logger.Errorf("failed to move retries of queue %s: %s", queue, err)
+				continue
+			}
+			if moved > 0 {
+				logger.Infof("returned %d retries to queue %s", moved, queue)
+			}
+		}
+	}
+}
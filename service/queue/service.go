@@ -17,30 +17,38 @@ const (
 	RedisTimelineTag = "timeline-update"
 	queueNamePosts   = "post"
 	queueNameFeed    = "feed"
+	queueNameFanout  = "fanout"
+	queueNameSuggest = "suggestions"
+
+	feedCacheKeyPattern = "user_feed*"
+	feedCacheScanCount  = 1000
 
 	prefetchLimit = 10
 	pollPeriod    = 1 * time.Second
 )
 
+var queueNames = []string{queueNamePosts, queueNameFanout, queueNameFeed, queueNameSuggest}
+
 var (
 	ErrUnknownQueue   = errors.New("unknown queue")
 	ErrNoOrEmptyQueue = errors.New("empty or unknown queue")
 )
 
 type Service struct {
-	connection  rmq.Connection
-	redisClient *redis.Client
-	log         *logrus.Logger
-	storage     *storage.UserService
-	queues      map[string]*TaskQueue
-	err         chan error
-	config      Config
-	counters    map[string]*qStatCounters
-	publish     func(ctx context.Context, userId int64, shardId string, event model.Event) error
+	connection   rmq.Connection
+	redisClient  *redis.Client
+	log          *logrus.Logger
+	storage      *storage.UserService
+	queues       map[string]*TaskQueue
+	err          chan error
+	config       Config
+	publish      func(ctx context.Context, userId int64, shardId string, event model.Event) error
+	publishBatch func(ctx context.Context, targets []model.EventTarget, event model.Event) error
 }
 
 // New Инициализация сервиса
-func New(config Config, storage *storage.UserService, logger *logrus.Logger, publish func(ctx context.Context, userId int64, shardId string, event model.Event) error) (*Service, error) {
+func New(config Config, storage *storage.UserService, logger *logrus.Logger, publish func(ctx context.Context, userId int64, shardId string, event model.Event) error,
+	publishBatch func(ctx context.Context, targets []model.EventTarget, event model.Event) error) (*Service, error) {
 	e := make(chan error, 10)
 	redisClient := redis.NewClient(
 		&redis.Options{
@@ -58,14 +66,15 @@ func New(config Config, storage *storage.UserService, logger *logrus.Logger, pub
 	}
 	logger.Info("Created new instance Queue service")
 	return &Service{
-		connection:  connection,
-		err:         e,
-		log:         logger,
-		redisClient: redisClient,
-		storage:     storage,
-		queues:      make(map[string]*TaskQueue),
-		config:      config,
-		publish:     publish,
+		connection:   connection,
+		err:          e,
+		log:          logger,
+		redisClient:  redisClient,
+		storage:      storage,
+		queues:       make(map[string]*TaskQueue),
+		config:       config,
+		publish:      publish,
+		publishBatch: publishBatch,
 	}, nil
 }
 
@@ -101,7 +110,7 @@ func (s *Service) UpdateFeed(ctx context.Context, userId int64) error {
 	if err != nil {
 		return err
 	}
-	err = taskQueue.AddTaskUpdateUserIdFeed(userId)
+	err = taskQueue.AddTaskUpdateUserIdFeed(ctx, userId)
 	if err != nil {
 		logger.WithError(err).Error("error on adding queue update feed to queue")
 		return err
@@ -123,6 +132,10 @@ func (s *Service) StartConsumers(_ context.Context) error {
 	if err != nil {
 		return err
 	}
+	taskFanoutQueue, err := s.getQueue(queueNameFanout)
+	if err != nil {
+		return err
+	}
 	taskFeedsQueue, err := s.getQueue(queueNameFeed)
 	if err != nil {
 		return err
@@ -132,7 +145,20 @@ func (s *Service) StartConsumers(_ context.Context) error {
 	for i := 0; i < s.config.NumberConsumersForQueue; i++ {
 		name := fmt.Sprintf("consumer-%s", queueNamePosts)
 		s.log.Infof("adding consumer %d name %s", i, name)
-		if _, err := taskPostsQueue.AddConsumer(name, NewConsumerPost(fmt.Sprintf("%s-%d", name, i), s.log, s.storage, taskFeedsQueue, s.publish)); err != nil {
+		if _, err := taskPostsQueue.AddConsumer(name, NewConsumerPost(fmt.Sprintf("%s-%d", name, i), s.log, s.storage, taskFanoutQueue, s.config.FanoutBatchSize, newRetryPolicy(queueNamePosts, s.redisClient, s.config))); err != nil {
+			return err
+		}
+	}
+
+	err = taskFanoutQueue.StartConsuming(prefetchLimit, pollPeriod)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < s.config.NumberFanoutConsumers; i++ {
+		name := fmt.Sprintf("consumer-%s", queueNameFanout)
+		s.log.Infof("adding consumer %d name %s", i, name)
+		if _, err := taskFanoutQueue.AddConsumer(name, NewConsumerFanout(fmt.Sprintf("%s-%d", name, i), s.log, s.storage, taskFeedsQueue, s.publishBatch, s.redisClient, s.config, newRetryPolicy(queueNameFanout, s.redisClient, s.config))); err != nil {
 			return err
 		}
 	}
@@ -145,7 +171,24 @@ func (s *Service) StartConsumers(_ context.Context) error {
 	for i := 0; i < s.config.NumberConsumersForQueue; i++ {
 		name := fmt.Sprintf("consumer-%s", queueNameFeed)
 		s.log.Infof("adding consumer %d name %s", i, name)
-		if _, err := taskFeedsQueue.AddConsumer(name, NewConsumerUserFeed(fmt.Sprintf("%s-%d", name, i), s.log, s.storage, s.redisClient)); err != nil {
+		if _, err := taskFeedsQueue.AddConsumer(name, NewConsumerUserFeed(fmt.Sprintf("%s-%d", name, i), s.log, s.storage, s.redisClient, newRetryPolicy(queueNameFeed, s.redisClient, s.config))); err != nil {
+			return err
+		}
+	}
+
+	taskSuggestionsQueue, err := s.getQueue(queueNameSuggest)
+	if err != nil {
+		return err
+	}
+	err = taskSuggestionsQueue.StartConsuming(prefetchLimit, pollPeriod)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < s.config.NumberSuggestionsConsumers; i++ {
+		name := fmt.Sprintf("consumer-%s", queueNameSuggest)
+		s.log.Infof("adding consumer %d name %s", i, name)
+		if _, err := taskSuggestionsQueue.AddConsumer(name, NewConsumerSuggestions(fmt.Sprintf("%s-%d", name, i), s.log, s.storage, s.redisClient, s.config, newRetryPolicy(queueNameSuggest, s.redisClient, s.config))); err != nil {
 			return err
 		}
 	}
@@ -176,7 +219,7 @@ func (s *Service) getQueue(queue string) (*TaskQueue, error) {
 		if err != nil {
 			return nil, err
 		}
-		task := &TaskQueue{Queue: openQueue}
+		task := &TaskQueue{Queue: openQueue, name: queue, redis: s.redisClient, config: s.config}
 		s.queues[queue] = task
 		return task, nil
 	}
@@ -200,7 +243,8 @@ func (s *Service) Run(ctx context.Context) error {
 	logger := log.Ctx(ctx)
 	logger.Info("started queue service")
 
-	go s.QueueCleaner(s.config, logger)
+	go s.RetryMover(ctx, logger)
+	go s.collectMetrics(ctx, logger)
 
 	logger.Info("starting consumers")
 	err := s.StartConsumers(ctx)
@@ -239,18 +283,48 @@ func (s *Service) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func (s *Service) QueueCleaner(config Config, log *logrus.Entry) {
+// CleanQueues Возвращает в очереди задачи зависших (остановленных) консьюмеров
+func (s *Service) CleanQueues(ctx context.Context) error {
 	cleaner := rmq.NewCleaner(s.connection)
+	returned, err := cleaner.Clean()
+	if err != nil {
+		return err
+	}
+	log.Ctx(ctx).Infof("cleaned unacked %d", returned)
+	return nil
+}
 
-	for range time.Tick(config.CleanPeriod) {
-		returned, err := cleaner.Clean()
+// ExpireFeedCaches Устанавливает время жизни кэшам лент, у которых оно не задано
+func (s *Service) ExpireFeedCaches(ctx context.Context) error {
+	var cursor uint64
+	var expired int64
+	for {
+		keys, next, err := s.redisClient.Scan(ctx, cursor, feedCacheKeyPattern, feedCacheScanCount).Result()
 		if err != nil {
-			log.Errorf("failed to clean: %s", err)
-			continue
+			return err
+		}
+		for _, key := range keys {
+			ttl, err := s.redisClient.TTL(ctx, key).Result()
+			if err != nil {
+				return err
+			}
+			// -1 ключ без времени жизни
+			if ttl == -1 {
+				if err := s.redisClient.Expire(ctx, key, s.config.FeedCacheTTL).Err(); err != nil {
+					return err
+				}
+				expired++
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			break
 		}
-		log.Infof("cleaned unacked %d", returned)
 	}
+	log.Ctx(ctx).Infof("set expiration for %d feed caches", expired)
+	return nil
 }
+
 func (s *Service) GetRedisClient() *redis.Client {
 	return s.redisClient
 }
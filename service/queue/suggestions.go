@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/model"
+	"github.com/go-redis/redis/v8"
+	"sort"
+)
+
+// suggestionsCacheKeyTemplate Ключ кэша рекомендаций друзей пользователя
+const suggestionsCacheKeyTemplate = "user_suggestions%d"
+
+// Suggestions Получить рекомендации друзей из кэша. Если рекомендации еще не рассчитаны (ready=false),
+// пересчет ставится в очередь
+func (s *Service) Suggestions(ctx context.Context, userId int64) (suggestions []model.FriendSuggestion, ready bool, err error) {
+	result, err := s.redisClient.Get(ctx, fmt.Sprintf(suggestionsCacheKeyTemplate, userId)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, false, err
+	}
+	if err == nil {
+		if err = json.Unmarshal([]byte(result), &suggestions); err == nil {
+			return suggestions, true, nil
+		}
+		log.Ctx(ctx).WithError(err).Errorf("cant unmarshal suggestions of user_id %d", userId)
+	}
+	if err = s.UpdateSuggestions(ctx, userId); err != nil {
+		return nil, false, err
+	}
+	return nil, false, nil
+}
+
+// UpdateSuggestions Поставить в очередь пересчет рекомендаций друзей пользователя
+func (s *Service) UpdateSuggestions(ctx context.Context, userId int64) error {
+	logger := log.Ctx(ctx)
+	taskQueue, err := s.getQueue(queueNameSuggest)
+	if err != nil {
+		return err
+	}
+	err = taskQueue.AddTaskUpdateSuggestions(ctx, userId)
+	if err != nil {
+		logger.WithError(err).Error("error on adding update suggestions to queue")
+		return err
+	}
+	logger.Infof("add task for update suggestions of user_id %d is success", userId)
+	return nil
+}
+
+// rankSuggestions Ранжирование кандидатов по количеству общих друзей, совпадению города, страны и интересов
+func rankSuggestions(user model.User, candidates []model.FriendSuggestion, limit int) []model.FriendSuggestion {
+	for i := range candidates {
+		candidate := &candidates[i]
+		score := model.SuggestionWeightMutual * float64(candidate.MutualFriends)
+		if user.City != "" && candidate.City == user.City {
+			score += model.SuggestionWeightCity
+		}
+		if user.Country != "" && candidate.Country == user.Country {
+			score += model.SuggestionWeightCountry
+		}
+		score += model.SuggestionWeightInterest * float64(model.InterestsOverlap(user.Interests, candidate.Interests))
+		candidate.Score = score
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
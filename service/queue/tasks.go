@@ -1,28 +1,62 @@
 package queue
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/adjust/rmq/v4"
 	"github.com/basicus/hla-course/model"
+	"github.com/go-redis/redis/v8"
 	"sync"
 	"time"
 )
 
+const (
+	// feedPendingKeyTemplate Ключ идемпотентности ожидающего обновления ленты пользователя
+	feedPendingKeyTemplate = "timeline::feed::pending::%d"
+	// suggestionsPendingKeyTemplate Ключ идемпотентности ожидающего пересчета рекомендаций пользователя
+	suggestionsPendingKeyTemplate = "timeline::suggestions::pending::%d"
+	// fanoutDoneKeyTemplate Отметка о выполненном пакете рассылки публикации (id публикации, номер пакета)
+	fanoutDoneKeyTemplate = "timeline::fanout::done::%d::%d"
+)
+
 // TaskQueue Очередь Задач
 type TaskQueue struct {
 	rmq.Queue
-	done int64
-	m    sync.Mutex
+	name   string
+	redis  *redis.Client
+	config Config
+	done   int64
+	m      sync.Mutex
 }
 
 // TaskPost Задача на обновление поста
 type TaskPost struct {
 	Post      model.Post
 	QueueDate time.Time `json:"queue_date"`
+	Attempt   int       `json:"attempt,omitempty"`
+}
+
+// TaskPostFanout Задача рассылки публикации пакету подписчиков
+type TaskPostFanout struct {
+	Post      model.Post
+	UserFrom  string  `json:"user_from"`
+	Followers []int64 `json:"followers"`
+	Batch     int     `json:"batch"` // Номер пакета: повтор задачи публикации пропускает выполненные пакеты
+	Attempt   int     `json:"attempt,omitempty"`
 }
 
 type TaskUpdateUserIdFeed struct {
-	UserId int64
+	UserId  int64
+	Key     string `json:"key,omitempty"` // Ключ идемпотентности, снимается при начале обработки
+	Attempt int    `json:"attempt,omitempty"`
+}
+
+// TaskUpdateSuggestions Задача на пересчет рекомендаций друзей пользователя
+type TaskUpdateSuggestions struct {
+	UserId  int64
+	Key     string `json:"key,omitempty"` // Ключ идемпотентности, снимается при начале обработки
+	Attempt int    `json:"attempt,omitempty"`
 }
 
 func (t *TaskQueue) AddTaskPost(post model.Post) error {
@@ -41,18 +75,129 @@ func (t *TaskQueue) AddTaskPost(post model.Post) error {
 	return nil
 }
 
-func (t *TaskQueue) AddTaskUpdateUserIdFeed(userId int64) error {
-	taskBytes, err := json.Marshal(TaskUpdateUserIdFeed{
+// AddTasksPostFanout Разбивает подписчиков на пакеты по batchSize и ставит их в очередь одной операцией
+func (t *TaskQueue) AddTasksPostFanout(post model.Post, userFrom string, followers []int64, batchSize int) error {
+	if len(followers) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = len(followers)
+	}
+	tasks := make([][]byte, 0, (len(followers)+batchSize-1)/batchSize)
+	for start := 0; start < len(followers); start += batchSize {
+		end := start + batchSize
+		if end > len(followers) {
+			end = len(followers)
+		}
+		taskBytes, err := json.Marshal(TaskPostFanout{
+			Post:      post,
+			UserFrom:  userFrom,
+			Followers: followers[start:end],
+			Batch:     len(tasks),
+		})
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, taskBytes)
+	}
+
+	err := t.Queue.PublishBytes(tasks...)
+	if err != nil {
+		return err
+	}
+	tasksPublished.WithLabelValues(t.name, resultPublished).Add(float64(len(tasks)))
+	return nil
+}
+
+// AddTaskUpdateUserIdFeed Планирует обновление ленты пользователя.
+// Пока обновление ожидает обработки, повторные запросы для того же пользователя объединяются с ним,
+// а сама задача попадает в очередь по истечении окна объединения
+func (t *TaskQueue) AddTaskUpdateUserIdFeed(ctx context.Context, userId int64) error {
+	return t.AddTasksUpdateUserIdFeed(ctx, []int64{userId})
+}
+
+// AddTasksUpdateUserIdFeed Планирует обновление лент списка пользователей (см. AddTaskUpdateUserIdFeed).
+// Ключи идемпотентности ставятся одним пайплайном, задачи публикуются одной командой
+func (t *TaskQueue) AddTasksUpdateUserIdFeed(ctx context.Context, userIds []int64) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	pipe := t.redis.Pipeline()
+	cmds := make([]*redis.BoolCmd, len(userIds))
+	for i, userId := range userIds {
+		cmds[i] = pipe.SetNX(ctx, fmt.Sprintf(feedPendingKeyTemplate, userId), now, t.config.FeedPendingTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	var keys []string
+	var payloads []string
+	var tasks [][]byte
+	for i, userId := range userIds {
+		if !cmds[i].Val() {
+			tasksPublished.WithLabelValues(t.name, resultCoalesced).Inc()
+			continue
+		}
+		key := fmt.Sprintf(feedPendingKeyTemplate, userId)
+		keys = append(keys, key)
+		taskBytes, err := json.Marshal(TaskUpdateUserIdFeed{
+			UserId: userId,
+			Key:    key,
+		})
+		if err != nil {
+			t.redis.Del(ctx, keys...)
+			return err
+		}
+		payloads = append(payloads, string(taskBytes))
+		tasks = append(tasks, taskBytes)
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	var err error
+	if t.config.FeedCoalesceWindow > 0 {
+		err = publishDelayed(ctx, t.redis, t.name, t.config.FeedCoalesceWindow, payloads...)
+	} else {
+		err = t.Queue.PublishBytes(tasks...)
+	}
+	if err != nil {
+		t.redis.Del(ctx, keys...)
+		return err
+	}
+	tasksPublished.WithLabelValues(t.name, resultPublished).Add(float64(len(tasks)))
+	return nil
+}
+
+// AddTaskUpdateSuggestions Планирует пересчет рекомендаций пользователя.
+// Пока пересчет ожидает обработки, повторные запросы для того же пользователя объединяются с ним
+func (t *TaskQueue) AddTaskUpdateSuggestions(ctx context.Context, userId int64) error {
+	key := fmt.Sprintf(suggestionsPendingKeyTemplate, userId)
+	pending, err := t.redis.SetNX(ctx, key, time.Now().UnixMilli(), t.config.FeedPendingTTL).Result()
+	if err != nil {
+		return err
+	}
+	if !pending {
+		tasksPublished.WithLabelValues(t.name, resultCoalesced).Inc()
+		return nil
+	}
+
+	taskBytes, err := json.Marshal(TaskUpdateSuggestions{
 		UserId: userId,
+		Key:    key,
 	})
 	if err != nil {
+		t.redis.Del(ctx, key)
 		return err
 	}
 
-	err = t.Queue.PublishBytes(taskBytes)
-	if err != nil {
+	if err = t.Queue.PublishBytes(taskBytes); err != nil {
+		t.redis.Del(ctx, key)
 		return err
 	}
+	tasksPublished.WithLabelValues(t.name, resultPublished).Inc()
 	return nil
 }
 
@@ -0,0 +1,39 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"testing"
+	"time"
+)
+
+func TestAddTasksUpdateUserIdFeedBulk(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	feeds := &TaskQueue{name: "feeds", redis: client, config: Config{FeedCoalesceWindow: time.Second, FeedPendingTTL: time.Minute}}
+	ctx := context.Background()
+
+	// Обновление ленты пользователя 2 уже ожидает обработки
+	if err := feeds.AddTaskUpdateUserIdFeed(ctx, 2); err != nil {
+		t.Fatalf("add: %s", err)
+	}
+	if err := feeds.AddTasksUpdateUserIdFeed(ctx, []int64{1, 2, 3}); err != nil {
+		t.Fatalf("add bulk: %s", err)
+	}
+
+	delayed, err := client.ZCard(ctx, fmt.Sprintf(retryKeyTemplate, "feeds")).Result()
+	if err != nil {
+		t.Fatalf("zcard: %s", err)
+	}
+	if delayed != 3 {
+		t.Fatalf("expected 3 delayed tasks, got %d", delayed)
+	}
+	for _, userId := range []int64{1, 2, 3} {
+		if !server.Exists(fmt.Sprintf(feedPendingKeyTemplate, userId)) {
+			t.Fatalf("pending key of user %d is not set", userId)
+		}
+	}
+}
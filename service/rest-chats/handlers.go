@@ -1,8 +1,10 @@
 package rest_chats
 
 import (
+	"errors"
 	auth_api "github.com/basicus/hla-course/grpc/auth"
 	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
 	"github.com/gofiber/fiber/v2"
 	"strconv"
 	"strings"
@@ -33,8 +35,11 @@ func (s *Service) ChatCreate(c *fiber.Ctx) error {
 	}
 	chatCreate.Users = append(chatCreate.Users, userId)
 
-	chat, err := s.storage.ChatCreate(c.UserContext(), chatCreate.Title, chatCreate.Users...)
+	chat, err := s.storage.ChatCreate(c.UserContext(), userId, chatCreate.Title, chatCreate.Users...)
 	if err != nil {
+		if errors.Is(err, storage.ErrBlocked) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "User is blocked", "data": nil})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat create problem", "data": err})
 	}
 
@@ -54,13 +59,6 @@ func (s *Service) ChatPostMessage(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
 	}
 
-	// If chat exists
-	_, err = s.storage.GetChat(c.UserContext(), chatId)
-
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat not found", "data": err})
-	}
-
 	/*// Try to save message to chat
 	messageSaved, err := s.storage.MessageSave(c.UserContext(), chatId, userId, time.Now(), message.Message)
 	if err != nil {
@@ -81,8 +79,9 @@ func (s *Service) ChatPostMessage(c *fiber.Ctx) error {
 		message:      message.Message,
 		userName:     nil,
 	})
+	// Участие в чате, закрытость чата и роль проверяются при сохранении сообщения
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Send message problem", "data": err})
+		return chatError(c, err)
 	}
 	if sagaResult == nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Send message problem", "data": nil})
@@ -103,25 +102,10 @@ func (s *Service) GetChatMessages(c *fiber.Ctx) error {
 
 	chatId, err := strconv.ParseInt(id, 10, 64)
 
-	// If chat exists
-	chat, err := s.storage.GetChat(c.UserContext(), chatId)
-
+	// If chat exists and user is participant
+	chat, _, err := s.storage.ChatAccess(c.UserContext(), chatId, userId)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat not found", "data": err})
-	}
-
-	// Check if user is participant
-
-	var userIsParticipant bool
-	paricipants, err := s.storage.ChatGetParticipants(c.UserContext(), chatId)
-	for _, v := range paricipants {
-		if v == userId {
-			userIsParticipant = true
-		}
-	}
-
-	if !userIsParticipant {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "User is not participant of this chat", "data": "not participant"})
+		return chatError(c, err)
 	}
 
 	// Try get messages for chat
@@ -135,23 +119,35 @@ func (s *Service) GetChatMessages(c *fiber.Ctx) error {
 		List: make([]model.MessageDTO, len(messageList)),
 	}
 
-	// Get usernames and create dto for user
-	users := make(map[int64]string, 10)
-	for i := 0; i < len(messageList); i++ {
-		_, ok := users[messageList[i].UserFrom]
-		if !ok {
-			// Get username
-			userName, err := s.authApi.UserName(c.UserContext(), &auth_api.UserNameRequest{UserId: messageList[i].UserFrom})
-			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get username problem", "data": err})
-			}
-			users[messageList[i].UserFrom] = userName.GetUserName()
+	messageIds := make([]int64, len(messageList))
+	senders := make([]int64, 0)
+	seen := make(map[int64]struct{})
+	for i, msg := range messageList {
+		messageIds[i] = msg.Id
+		if _, ok := seen[msg.UserFrom]; !ok {
+			seen[msg.UserFrom] = struct{}{}
+			senders = append(senders, msg.UserFrom)
 		}
+	}
+	reactions, err := s.storage.GetMessagesReactions(c.UserContext(), messageIds)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get reactions problem", "data": err})
+	}
+
+	// Get usernames of all senders in one request and create dto for user
+	userNames, err := s.authApi.UserNames(c.UserContext(), &auth_api.UserNamesRequest{UserIds: senders})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get username problem", "data": err})
+	}
+	users := userNames.GetUserNames()
+	for i := 0; i < len(messageList); i++ {
 		m.List[i] = model.MessageDTO{
-			Id:       messageList[i].Id,
-			UserFrom: users[messageList[i].UserFrom],
-			Date:     messageList[i].SendAt,
-			Message:  messageList[i].Message,
+			Id:        messageList[i].Id,
+			UserFrom:  users[messageList[i].UserFrom],
+			Date:      messageList[i].SendAt,
+			Message:   messageList[i].Message,
+			System:    messageList[i].System,
+			Reactions: reactions[messageList[i].Id],
 		}
 	}
 
@@ -0,0 +1,232 @@
+package rest_chats
+
+import (
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// ChatAddParticipants Добавить участников в чат
+func (s *Service) ChatAddParticipants(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatAddParticipants request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	update := new(model.ChatParticipantsUpdateDTO)
+	if err := c.BodyParser(update); err != nil || len(update.Users) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	if err = s.storage.ChatAddParticipants(c.UserContext(), chatId, userId, update.Users); err != nil {
+		return chatError(c, err)
+	}
+	return s.chatParticipants(c, chatId, "Participants add ok")
+}
+
+// ChatRemoveParticipant Исключить участника из чата
+func (s *Service) ChatRemoveParticipant(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatRemoveParticipant request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	participantId, err := strconv.ParseInt(c.Params("userId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+
+	if err = s.storage.ChatRemoveParticipants(c.UserContext(), chatId, userId, []int64{participantId}); err != nil {
+		return chatError(c, err)
+	}
+	return s.chatParticipants(c, chatId, "Participant remove ok")
+}
+
+// ChatLeave Покинуть чат
+func (s *Service) ChatLeave(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatLeave request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	if err = s.storage.ChatLeave(c.UserContext(), chatId, userId); err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat leave ok", "data": nil})
+}
+
+// ChatRename Переименовать чат
+func (s *Service) ChatRename(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatRename request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	rename := new(model.ChatRenameDTO)
+	if err := c.BodyParser(rename); err != nil || rename.Title == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	chat, err := s.storage.ChatRename(c.UserContext(), chatId, userId, rename.Title)
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat rename ok", "data": chat})
+}
+
+// ChatClose Закрыть чат
+func (s *Service) ChatClose(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatClose request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	chat, err := s.storage.ChatClose(c.UserContext(), chatId, userId)
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat close ok", "data": chat})
+}
+
+// ChatDelete Удалить чат
+func (s *Service) ChatDelete(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatDelete request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	chat, err := s.storage.ChatDelete(c.UserContext(), chatId, userId)
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat delete ok", "data": chat})
+}
+
+// GetDialog Диалог текущего пользователя с указанным пользователем
+func (s *Service) GetDialog(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request GetDialog request_id %s", c.Params("requestid"))
+	peerId, err := strconv.ParseInt(c.Params("userId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+
+	chat, err := s.storage.ChatGetDialog(c.UserContext(), userId, peerId)
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Get dialog ok", "data": chat})
+}
+
+// ChatMembers Участники чата с ролями
+func (s *Service) ChatMembers(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatMembers request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	if _, _, err = s.storage.ChatAccess(c.UserContext(), chatId, userId); err != nil {
+		return chatError(c, err)
+	}
+	return s.chatMembers(c, chatId, "Get participants ok")
+}
+
+// ChatSetRole Сменить роль участника чата
+func (s *Service) ChatSetRole(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatSetRole request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	memberId, err := strconv.ParseInt(c.Params("userId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+	role := new(model.ChatRoleDTO)
+	if err := c.BodyParser(role); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	if err = s.storage.ChatSetRole(c.UserContext(), chatId, userId, memberId, role.Role); err != nil {
+		return chatError(c, err)
+	}
+	return s.chatMembers(c, chatId, "Role change ok")
+}
+
+// ChatUpdateSettings Изменить настройки чата
+func (s *Service) ChatUpdateSettings(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ChatUpdateSettings request_id %s", c.Params("requestid"))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	settings := new(model.ChatSettings)
+	if err := c.BodyParser(settings); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	chat, err := s.storage.ChatUpdateSettings(c.UserContext(), chatId, userId, *settings)
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat settings update ok", "data": chat})
+}
+
+// chatMembers Ответ со списком участников чата и их ролей
+func (s *Service) chatMembers(c *fiber.Ctx, chatId int64, message string) error {
+	members, err := s.storage.ChatGetMembers(c.UserContext(), chatId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get participants problem", "data": err})
+	}
+	if members == nil {
+		members = []model.ChatParticipant{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": message, "data": members})
+}
+
+// chatParticipants Ответ со списком участников чата
+func (s *Service) chatParticipants(c *fiber.Ctx, chatId int64, message string) error {
+	participants, err := s.storage.ChatGetParticipants(c.UserContext(), chatId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get participants problem", "data": err})
+	}
+	if participants == nil {
+		participants = []int64{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": message, "data": participants})
+}
+
+func chatError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrChatNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Chat not found", "data": nil})
+	case errors.Is(err, storage.ErrNotParticipant):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "User is not participant of this chat", "data": nil})
+	case errors.Is(err, storage.ErrAccessDenied):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "Not enough rights for this chat", "data": nil})
+	case errors.Is(err, storage.ErrBlocked):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "User is blocked", "data": nil})
+	case errors.Is(err, storage.ErrChatClosed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "error", "message": "Chat is closed", "data": nil})
+	case errors.Is(err, storage.ErrDialogChat):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "error", "message": "Operation is not allowed in dialog", "data": nil})
+	case errors.Is(err, storage.ErrInvalidChatRole):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid chat role", "data": nil})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat problem", "data": err})
+}
@@ -0,0 +1,85 @@
+package rest_chats
+
+import (
+	"errors"
+	"github.com/basicus/hla-course/model"
+	client_auth "github.com/basicus/hla-course/service/client-auth"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// ReactMessage Поставить реакцию на сообщение
+func (s *Service) ReactMessage(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request ReactMessage request_id %s", c.Params("requestid"))
+
+	message, err := s.messageForReaction(c, userId)
+	if err != nil {
+		return s.reactionError(c, err)
+	}
+
+	reaction := new(model.ReactionPojo)
+	if err := c.BodyParser(reaction); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	reactions, err := s.storage.ReactMessage(c.UserContext(), message.Id, userId, reaction.Reaction)
+	if err != nil {
+		return s.reactionError(c, err)
+	}
+
+	// Уведомляем автора сообщения о реакции
+	err = client_auth.NotifyReaction(c.UserContext(), s.authApi, s.publish, message, userId, reaction.Reaction)
+	if err != nil {
+		s.log.Errorf("error when publish reaction info: %s", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Reaction add ok", "data": reactions})
+}
+
+// UnreactMessage Убрать реакцию с сообщения
+func (s *Service) UnreactMessage(c *fiber.Ctx) error {
+	userId := c.Locals("user_id").(int64)
+	s.log.Infof("Request UnreactMessage request_id %s", c.Params("requestid"))
+
+	message, err := s.messageForReaction(c, userId)
+	if err != nil {
+		return s.reactionError(c, err)
+	}
+
+	reactions, err := s.storage.UnreactMessage(c.UserContext(), message.Id, userId)
+	if err != nil {
+		return s.reactionError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Reaction del ok", "data": reactions})
+}
+
+// messageForReaction Сообщение чата из параметров запроса, доступное участнику чата
+func (s *Service) messageForReaction(c *fiber.Ctx, userId int64) (model.Message, error) {
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return model.Message{}, storage.ErrMessageNotFound
+	}
+	messageId, err := strconv.ParseInt(c.Params("messageId"), 10, 64)
+	if err != nil {
+		return model.Message{}, storage.ErrMessageNotFound
+	}
+	return s.storage.ChatMessage(c.UserContext(), chatId, messageId, userId)
+}
+
+func (s *Service) reactionError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrMessageNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Message not found", "data": nil})
+	case errors.Is(err, storage.ErrAccessDenied):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "User is not participant of this chat", "data": "not participant"})
+	case errors.Is(err, storage.ErrInvalidReaction):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Unknown reaction", "data": nil})
+	case errors.Is(err, storage.ErrChatNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Chat not found", "data": nil})
+	case errors.Is(err, storage.ErrChatClosed):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "error", "message": "Chat is closed", "data": nil})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Reaction problem", "data": err})
+}
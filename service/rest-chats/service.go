@@ -6,6 +6,7 @@ import (
 	auth_api "github.com/basicus/hla-course/grpc/auth"
 	counter_api "github.com/basicus/hla-course/grpc/counter"
 	"github.com/basicus/hla-course/log"
+	"github.com/basicus/hla-course/model"
 	"github.com/basicus/hla-course/service/monitoring"
 	"github.com/basicus/hla-course/service/rest/middleware"
 	"github.com/basicus/hla-course/storage"
@@ -25,9 +26,10 @@ type Service struct {
 	authApi    auth_api.AuthServiceClient
 	counterApi counter_api.CounterServiceClient
 	ss         saga.Store
+	publish    func(ctx context.Context, userId int64, shardId string, event model.Event) error
 }
 
-func New(config Config, log *logrus.Logger, prom *monitoring.Service, storage *storage.ChatsService, authApi auth_api.AuthServiceClient, counter counter_api.CounterServiceClient) (*Service, error) {
+func New(config Config, log *logrus.Logger, prom *monitoring.Service, storage *storage.ChatsService, authApi auth_api.AuthServiceClient, counter counter_api.CounterServiceClient, publish func(ctx context.Context, userId int64, shardId string, event model.Event) error) (*Service, error) {
 
 	app := fiber.New(fiber.Config{
 		DisableStartupMessage: true,
@@ -47,6 +49,7 @@ func New(config Config, log *logrus.Logger, prom *monitoring.Service, storage *s
 		authApi:    authApi,
 		counterApi: counter,
 		ss:         saga.New(),
+		publish:    publish,
 	}
 	// Функционал чатов (диалогов)
 	app.Use(requestid.New())
@@ -56,6 +59,21 @@ func New(config Config, log *logrus.Logger, prom *monitoring.Service, storage *s
 	protected.Get("/chat/:id", s.GetChatMessages)  // Получение списка сообщений из чата
 	protected.Post("/chat", s.ChatCreate)          // Создать чат с пользователем
 	protected.Post("/chat/:id", s.ChatPostMessage) // Отправить сообщение в чат
+	protected.Get("/dialog/:userId", s.GetDialog)  // Диалог с пользователем
+
+	protected.Post("/chat/:id/messages/:messageId/reactions", s.ReactMessage)     // Поставить реакцию на сообщение
+	protected.Delete("/chat/:id/messages/:messageId/reactions", s.UnreactMessage) // Убрать реакцию с сообщения
+
+	// Управление групповым чатом
+	protected.Put("/chat/:id", s.ChatRename)                                    // Переименовать чат
+	protected.Delete("/chat/:id", s.ChatDelete)                                 // Удалить чат
+	protected.Post("/chat/:id/close", s.ChatClose)                              // Закрыть чат
+	protected.Post("/chat/:id/leave", s.ChatLeave)                              // Покинуть чат
+	protected.Post("/chat/:id/participants", s.ChatAddParticipants)             // Добавить участников
+	protected.Delete("/chat/:id/participants/:userId", s.ChatRemoveParticipant) // Исключить участника
+	protected.Get("/chat/:id/participants", s.ChatMembers)                      // Участники чата с ролями
+	protected.Put("/chat/:id/participants/:userId/role", s.ChatSetRole)         // Сменить роль участника
+	protected.Put("/chat/:id/settings", s.ChatUpdateSettings)                   // Изменить настройки чата
 
 	return s, nil
 }
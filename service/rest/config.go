@@ -4,4 +4,5 @@ type Config struct {
 	Listen     string `env:"LISTEN_ADDRESS,default=localhost:8080"`
 	JwtSecret  string `env:"JWT_SECRET,default=superpuper"`
 	PostsLimit int64  `env:"FRIENDS_POSTS_LIMIT,default=1000"`
+	AdminToken string `env:"ADMIN_TOKEN"`
 }
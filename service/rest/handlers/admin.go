@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/basicus/hla-course/service/queue"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// StorageTopology Текущая топология кластера БД: мастер, кандидаты и реплики
+func (h *Handlers) StorageTopology(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get topology ok", "data": h.Storage.Topology()})
+}
+
+// QueuesList Список очередей с их состоянием
+func (h *Handlers) QueuesList(c *fiber.Ctx) error {
+	stats, err := h.Queue.QueuesStats(c.UserContext())
+	if err != nil {
+		return queueAdminError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get queues ok", "data": stats})
+}
+
+// QueueInfo Состояние очереди
+func (h *Handlers) QueueInfo(c *fiber.Ctx) error {
+	stats, err := h.Queue.QueueStats(c.UserContext(), c.Params("name"))
+	if err != nil {
+		return queueAdminError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get queue ok", "data": stats})
+}
+
+// QueuePurgeReady Удаление готовых к обработке задач очереди
+func (h *Handlers) QueuePurgeReady(c *fiber.Ctx) error {
+	purged, err := h.Queue.PurgeReady(c.UserContext(), c.Params("name"))
+	if err != nil {
+		return queueAdminError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "purge ready tasks ok", "data": purged})
+}
+
+// QueueRejected Просмотр отклоненных задач очереди
+func (h *Handlers) QueueRejected(c *fiber.Ctx) error {
+	limit, offset := pagination(c)
+	tasks, err := h.Queue.RejectedTasks(c.UserContext(), c.Params("name"), limit, offset)
+	if err != nil {
+		return queueAdminError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get rejected tasks ok", "data": tasks})
+}
+
+// QueueRequeue Возврат отклоненных задач в очередь
+func (h *Handlers) QueueRequeue(c *fiber.Ctx) error {
+	count := int64(defaultPageLimit)
+	if v, err := strconv.ParseInt(c.Query("count"), 10, 64); err == nil && v > 0 {
+		count = v
+	}
+	returned, err := h.Queue.RequeueRejected(c.UserContext(), c.Params("name"), count)
+	if err != nil {
+		return queueAdminError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "requeue rejected tasks ok", "data": returned})
+}
+
+// QueuePurge Удаление отклоненных задач очереди
+func (h *Handlers) QueuePurge(c *fiber.Ctx) error {
+	purged, err := h.Queue.PurgeRejected(c.UserContext(), c.Params("name"))
+	if err != nil {
+		return queueAdminError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "purge rejected tasks ok", "data": purged})
+}
+
+func queueAdminError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, queue.ErrUnknownQueue) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Queue not found", "data": nil})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Queue admin problem", "data": err.Error()})
+}
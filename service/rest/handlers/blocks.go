@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+// GetBlocks Список заблокированных и скрытых пользователей (опционально kind=block|mute)
+func (h *Handlers) GetBlocks(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	limit, offset := pagination(c)
+
+	kind := c.Query("kind")
+	if kind != "" && !model.ValidBlockKind(kind) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "kind must be block or mute"})
+	}
+
+	blocks, err := h.Storage.GetBlocks(c.UserContext(), userId, kind, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get blocks problem", "data": err})
+	}
+	if blocks == nil {
+		blocks = []model.UserBlock{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get blocks ok", "data": blocks})
+}
+
+// BlockUser Заблокировать пользователя
+func (h *Handlers) BlockUser(c *fiber.Ctx) error {
+	return h.block(c, model.BlockKindBlock)
+}
+
+// UnblockUser Снять блокировку пользователя
+func (h *Handlers) UnblockUser(c *fiber.Ctx) error {
+	return h.unblock(c, model.BlockKindBlock)
+}
+
+// MuteUser Скрыть публикации пользователя из ленты
+func (h *Handlers) MuteUser(c *fiber.Ctx) error {
+	return h.block(c, model.BlockKindMute)
+}
+
+// UnmuteUser Вернуть публикации пользователя в ленту
+func (h *Handlers) UnmuteUser(c *fiber.Ctx) error {
+	return h.unblock(c, model.BlockKindMute)
+}
+
+func (h *Handlers) block(c *fiber.Ctx, kind string) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	targetId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+
+	block, err := h.Storage.BlockUser(c.UserContext(), userId, targetId, kind)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidBlock) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid block", "data": nil})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Block problem", "data": err})
+	}
+
+	// Обновляем ленты постов: блокировка удаляет подписки в обе стороны (добавляем в очередь)
+	_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), userId)
+	if kind == model.BlockKindBlock {
+		_ = h.Queue.UpdateFeed(c.UserContext(), targetId)
+		_ = h.Queue.UpdateSuggestions(c.UserContext(), targetId)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Block ok", "data": block})
+}
+
+func (h *Handlers) unblock(c *fiber.Ctx, kind string) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	targetId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+
+	removed, err := h.Storage.UnblockUser(c.UserContext(), userId, targetId, kind)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Unblock problem", "data": err})
+	}
+	if !removed {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Block not found", "data": nil})
+	}
+
+	_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), userId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Unblock ok", "data": nil})
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	chat_api "github.com/basicus/hla-course/grpc/chats"
+	"github.com/basicus/hla-course/model"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+)
+
+// ChatAddParticipants Добавить участников в чат
+func (h *Handlers) ChatAddParticipants(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	update := new(model.ChatParticipantsUpdateDTO)
+	if err := c.BodyParser(update); err != nil || len(update.Users) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	response, err := h.ChatApi.AddParticipants(c.UserContext(), &chat_api.ChatParticipantsRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		Users:     update.Users,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Participants add ok", "data": participantsList(response.GetUsers())})
+}
+
+// ChatRemoveParticipant Исключить участника из чата
+func (h *Handlers) ChatRemoveParticipant(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	participantId, err := strconv.ParseInt(c.Params("userId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+
+	response, err := h.ChatApi.RemoveParticipants(c.UserContext(), &chat_api.ChatParticipantsRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		Users:     []int64{participantId},
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Participant remove ok", "data": participantsList(response.GetUsers())})
+}
+
+// ChatLeave Покинуть чат
+func (h *Handlers) ChatLeave(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	_, err = h.ChatApi.LeaveChat(c.UserContext(), &chat_api.ChatActionRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat leave ok", "data": nil})
+}
+
+// ChatRename Переименовать чат
+func (h *Handlers) ChatRename(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	rename := new(model.ChatRenameDTO)
+	if err := c.BodyParser(rename); err != nil || rename.Title == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	response, err := h.ChatApi.RenameChat(c.UserContext(), &chat_api.RenameChatRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		Title:     rename.Title,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat rename ok", "data": convertChatInfo2Chat(response.GetChat())})
+}
+
+// ChatClose Закрыть чат
+func (h *Handlers) ChatClose(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	response, err := h.ChatApi.CloseChat(c.UserContext(), &chat_api.ChatActionRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat close ok", "data": convertChatInfo2Chat(response.GetChat())})
+}
+
+// ChatDelete Удалить чат
+func (h *Handlers) ChatDelete(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	response, err := h.ChatApi.DeleteChat(c.UserContext(), &chat_api.ChatActionRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat delete ok", "data": convertChatInfo2Chat(response.GetChat())})
+}
+
+// GetDialog Диалог текущего пользователя с указанным пользователем
+func (h *Handlers) GetDialog(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	peerId, err := strconv.ParseInt(c.Params("userId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+
+	response, err := h.ChatApi.GetDialog(c.UserContext(), &chat_api.GetDialogRequest{
+		UserId:    userId,
+		PeerId:    peerId,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Get dialog ok", "data": convertChatInfo2Chat(response.GetChat())})
+}
+
+// ChatMembers Участники чата с ролями
+func (h *Handlers) ChatMembers(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+
+	response, err := h.ChatApi.ListMembers(c.UserContext(), &chat_api.ChatActionRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Get participants ok", "data": membersList(chatId, response.GetMembers())})
+}
+
+// ChatSetRole Сменить роль участника чата
+func (h *Handlers) ChatSetRole(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	memberId, err := strconv.ParseInt(c.Params("userId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+	role := new(model.ChatRoleDTO)
+	if err := c.BodyParser(role); err != nil || !model.ValidChatRole(role.Role) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	response, err := h.ChatApi.SetMemberRole(c.UserContext(), &chat_api.SetMemberRoleRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		MemberId:  memberId,
+		Role:      role.Role,
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Role change ok", "data": membersList(chatId, response.GetMembers())})
+}
+
+// ChatUpdateSettings Изменить настройки чата
+func (h *Handlers) ChatUpdateSettings(c *fiber.Ctx) error {
+	userId, chatId, err := chatRequest(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	settings := new(model.ChatSettings)
+	if err := c.BodyParser(settings); err != nil || !settings.Valid() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	response, err := h.ChatApi.UpdateChatSettings(c.UserContext(), &chat_api.UpdateChatSettingsRequest{
+		UserId: userId,
+		ChatId: chatId,
+		Settings: &chat_api.ChatSettings{
+			Invite: settings.Invite,
+			Post:   settings.Post,
+			Rename: settings.Rename,
+		},
+		RequestId: c.Locals("requestid").(string),
+	})
+	if err != nil {
+		return chatError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Chat settings update ok", "data": convertChatInfo2Chat(response.GetChat())})
+}
+
+// chatRequest Пользователь и id чата из запроса
+func chatRequest(c *fiber.Ctx) (int64, int64, error) {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	return userId, chatId, err
+}
+
+func participantsList(users []int64) []int64 {
+	if users == nil {
+		return []int64{}
+	}
+	return users
+}
+
+func membersList(chatId int64, members []*chat_api.ChatMember) []model.ChatParticipant {
+	list := make([]model.ChatParticipant, len(members))
+	for i, member := range members {
+		list[i] = model.ChatParticipant{
+			ChatId: chatId,
+			UserId: member.GetUserId(),
+			Status: model.ParticipantActive,
+			Role:   member.GetRole(),
+		}
+	}
+	return list
+}
+
+// chatError Преобразование кодов gRPC сервиса чатов в HTTP статусы
+func chatError(c *fiber.Ctx, err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": status.Convert(err).Message(), "data": nil})
+	case codes.PermissionDenied:
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": status.Convert(err).Message(), "data": nil})
+	case codes.FailedPrecondition:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "error", "message": status.Convert(err).Message(), "data": nil})
+	case codes.InvalidArgument:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": status.Convert(err).Message(), "data": nil})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat problem", "data": err})
+}
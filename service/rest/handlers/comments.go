@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// AddComment Добавить комментарий к публикации
+func (h *Handlers) AddComment(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	postId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Post id must be number", "data": err})
+	}
+
+	comment := new(model.CommentPojo)
+	if err := c.BodyParser(comment); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+	if comment.Message == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "message is empty"})
+	}
+
+	post, err := h.visiblePost(c.UserContext(), postId, userId)
+	if err != nil {
+		if errors.Is(err, storage.ErrPostNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Post not found", "data": nil})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get post problem", "data": err})
+	}
+
+	savedComment, err := h.Storage.AddComment(c.UserContext(), postId, userId, comment.Message)
+	if err != nil {
+		if errors.Is(err, storage.ErrPostNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Post not found", "data": nil})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Save comment problem", "data": err})
+	}
+
+	// Уведомляем автора публикации о новом комментарии
+	if post.UserId != userId {
+		userName, err := h.Storage.GetUserName(c.UserContext(), userId)
+		if err != nil {
+			userName = ""
+		}
+		_ = h.Queue.Notify(c.UserContext(), post.UserId, &model.EventComment{
+			Data: model.CommentDTO{
+				Id:       savedComment.Id,
+				PostId:   savedComment.PostId,
+				UserFrom: userName,
+				Message:  savedComment.Message,
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Comment add ok", "data": savedComment})
+}
+
+// GetComments Получение списка комментариев к публикации
+func (h *Handlers) GetComments(c *fiber.Ctx) error {
+	postId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Post id must be number", "data": err})
+	}
+	limit, offset := pagination(c)
+
+	if _, err := h.visiblePost(c.UserContext(), postId, viewerId(c)); err != nil {
+		if errors.Is(err, storage.ErrPostNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Post not found", "data": nil})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get post problem", "data": err})
+	}
+
+	comments, err := h.Storage.GetComments(c.UserContext(), postId, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get comments problem", "data": err})
+	}
+	if comments == nil {
+		comments = []model.Comment{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get comments ok", "data": comments})
+}
+
+// DeleteComment Удаление комментария (автором комментария или автором публикации)
+func (h *Handlers) DeleteComment(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	commentId, err := strconv.ParseInt(c.Params("commentId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Comment id must be number", "data": err})
+	}
+
+	_, err = h.Storage.DelComment(c.UserContext(), commentId, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrCommentNotFound), errors.Is(err, storage.ErrPostNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Comment not found", "data": nil})
+		case errors.Is(err, storage.ErrAccessDenied):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "Comment del forbidden", "data": nil})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Comment del error", "data": err})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Comment del successfully", "data": nil})
+}
+
+// pagination Разбор параметров limit и offset запроса
+func pagination(c *fiber.Ctx) (limit, offset int64) {
+	limit = defaultPageLimit
+	if v, err := strconv.ParseInt(c.Query("limit"), 10, 64); err == nil && v > 0 {
+		limit = v
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	if v, err := strconv.ParseInt(c.Query("offset"), 10, 64); err == nil && v > 0 {
+		offset = v
+	}
+	return limit, offset
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+	"time"
+)
+
+// SaveDraft Сохранение черновика или планирование публикации (при заданном publish_at)
+func (h *Handlers) SaveDraft(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	draft := new(model.DraftPojo)
+	if err := c.BodyParser(draft); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+	if msg := validateDraft(draft); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": msg})
+	}
+
+	saved, err := h.Storage.SaveDraft(c.UserContext(), userId, *draft)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Save draft problem", "data": err})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Draft save ok", "data": saved})
+}
+
+// GetDrafts Получение списка черновиков и запланированных публикаций пользователя
+func (h *Handlers) GetDrafts(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	limit, offset := pagination(c)
+
+	drafts, err := h.Storage.GetDrafts(c.UserContext(), userId, c.Query("status"), limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get drafts problem", "data": err})
+	}
+	if drafts == nil {
+		drafts = []model.Draft{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get drafts ok", "data": drafts})
+}
+
+// GetDraft Получение черновика
+func (h *Handlers) GetDraft(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	draftId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Draft id must be number", "data": err})
+	}
+
+	draft, err := h.Storage.GetDraftById(c.UserContext(), draftId, userId)
+	if err != nil {
+		return draftError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get draft ok", "data": draft})
+}
+
+// UpdateDraft Изменение черновика или запланированной публикации
+func (h *Handlers) UpdateDraft(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	draftId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Draft id must be number", "data": err})
+	}
+	draft := new(model.DraftPojo)
+	if err := c.BodyParser(draft); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+	if msg := validateDraft(draft); msg != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": msg})
+	}
+
+	saved, err := h.Storage.UpdateDraft(c.UserContext(), draftId, userId, *draft)
+	if err != nil {
+		return draftError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Draft update ok", "data": saved})
+}
+
+// CancelDraft Отмена черновика или запланированной публикации
+func (h *Handlers) CancelDraft(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	draftId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Draft id must be number", "data": err})
+	}
+
+	if _, err := h.Storage.CancelDraft(c.UserContext(), draftId, userId); err != nil {
+		return draftError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Draft cancel ok", "data": nil})
+}
+
+// PublishDraft Немедленная публикация черновика
+func (h *Handlers) PublishDraft(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	draftId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Draft id must be number", "data": err})
+	}
+
+	draft, err := h.Storage.GetDraftById(c.UserContext(), draftId, userId)
+	if err != nil {
+		return draftError(c, err)
+	}
+	post, err := h.Queue.PublishDraft(c.UserContext(), draft)
+	if err != nil {
+		return draftError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Publish ok", "data": post})
+}
+
+// validateDraft Проверка черновика, возвращает описание ошибки
+func validateDraft(draft *model.DraftPojo) string {
+	if draft.Title == "" {
+		return "title is empty"
+	}
+	if draft.Visibility == "" {
+		draft.Visibility = model.PostVisibilityPublic
+	}
+	if !model.ValidPostVisibility(draft.Visibility) {
+		return "visibility must be public, friends or private"
+	}
+	if draft.PublishAt != nil && !draft.PublishAt.After(time.Now()) {
+		return "publish_at must be in the future"
+	}
+	return ""
+}
+
+func draftError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrDraftNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Draft not found", "data": nil})
+	case errors.Is(err, storage.ErrDraftNotEditable):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "error", "message": "Draft already published or cancelled", "data": nil})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Draft problem", "data": err})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+// GetFollowers Страница подписчиков пользователя с их общим количеством
+func (h *Handlers) GetFollowers(c *fiber.Ctx) error {
+	return h.userList(c, func(c *fiber.Ctx, user model.User, limit, offset int64) (model.UserList, error) {
+		users, err := h.Storage.GetFollowers(c.UserContext(), user.UserId, limit, offset)
+		return model.UserList{Count: user.FollowersCount, Users: users}, err
+	})
+}
+
+// GetFollowing Страница пользователей, на которых подписан пользователь, с их общим количеством
+func (h *Handlers) GetFollowing(c *fiber.Ctx) error {
+	return h.userList(c, func(c *fiber.Ctx, user model.User, limit, offset int64) (model.UserList, error) {
+		users, err := h.Storage.GetFollowing(c.UserContext(), user.UserId, limit, offset)
+		return model.UserList{Count: user.FollowingCount, Users: users}, err
+	})
+}
+
+// GetMutualFriends Страница общих друзей авторизованного пользователя и пользователя :id
+func (h *Handlers) GetMutualFriends(c *fiber.Ctx) error {
+	viewer := viewerId(c)
+	return h.userList(c, func(c *fiber.Ctx, user model.User, limit, offset int64) (model.UserList, error) {
+		count, err := h.Storage.CountMutualFriends(c.UserContext(), viewer, user.UserId)
+		if err != nil {
+			return model.UserList{}, err
+		}
+		users, err := h.Storage.GetMutualFriends(c.UserContext(), viewer, user.UserId, limit, offset)
+		return model.UserList{Count: count, Users: users}, err
+	})
+}
+
+// userList Общая обработка запроса списка пользователей, связанных с пользователем :id
+func (h *Handlers) userList(c *fiber.Ctx, list func(c *fiber.Ctx, user model.User, limit, offset int64) (model.UserList, error)) error {
+	token := c.Locals("user").(*jwt.Token)
+	claims := token.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	targetId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+	limit, offset := pagination(c)
+
+	blocked, err := h.Storage.IsBlocked(c.UserContext(), userId, targetId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get users problem", "data": err})
+	}
+	if blocked {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "User not found", "data": nil})
+	}
+	user, err := h.Storage.GetById(c.UserContext(), targetId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "User not found", "data": nil})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get users problem", "data": err})
+	}
+
+	result, err := list(c, user, limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get users problem", "data": err})
+	}
+	if result.Users == nil {
+		result.Users = []model.User{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get users ok", "data": result})
+}
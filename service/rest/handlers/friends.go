@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+// Follow Подписка на публикации пользователя без заявки в друзья
+func (h *Handlers) Follow(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	targetId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+	if targetId == userId {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Cant follow yourself", "data": nil})
+	}
+
+	if _, err := h.Storage.AddFriend(c.UserContext(), userId, targetId); err != nil {
+		if errors.Is(err, storage.ErrBlocked) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "User is blocked", "data": nil})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Follow error", "data": err})
+	}
+	// Обновляем ленту постов после подписки (добавляем в очередь)
+	_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), userId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Follow successfully", "data": nil})
+}
+
+// Unfollow Отписка от публикаций пользователя
+func (h *Handlers) Unfollow(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	targetId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+
+	if _, err := h.Storage.DelFriend(c.UserContext(), userId, targetId); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Unfollow error", "data": err})
+	}
+	// Обновляем ленту постов после отписки (добавляем в очередь)
+	_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Unfollow successfully", "data": nil})
+}
+
+// GetSuggestions Рекомендации друзей. Пока рекомендации рассчитываются, возвращается пустой список со статусом 202
+func (h *Handlers) GetSuggestions(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	suggestions, ready, err := h.Queue.Suggestions(c.UserContext(), userId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get suggestions problem", "data": err})
+	}
+	if !ready {
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"status": "success", "message": "Suggestions are being prepared", "data": []model.FriendSuggestion{}})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get suggestions ok", "data": suggestions})
+}
+
+// SendFriendRequest Отправка заявки в друзья (встречная заявка принимается сразу)
+func (h *Handlers) SendFriendRequest(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	id := c.Params("id")
+	var friendId int64
+	var err error
+
+	friendId, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Friend id must be number", "data": err})
+	}
+	request, err := h.Storage.SendFriendRequest(c.UserContext(), userId, friendId)
+	if err != nil {
+		return friendRequestError(c, err)
+	}
+
+	if request.Status == model.FriendRequestAccepted {
+		// Встречная заявка принята: уведомляем ее автора и обновляем ленты обоих пользователей
+		h.notifyFriendRequest(c.UserContext(), request.FromUserId, userId, request)
+		_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+		_ = h.Queue.UpdateFeed(c.UserContext(), friendId)
+		_ = h.Queue.UpdateSuggestions(c.UserContext(), userId)
+		_ = h.Queue.UpdateSuggestions(c.UserContext(), friendId)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend add successfully", "data": request})
+	}
+
+	h.notifyFriendRequest(c.UserContext(), friendId, userId, request)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend request sent", "data": request})
+}
+
+// RemoveFriend Удаление пользователя из друзей (подписки удаляются в обе стороны)
+func (h *Handlers) RemoveFriend(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	id := c.Params("id")
+	var friendId int64
+	var err error
+
+	friendId, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Friend id must be number", "data": err})
+	}
+	status, err := h.Storage.RemoveFriend(c.UserContext(), userId, friendId)
+	if err != nil || !status {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Friend del error", "data": err})
+	}
+
+	// Обновляем ленты постов после удаления друга (добавляем в очередь)
+	_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+	_ = h.Queue.UpdateFeed(c.UserContext(), friendId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), userId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), friendId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend del successfully", "data": nil})
+}
+
+// GetMutualFriendList Список друзей (взаимных подписок) пользователя
+func (h *Handlers) GetMutualFriendList(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	friends, err := h.Storage.GetFriendUsers(c.UserContext(), userId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get friends error", "data": err})
+	}
+	if friends == nil {
+		friends = []model.User{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Get friends ok", "data": friends})
+}
+
+// GetFriendRequests Список входящих (direction=incoming, по умолчанию) или исходящих (direction=outgoing) заявок
+func (h *Handlers) GetFriendRequests(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	limit, offset := pagination(c)
+
+	var incoming bool
+	switch c.Query("direction", "incoming") {
+	case "incoming":
+		incoming = true
+	case "outgoing":
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "direction must be incoming or outgoing"})
+	}
+
+	requests, err := h.Storage.GetFriendRequests(c.UserContext(), userId, incoming, c.Query("status", model.FriendRequestPending), limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get friend requests problem", "data": err})
+	}
+	if requests == nil {
+		requests = []model.FriendRequest{}
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get friend requests ok", "data": requests})
+}
+
+// AcceptFriendRequest Принять входящую заявку в друзья
+func (h *Handlers) AcceptFriendRequest(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	requestId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Request id must be number", "data": err})
+	}
+
+	request, err := h.Storage.AcceptFriendRequest(c.UserContext(), requestId, userId)
+	if err != nil {
+		return friendRequestError(c, err)
+	}
+
+	h.notifyFriendRequest(c.UserContext(), request.FromUserId, userId, request)
+	// Обновляем ленты постов обоих пользователей (добавляем в очередь)
+	_ = h.Queue.UpdateFeed(c.UserContext(), request.FromUserId)
+	_ = h.Queue.UpdateFeed(c.UserContext(), request.ToUserId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), request.FromUserId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), request.ToUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend request accepted", "data": request})
+}
+
+// DeclineFriendRequest Отклонить входящую заявку в друзья
+func (h *Handlers) DeclineFriendRequest(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	requestId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Request id must be number", "data": err})
+	}
+
+	request, err := h.Storage.DeclineFriendRequest(c.UserContext(), requestId, userId)
+	if err != nil {
+		return friendRequestError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend request declined", "data": request})
+}
+
+// CancelFriendRequest Отозвать исходящую заявку в друзья
+func (h *Handlers) CancelFriendRequest(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	requestId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Request id must be number", "data": err})
+	}
+
+	request, err := h.Storage.CancelFriendRequest(c.UserContext(), requestId, userId)
+	if err != nil {
+		return friendRequestError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend request cancelled", "data": request})
+}
+
+// notifyFriendRequest Отправка события о заявке пользователю recipientId от пользователя senderId
+func (h *Handlers) notifyFriendRequest(ctx context.Context, recipientId, senderId int64, request model.FriendRequest) {
+	userName, err := h.Storage.GetUserName(ctx, senderId)
+	if err != nil {
+		userName = ""
+	}
+	_ = h.Queue.Notify(ctx, recipientId, &model.EventFriendRequest{
+		Data: model.FriendRequestDTO{
+			Id:         request.Id,
+			FromUserId: senderId,
+			UserFrom:   userName,
+			Status:     request.Status,
+		},
+	})
+}
+
+func friendRequestError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrInvalidFriendRequest):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Invalid friend request", "data": nil})
+	case errors.Is(err, storage.ErrBlocked):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "User is blocked", "data": nil})
+	case errors.Is(err, storage.ErrFriendRequestNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Friend request not found", "data": nil})
+	case errors.Is(err, storage.ErrFriendRequestExists), errors.Is(err, storage.ErrFriendRequestClosed), errors.Is(err, storage.ErrAlreadyFriends):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"status": "error", "message": err.Error(), "data": nil})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Friend request problem", "data": err})
+}
@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"fmt"
+	"errors"
 	chat_api "github.com/basicus/hla-course/grpc/chats"
 	"github.com/basicus/hla-course/model"
 	"github.com/basicus/hla-course/service/queue"
@@ -9,6 +9,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"strconv"
 	"strings"
@@ -104,12 +106,11 @@ func (h *Handlers) UsersGet(c *fiber.Ctx) error {
 	//claims := user.Claims.(jwt.MapClaims)
 	//userId := int64(claims["user_id"].(float64))
 	//search := c.Params("search")
-	users, err := h.Storage.GetUsers(c.UserContext(), map[string]string{"name": "Ser%"},
-		map[string]string{"user_id": "ASC"}, 0, 1000)
+	result, err := h.Storage.SearchUsers(c.UserContext(), 0, model.UserSearch{Name: "Ser", Limit: 1000})
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Error on get user list", "data": err.Error()})
 	}
-	return c.JSON(fiber.Map{"status": "success", "message": "Success user listing", "data": users})
+	return c.JSON(fiber.Map{"status": "success", "message": "Success user listing", "data": result.Users})
 }
 
 func (h *Handlers) UpdateProfile(c *fiber.Ctx) error {
@@ -149,6 +150,14 @@ func (h *Handlers) UserInfo(c *fiber.Ctx) error {
 		userId = int64(claims["user_id"].(float64))
 	}
 
+	blocked, err := h.Storage.IsBlocked(c.UserContext(), int64(claims["user_id"].(float64)), userId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Cant get user", "data": err})
+	}
+	if blocked {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "User not found", "data": nil})
+	}
+
 	userData, err := h.Storage.GetById(c.UserContext(), userId)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Cant get user", "data": err})
@@ -157,6 +166,7 @@ func (h *Handlers) UserInfo(c *fiber.Ctx) error {
 
 }
 
+// AddFriend Подписка на пользователя (прежний контракт, заявки в друзья - SendFriendRequest)
 func (h *Handlers) AddFriend(c *fiber.Ctx) error {
 	user := c.Locals("user").(*jwt.Token)
 	claims := user.Claims.(jwt.MapClaims)
@@ -167,18 +177,23 @@ func (h *Handlers) AddFriend(c *fiber.Ctx) error {
 
 	friendId, err = strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Friend id must be number", "data": err})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Friend id must be number", "data": err})
 	}
-	status, err := h.Storage.AddFriend(c.UserContext(), userId, friendId)
-	if err != nil || !status {
+	// Повторная подписка не является ошибкой
+	if _, err = h.Storage.AddFriend(c.UserContext(), userId, friendId); err != nil {
+		if errors.Is(err, storage.ErrBlocked) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "User is blocked", "data": nil})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Friend add error", "data": err})
 	}
 	// Обновляем ленту постов после добавления друга (добавляем в очередь)
 	_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), userId)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend add successfully", "data": nil})
 }
 
+// DeleteFriend Отписка от пользователя (прежний контракт, удаление из друзей - RemoveFriend)
 func (h *Handlers) DeleteFriend(c *fiber.Ctx) error {
 	user := c.Locals("user").(*jwt.Token)
 	claims := user.Claims.(jwt.MapClaims)
@@ -189,7 +204,7 @@ func (h *Handlers) DeleteFriend(c *fiber.Ctx) error {
 
 	friendId, err = strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Friend id must be number", "data": err})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Friend id must be number", "data": err})
 	}
 	status, err := h.Storage.DelFriend(c.UserContext(), userId, friendId)
 	if err != nil || !status {
@@ -198,6 +213,7 @@ func (h *Handlers) DeleteFriend(c *fiber.Ctx) error {
 
 	// Обновляем ленту постов после удаления друга (добавляем в очередь)
 	_ = h.Queue.UpdateFeed(c.UserContext(), userId)
+	_ = h.Queue.UpdateSuggestions(c.UserContext(), userId)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend del successfully", "data": nil})
 }
@@ -214,58 +230,69 @@ func (h *Handlers) GetFriends(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Friend del successfully", "data": friends})
 }
 
+// SearchProfile Поиск пользователей. Параметры: name (first), surname (second), city, country, sex,
+// age_from, age_to, interests, sort (user_id, name, surname, age, city, country), order (asc, desc), limit, offset, cursor
 func (h *Handlers) SearchProfile(c *fiber.Ctx) error {
-	//user := c.Locals("user").(*jwt.Token)
-	//claims := user.Claims.(jwt.MapClaims)
-	//userId := int64(claims["user_id"].(float64))
-	searchFirst := c.Query("first")
-	searchSecond := c.Query("second")
-	searchLimit := c.Query("limit")
-	searchOffset := c.Query("offset")
-	searchOrder := c.Query("order")
-
-	orderBy := make(map[string]string)
-
-	offset := 0
-	limit := 0
-
-	if searchLimit != "" {
-		atoi, err := strconv.Atoi(searchLimit)
-		if err != nil {
-			limit = 0
-		} else {
-			limit = atoi
-		}
+	search := model.UserSearch{
+		Name:      c.Query("name", c.Query("first")),
+		Surname:   c.Query("surname", c.Query("second")),
+		City:      c.Query("city"),
+		Country:   c.Query("country"),
+		Sex:       c.Query("sex"),
+		Interests: c.Query("interests"),
+		Sort:      c.Query("sort", model.SearchSortId),
 	}
-	if searchOrder != "" {
-		orderBy[searchOrder] = "ASC"
-	} else {
-		orderBy["user_id"] = "ASC"
+	if search.Sex != "" && search.Sex != "male" && search.Sex != "female" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "sex must be male or female"})
 	}
-
-	if searchOffset != "" {
-		atoi, err := strconv.Atoi(searchOffset)
-		if err != nil {
-			offset = 0
-		} else {
-			offset = atoi
-		}
+	if !model.ValidSearchSort(search.Sort) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "unknown sort field"})
+	}
+	switch strings.ToLower(c.Query("order", "asc")) {
+	case "asc":
+	case "desc":
+		search.Desc = true
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "order must be asc or desc"})
 	}
 
-	filter := make(map[string]string)
-	if searchSecond != "" {
-		filter["surname"] = fmt.Sprintf("%s%s", searchSecond, "%")
+	var err error
+	if search.AgeFrom, err = queryInt(c, "age_from"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "age_from must be number"})
+	}
+	if search.AgeTo, err = queryInt(c, "age_to"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "age_to must be number"})
+	}
+	if search.AgeTo > 0 && search.AgeFrom > search.AgeTo {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "age_from must not exceed age_to"})
 	}
-	if searchFirst != "" {
-		filter["name"] = fmt.Sprintf("%s%s", searchFirst, "%")
+	if cursor := c.Query("cursor"); cursor != "" {
+		if search.After, err = model.DecodeSearchCursor(cursor); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err.Error()})
+		}
 	}
+	search.Limit, search.Offset = pagination(c)
 
-	users, err := h.Storage.GetUsers(c.UserContext(), filter,
-		orderBy, offset, limit)
+	result, err := h.Storage.SearchUsers(c.UserContext(), viewerId(c), search)
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidSearch) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err.Error()})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Error on get user list", "data": err.Error()})
 	}
-	return c.JSON(fiber.Map{"status": "success", "message": "Success user listing", "data": users})
+	if result.Users == nil {
+		result.Users = []model.User{}
+	}
+	return c.JSON(fiber.Map{"status": "success", "message": "Success user listing", "data": result})
+}
+
+// queryInt Целочисленный параметр запроса, 0 если параметр не задан
+func queryInt(c *fiber.Ctx, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
 }
 
 // PublishPost Публикация новой записи
@@ -281,8 +308,14 @@ func (h *Handlers) PublishPost(c *fiber.Ctx) error {
 
 	}
 	post.UserId = userId
+	if post.Visibility == "" {
+		post.Visibility = model.PostVisibilityPublic
+	}
+	if !model.ValidPostVisibility(post.Visibility) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": "visibility must be public, friends or private"})
+	}
 
-	savedPost, err := h.Storage.PublishPost(c.UserContext(), post.UserId, post.Title, post.Message)
+	savedPost, err := h.Storage.PublishPost(c.UserContext(), post.UserId, post.Title, post.Message, post.Visibility)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Save post problem", "data": err})
 	}
@@ -313,9 +346,15 @@ func (h *Handlers) GetPostById(c *fiber.Ctx) error {
 	var err error
 
 	postId, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Post id must be number", "data": err})
+	}
 
-	post, err := h.Storage.GetPostById(c.UserContext(), postId)
+	post, err := h.visiblePost(c.UserContext(), postId, viewerId(c))
 	if err != nil {
+		if errors.Is(err, storage.ErrPostNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Post not found", "data": nil})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get post problem", "data": err})
 	}
 
@@ -344,6 +383,13 @@ func convertChatInfo2Chat(chat *chat_api.ChatInfo) model.Chat {
 		Title:     chat.GetTitle(),
 		CreatedAt: chat.GetCreatedAt().AsTime(),
 		Closed:    chat.GetClosed(),
+		CreatedBy: chat.GetCreatedBy(),
+		Dialog:    chat.GetDialog(),
+		ChatSettings: model.ChatSettings{
+			Invite: chat.GetSettings().GetInvite(),
+			Post:   chat.GetSettings().GetPost(),
+			Rename: chat.GetSettings().GetRename(),
+		},
 	}
 }
 
@@ -367,6 +413,9 @@ func (h *Handlers) ChatCreate(c *fiber.Ctx) error {
 	})
 
 	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"status": "error", "message": "User is blocked", "data": nil})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat create problem", "data": err})
 	}
 	chat := convertChatInfo2Chat(createChatResponse.Chat)
@@ -391,14 +440,7 @@ func (h *Handlers) ChatPostMessage(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
 	}
 
-	// If chat exists
-	_, err = h.ChatApi.Get(c.UserContext(), &chat_api.GetChatRequest{ChatId: chatId, RequestId: requestId})
-
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat not found", "data": err})
-	}
-
-	// Try to save message to chat
+	// Try to save message to chat, membership and closed state are checked by chat service
 	messageSaved, err := h.ChatApi.PostMessage(c.UserContext(), &chat_api.PostMessageRequest{
 		UserId:    userId,
 		ChatId:    chatId,
@@ -408,11 +450,7 @@ func (h *Handlers) ChatPostMessage(c *fiber.Ctx) error {
 	})
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Create chat problem", "data": err})
-	}
-
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get username problem", "data": err})
+		return chatError(c, err)
 	}
 
 	// TODO Отправка в очередь уведомления о новом событии
@@ -435,28 +473,7 @@ func (h *Handlers) GetChatMessages(c *fiber.Ctx) error {
 
 	chatId, err := strconv.ParseInt(id, 10, 64)
 
-	// If chat exists
-	chat, err := h.ChatApi.Get(c.UserContext(), &chat_api.GetChatRequest{ChatId: chatId})
-
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Chat not found", "data": err})
-	}
-
-	// Check if user is participant
-
-	var userIsParticipant bool
-	participants := chat.GetUsers()
-	for _, v := range participants {
-		if v == userId {
-			userIsParticipant = true
-		}
-	}
-
-	if !userIsParticipant {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "User is not participant of this chat", "data": "not participant"})
-	}
-
-	// Try get messages for chat
+	// Try get messages for chat, membership is checked by chat service
 	messageList, err := h.ChatApi.Messages(c.UserContext(), &chat_api.ChatMessagesRequest{
 		UserId:    userId,
 		ChatId:    chatId,
@@ -464,19 +481,21 @@ func (h *Handlers) GetChatMessages(c *fiber.Ctx) error {
 	})
 
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get messages of chat problem", "data": err})
+		return chatError(c, err)
 	}
 
 	m := model.MessageList{
-		Chat: convertChatInfo2Chat(chat.Chat),
+		Chat: convertChatInfo2Chat(messageList.Chat),
 		List: make([]model.MessageDTO, len(messageList.GetMessages())),
 	}
 	for i := 0; i < len(messageList.GetMessages()); i++ {
 		m.List[i] = model.MessageDTO{
-			Id:       messageList.Messages[i].MessageId,
-			UserFrom: messageList.Messages[i].UserFrom,
-			Date:     messageList.Messages[i].Date.AsTime(),
-			Message:  messageList.Messages[i].Message,
+			Id:        messageList.Messages[i].MessageId,
+			UserFrom:  messageList.Messages[i].UserFrom,
+			Date:      messageList.Messages[i].Date.AsTime(),
+			Message:   messageList.Messages[i].Message,
+			System:    messageList.Messages[i].System,
+			Reactions: messageList.Messages[i].Reactions,
 		}
 	}
 
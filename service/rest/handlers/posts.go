@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+// GetUserPosts Получение списка публикаций пользователя с учетом их видимости
+func (h *Handlers) GetUserPosts(c *fiber.Ctx) error {
+	viewer := viewerId(c)
+	authorId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "User id must be number", "data": err})
+	}
+	limit, offset := pagination(c)
+
+	blocked, err := h.Storage.IsBlocked(c.UserContext(), viewer, authorId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get posts problem", "data": err})
+	}
+	if blocked {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get posts ok", "data": []model.Post{}})
+	}
+
+	friends, err := h.areFriends(c.UserContext(), viewer, authorId)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get posts problem", "data": err})
+	}
+
+	posts, err := h.Storage.GetPostsByUserId(c.UserContext(), authorId, model.PostVisibilityFor(viewer, authorId, friends), limit, offset)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Get posts problem", "data": err})
+	}
+	if posts == nil {
+		posts = []model.Post{}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "get posts ok", "data": posts})
+}
+
+// visiblePost Получение публикации, если она доступна пользователю viewer
+func (h *Handlers) visiblePost(ctx context.Context, postId, viewer int64) (model.Post, error) {
+	post, err := h.Storage.GetPostById(ctx, postId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Post{}, storage.ErrPostNotFound
+		}
+		return model.Post{}, err
+	}
+	blocked, err := h.Storage.IsBlocked(ctx, viewer, post.UserId)
+	if err != nil {
+		return model.Post{}, err
+	}
+	friends, err := h.areFriends(ctx, viewer, post.UserId)
+	if err != nil {
+		return model.Post{}, err
+	}
+	if blocked || !post.VisibleTo(viewer, friends) {
+		// Не раскрываем существование недоступной публикации
+		return model.Post{}, storage.ErrPostNotFound
+	}
+	return post, nil
+}
+
+// areFriends Являются ли пользователь viewer и автор друзьями
+func (h *Handlers) areFriends(ctx context.Context, viewer, authorId int64) (bool, error) {
+	if viewer == 0 || viewer == authorId {
+		return false, nil
+	}
+	return h.Storage.AreFriends(ctx, viewer, authorId)
+}
+
+// viewerId Id авторизованного пользователя или 0 для анонимного запроса
+func viewerId(c *fiber.Ctx) int64 {
+	user, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return 0
+	}
+	claims, ok := user.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0
+	}
+	id, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0
+	}
+	return int64(id)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	chat_api "github.com/basicus/hla-course/grpc/chats"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+)
+
+// ReactPost Поставить реакцию на публикацию
+func (h *Handlers) ReactPost(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	postId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Post id must be number", "data": err})
+	}
+	reaction := new(model.ReactionPojo)
+	if err := c.BodyParser(reaction); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+	}
+
+	post, err := h.visiblePost(c.UserContext(), postId, userId)
+	if err != nil {
+		return postReactionError(c, err)
+	}
+
+	reactions, err := h.Storage.ReactPost(c.UserContext(), postId, userId, reaction.Reaction)
+	if err != nil {
+		return postReactionError(c, err)
+	}
+
+	// Уведомляем автора публикации о реакции
+	if post.UserId != userId {
+		userName, err := h.Storage.GetUserName(c.UserContext(), userId)
+		if err != nil {
+			userName = ""
+		}
+		_ = h.Queue.Notify(c.UserContext(), post.UserId, &model.EventReaction{
+			Data: model.ReactionDTO{
+				ItemType: model.ReactionItemPost,
+				ItemId:   postId,
+				UserFrom: userName,
+				Reaction: reaction.Reaction,
+			},
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Reaction add ok", "data": reactions})
+}
+
+// UnreactPost Убрать реакцию с публикации
+func (h *Handlers) UnreactPost(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+
+	postId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Post id must be number", "data": err})
+	}
+
+	reactions, err := h.Storage.UnreactPost(c.UserContext(), postId, userId)
+	if err != nil {
+		return postReactionError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Reaction del ok", "data": reactions})
+}
+
+// ReactMessage Поставить реакцию на сообщение чата
+func (h *Handlers) ReactMessage(c *fiber.Ctx) error {
+	return h.messageReaction(c, true)
+}
+
+// UnreactMessage Убрать реакцию с сообщения чата
+func (h *Handlers) UnreactMessage(c *fiber.Ctx) error {
+	return h.messageReaction(c, false)
+}
+
+func (h *Handlers) messageReaction(c *fiber.Ctx, add bool) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userId := int64(claims["user_id"].(float64))
+	requestId := c.Locals("requestid").(string)
+
+	chatId, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Chat id must be number", "data": err})
+	}
+	messageId, err := strconv.ParseInt(c.Params("messageId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Message id must be number", "data": err})
+	}
+
+	request := &chat_api.ReactMessageRequest{
+		UserId:    userId,
+		ChatId:    chatId,
+		MessageId: messageId,
+		RequestId: requestId,
+	}
+	var response *chat_api.ReactMessageResponse
+	if add {
+		reaction := new(model.ReactionPojo)
+		if err := c.BodyParser(reaction); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Review your input", "data": err})
+		}
+		if !model.ValidReaction(reaction.Reaction) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Unknown reaction", "data": nil})
+		}
+		request.Reaction = reaction.Reaction
+		response, err = h.ChatApi.ReactMessage(c.UserContext(), request)
+	} else {
+		response, err = h.ChatApi.UnreactMessage(c.UserContext(), request)
+	}
+	if err != nil {
+		return chatError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "success", "message": "Reaction ok", "data": model.ReactionCounts(response.GetReactions())})
+}
+
+func postReactionError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, storage.ErrPostNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": "error", "message": "Post not found", "data": nil})
+	case errors.Is(err, storage.ErrInvalidReaction):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"status": "error", "message": "Unknown reaction", "data": nil})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"status": "error", "message": "Reaction problem", "data": err})
+}
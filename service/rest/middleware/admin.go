@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminTokenHeader Заголовок с токеном администратора
+const AdminTokenHeader = "X-Admin-Token"
+
+// Admin protect administrative routes with static token, routes are disabled when token is empty
+func Admin(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return c.Status(fiber.StatusForbidden).
+				JSON(fiber.Map{"status": "error", "message": "Admin API is disabled", "data": nil})
+		}
+		if subtle.ConstantTimeCompare([]byte(c.Get(AdminTokenHeader)), []byte(token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).
+				JSON(fiber.Map{"status": "error", "message": "Invalid admin token", "data": nil})
+		}
+		return c.Next()
+	}
+}
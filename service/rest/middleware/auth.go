@@ -13,6 +13,17 @@ func Protected(secret string) fiber.Handler {
 	})
 }
 
+// Optional routes available for anonymous users, the token is validated only when passed
+func Optional(secret string) fiber.Handler {
+	return jwtware.New(jwtware.Config{
+		SigningKey:   []byte(secret),
+		ErrorHandler: jwtError,
+		Filter: func(c *fiber.Ctx) bool {
+			return c.Get(fiber.HeaderAuthorization) == ""
+		},
+	})
+}
+
 func jwtError(c *fiber.Ctx, err error) error {
 	if err.Error() == "Missing or malformed JWT" {
 		return c.Status(fiber.StatusBadRequest).
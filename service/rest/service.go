@@ -53,17 +53,54 @@ func New(config Config, log *logrus.Logger, prom *monitoring.Service, storage *s
 	app.Post("/api/v1/register", h.Register)
 	app.Post("/api/v1/login", h.Login)
 	app.Get("/api/v1/users", h.UsersGet)
-	app.Get("/api/v1/search", h.SearchProfile)
-	app.Get("/api/v1/post/:id", h.GetPostById)
+	app.Get("/api/v1/search", middleware.Optional(config.JwtSecret), h.SearchProfile)
+	app.Get("/api/v1/post/:id", middleware.Optional(config.JwtSecret), h.GetPostById)
+	// Комментарии к публикациям
+	app.Get("/api/v1/post/:id/comments", middleware.Optional(config.JwtSecret), h.GetComments)
+	app.Post("/api/v1/post/:id/comments", middleware.Protected(config.JwtSecret), h.AddComment)
+	app.Delete("/api/v1/post/:id/comments/:commentId", middleware.Protected(config.JwtSecret), h.DeleteComment)
+	// Реакции на публикации
+	app.Post("/api/v1/post/:id/reactions", middleware.Protected(config.JwtSecret), h.ReactPost)
+	app.Delete("/api/v1/post/:id/reactions", middleware.Protected(config.JwtSecret), h.UnreactPost)
+	// Публичная страница автора: видимость публикаций зависит от пользователя, если он авторизован.
+	// Регистрируется до группы protected, иначе к маршруту применится обязательная авторизация
+	app.Get("/api/v1/user/:id/posts", middleware.Optional(config.JwtSecret), h.GetUserPosts)
 	protected := app.Group("/api/v1/user", middleware.Protected(config.JwtSecret))
 	protected.Get("/feed", h.PersonalFeed)
 	protected.Get("/chats", h.GetUserChats) // Получение списка чатов пользователя
 	protected.Post("", h.UpdateProfile)
 	protected.Get("/friends", h.GetFriends)
+	protected.Get("/friends/mutual", h.GetMutualFriendList) // Друзья (взаимные подписки)
+	// Заявки в друзья
+	protected.Get("/friends/requests", h.GetFriendRequests)
+	protected.Post("/friends/requests/:id/accept", h.AcceptFriendRequest)
+	protected.Post("/friends/requests/:id/decline", h.DeclineFriendRequest)
+	protected.Delete("/friends/requests/:id", h.CancelFriendRequest)
+	protected.Get("/suggestions", h.GetSuggestions) // Рекомендации друзей
+	// Блокировки и скрытие пользователей
+	protected.Get("/blocks", h.GetBlocks)
+	// Черновики и запланированные публикации
+	protected.Get("/drafts", h.GetDrafts)
+	protected.Post("/drafts", h.SaveDraft)
+	protected.Get("/drafts/:id", h.GetDraft)
+	protected.Put("/drafts/:id", h.UpdateDraft)
+	protected.Delete("/drafts/:id", h.CancelDraft)
+	protected.Post("/drafts/:id/publish", h.PublishDraft)
+	protected.Get("/:id/followers", h.GetFollowers)
+	protected.Get("/:id/following", h.GetFollowing)
+	protected.Get("/:id/mutual", h.GetMutualFriends)
 	protected.Get("/:id", h.UserInfo)
 	protected.Get("", h.UserInfo)
 	protected.Post("/:id/friend", h.AddFriend)
 	protected.Delete("/:id/friend", h.DeleteFriend)
+	protected.Post("/:id/friend/request", h.SendFriendRequest) // Заявка в друзья
+	protected.Delete("/:id/friendship", h.RemoveFriend)        // Удаление из друзей в обе стороны
+	protected.Post("/:id/follow", h.Follow)
+	protected.Delete("/:id/follow", h.Unfollow)
+	protected.Post("/:id/block", h.BlockUser)
+	protected.Delete("/:id/block", h.UnblockUser)
+	protected.Post("/:id/mute", h.MuteUser)
+	protected.Delete("/:id/mute", h.UnmuteUser)
 	protected.Post("/publish", h.PublishPost)
 
 	// Функционал чатов (диалогов)
@@ -71,6 +108,31 @@ func New(config Config, log *logrus.Logger, prom *monitoring.Service, storage *s
 	protected.Get("/chat/:id", h.GetChatMessages)  // Получение списка сообщений из чата
 	protected.Post("/chat", h.ChatCreate)          // Создать чат с пользователем
 	protected.Post("/chat/:id", h.ChatPostMessage) // Отправить сообщение в чат
+	protected.Get("/dialog/:userId", h.GetDialog)  // Диалог с пользователем
+
+	protected.Post("/chat/:id/messages/:messageId/reactions", h.ReactMessage)     // Поставить реакцию на сообщение
+	protected.Delete("/chat/:id/messages/:messageId/reactions", h.UnreactMessage) // Убрать реакцию с сообщения
+
+	// Управление групповым чатом
+	protected.Put("/chat/:id", h.ChatRename)                                    // Переименовать чат
+	protected.Delete("/chat/:id", h.ChatDelete)                                 // Удалить чат
+	protected.Post("/chat/:id/close", h.ChatClose)                              // Закрыть чат
+	protected.Post("/chat/:id/leave", h.ChatLeave)                              // Покинуть чат
+	protected.Post("/chat/:id/participants", h.ChatAddParticipants)             // Добавить участников
+	protected.Delete("/chat/:id/participants/:userId", h.ChatRemoveParticipant) // Исключить участника
+	protected.Get("/chat/:id/participants", h.ChatMembers)                      // Участники чата с ролями
+	protected.Put("/chat/:id/participants/:userId/role", h.ChatSetRole)         // Сменить роль участника
+	protected.Put("/chat/:id/settings", h.ChatUpdateSettings)                   // Изменить настройки чата
+
+	// Администрирование очередей задач
+	admin := app.Group("/api/v1/admin", middleware.Admin(config.AdminToken))
+	admin.Get("/queues", h.QueuesList)                           // Список очередей с их состоянием
+	admin.Get("/queues/:name", h.QueueInfo)                      // Состояние очереди
+	admin.Delete("/queues/:name", h.QueuePurgeReady)             // Удаление готовых к обработке задач
+	admin.Get("/queues/:name/rejected", h.QueueRejected)         // Просмотр отклоненных задач
+	admin.Post("/queues/:name/rejected/requeue", h.QueueRequeue) // Возврат отклоненных задач в очередь
+	admin.Delete("/queues/:name/rejected", h.QueuePurge)         // Удаление отклоненных задач
+	admin.Get("/storage/topology", h.StorageTopology)            // Топология кластера БД
 
 	//app.Post("/api/v1/logout", handlers.Logout)
 	//app.Post("/api/v1/password_recover", handlers.PasswordRecover)
@@ -0,0 +1,13 @@
+package tasks
+
+import "time"
+
+type Config struct {
+	PollPeriod time.Duration `env:"TASKS_POLL_PERIOD,default=1s"`
+	LockTTL    time.Duration `env:"TASKS_LOCK_TTL,default=10m"`
+
+	// Расписания служебных задач (cron, @daily, @every 1h)
+	FeedCacheExpirySchedule   string `env:"FEED_CACHE_EXPIRY_SCHEDULE,default=@hourly"`
+	CountersReconcileSchedule string `env:"COUNTERS_RECONCILE_SCHEDULE,default=30 3 * * *"`
+	ScheduledPostsSchedule    string `env:"SCHEDULED_POSTS_SCHEDULE,default=@every 30s"`
+}
@@ -0,0 +1,171 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule Расписание выполнения задачи
+type Schedule interface {
+	// Next Время следующего запуска после t
+	Next(t time.Time) time.Time
+}
+
+// everySchedule Запуск с фиксированным интервалом (@every 5m)
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.interval).Add(s.interval)
+}
+
+// cronSchedule Расписание в формате cron: минута час день_месяца месяц день_недели
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	anyDom, anyDow                bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = []cronField{
+	{0, 59}, // минута
+	{0, 23}, // час
+	{1, 31}, // день месяца
+	{1, 12}, // месяц
+	{0, 6},  // день недели (0 - воскресенье)
+}
+
+var cronAliases = map[string]string{
+	"@yearly":  "0 0 1 1 *",
+	"@monthly": "0 0 1 * *",
+	"@weekly":  "0 0 * * 0",
+	"@daily":   "0 0 * * *",
+	"@hourly":  "0 * * * *",
+}
+
+// ParseSchedule Разбор расписания: cron (5 полей), псевдонимы (@daily, @hourly, ...) или @every <duration>
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || interval < time.Second {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, spec)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+	if alias, ok := cronAliases[spec]; ok {
+		spec = alias
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, spec)
+	}
+	bits := make([]uint64, len(parts))
+	for i, part := range parts {
+		b, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, spec)
+		}
+		bits[i] = b
+	}
+	return &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		// Как в cron: поле, начинающееся с *, считается неограниченным, в том числе с шагом (*/2)
+		anyDom: strings.HasPrefix(parts[2], "*"),
+		anyDow: strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseCronField Разбор поля cron: *, */n, a, a-b, a-b/n и их списки через запятую
+func parseCronField(field string, bounds cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s <= 0 {
+				return 0, ErrInvalidSchedule
+			}
+			step = s
+			item = item[:i]
+		}
+
+		from, to := bounds.min, bounds.max
+		switch {
+		case item == "*":
+		case strings.Contains(item, "-"):
+			rng := strings.SplitN(item, "-", 2)
+			a, errA := strconv.Atoi(rng[0])
+			b, errB := strconv.Atoi(rng[1])
+			if errA != nil || errB != nil {
+				return 0, ErrInvalidSchedule
+			}
+			from, to = a, b
+		default:
+			a, err := strconv.Atoi(item)
+			if err != nil {
+				return 0, ErrInvalidSchedule
+			}
+			from, to = a, a
+			if step > 1 {
+				to = bounds.max
+			}
+		}
+		if from < bounds.min || to > bounds.max || from > to {
+			return 0, ErrInvalidSchedule
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// Расписание, не имеющее запусков (например, 31 февраля), ограничиваем горизонтом в 5 лет
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches Как в cron: если заданы и день месяца, и день недели, достаточно совпадения одного из них
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.anyDom || s.anyDow {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
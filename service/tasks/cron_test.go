@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every 10ms",
+		"@every soon",
+		"@sometimes",
+	} {
+		if _, err := ParseSchedule(spec); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("%q: expected invalid schedule, got %v", spec, err)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Понедельник
+	from := time.Date(2024, time.January, 1, 10, 30, 20, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next []time.Time
+	}{
+		{"@every 5m", []time.Time{
+			time.Date(2024, time.January, 1, 10, 35, 0, 0, time.UTC),
+			time.Date(2024, time.January, 1, 10, 40, 0, 0, time.UTC),
+		}},
+		{"@hourly", []time.Time{
+			time.Date(2024, time.January, 1, 11, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+		}},
+		{"@daily", []time.Time{
+			time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+		}},
+		{"@weekly", []time.Time{
+			time.Date(2024, time.January, 7, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC),
+		}},
+		{"@monthly", []time.Time{
+			time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"@yearly", []time.Time{
+			time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		// Диапазон и список
+		{"0,15 9-11 * * *", []time.Time{
+			time.Date(2024, time.January, 1, 11, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 1, 11, 15, 0, 0, time.UTC),
+			time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC),
+		}},
+		// Шаги
+		{"*/20 * * * *", []time.Time{
+			time.Date(2024, time.January, 1, 10, 40, 0, 0, time.UTC),
+			time.Date(2024, time.January, 1, 11, 0, 0, 0, time.UTC),
+		}},
+		{"10-50/20 * * * *", []time.Time{
+			time.Date(2024, time.January, 1, 10, 50, 0, 0, time.UTC),
+			time.Date(2024, time.January, 1, 11, 10, 0, 0, time.UTC),
+		}},
+		{"45/5 10 * * *", []time.Time{
+			time.Date(2024, time.January, 1, 10, 45, 0, 0, time.UTC),
+			time.Date(2024, time.January, 1, 10, 50, 0, 0, time.UTC),
+		}},
+		// Заданы день месяца и день недели: достаточно совпадения одного из них
+		{"0 0 13 * 5", []time.Time{
+			time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 13, 0, 0, 0, 0, time.UTC),
+		}},
+		// Поле с * и шагом не ограничивает день: нужны нечетный день и понедельник
+		{"0 0 */2 * 1", []time.Time{
+			time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 29, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.February, 5, 0, 0, 0, 0, time.UTC),
+		}},
+		{"0 0 1 * */3", []time.Time{
+			time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		// 29 февраля - только в високосный год
+		{"0 12 29 2 *", []time.Time{
+			time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+			time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC),
+		}},
+		// Несуществующая дата
+		{"0 0 31 2 *", []time.Time{{}}},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("%q: %s", tt.spec, err)
+			continue
+		}
+		at := from
+		for i, want := range tt.next {
+			at = schedule.Next(at)
+			if !at.Equal(want) {
+				t.Errorf("%q: run %d at %s, want %s", tt.spec, i+1, at, want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// releaseScript Снимает блокировку, только если она принадлежит владельцу токена
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// Lock Распределенная блокировка на Redis
+type Lock struct {
+	redis *redis.Client
+	key   string
+	token string
+}
+
+// Acquire Попытка получить блокировку key на время ttl. Возвращает nil, если блокировка занята
+func Acquire(ctx context.Context, client *redis.Client, key string, ttl time.Duration) (*Lock, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	ok, err := client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &Lock{redis: client, key: key, token: token}, nil
+}
+
+// Release Снятие блокировки
+func (l *Lock) Release(ctx context.Context) error {
+	return releaseScript.Run(ctx, l.redis, []string{l.key}, l.token).Err()
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,62 @@
+package tasks
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"testing"
+	"time"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return server, client
+}
+
+func TestAcquireRelease(t *testing.T) {
+	server, client := newTestRedis(t)
+	ctx := context.Background()
+
+	lock, err := Acquire(ctx, client, "job", time.Minute)
+	if err != nil || lock == nil {
+		t.Fatalf("acquire: %v %v", lock, err)
+	}
+	busy, err := Acquire(ctx, client, "job", time.Minute)
+	if err != nil || busy != nil {
+		t.Fatalf("expected busy lock, got %v %v", busy, err)
+	}
+	if err = lock.Release(ctx); err != nil {
+		t.Fatalf("release: %s", err)
+	}
+	if server.Exists("job") {
+		t.Fatal("lock is not released")
+	}
+	again, err := Acquire(ctx, client, "job", time.Minute)
+	if err != nil || again == nil {
+		t.Fatalf("acquire after release: %v %v", again, err)
+	}
+}
+
+func TestReleaseKeepsForeignLock(t *testing.T) {
+	server, client := newTestRedis(t)
+	ctx := context.Background()
+
+	lock, err := Acquire(ctx, client, "job", time.Minute)
+	if err != nil || lock == nil {
+		t.Fatalf("acquire: %v %v", lock, err)
+	}
+	// Блокировка истекла и занята другим экземпляром
+	server.FastForward(2 * time.Minute)
+	other, err := Acquire(ctx, client, "job", time.Minute)
+	if err != nil || other == nil {
+		t.Fatalf("acquire expired: %v %v", other, err)
+	}
+	if err = lock.Release(ctx); err != nil {
+		t.Fatalf("release: %s", err)
+	}
+	if value, _ := server.Get("job"); value != other.token {
+		t.Fatalf("foreign lock released, value %q", value)
+	}
+}
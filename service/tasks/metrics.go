@@ -0,0 +1,19 @@
+package tasks
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	outcomeSuccess = "success"
+	outcomeFailed  = "failed"
+)
+
+// jobsProcessed Количество выполненных задач планировщика в разрезе задачи и результата
+var jobsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "scheduler",
+	Name:      "jobs_total",
+	Help:      "Number of executed scheduler jobs by job and outcome",
+}, []string{"job", "outcome"})
+
+func init() {
+	prometheus.MustRegister(jobsProcessed)
+}
@@ -0,0 +1,170 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/basicus/hla-course/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+const (
+	// lockKeyTemplate Блокировка выполнения задачи (одновременно выполняется не более одного экземпляра)
+	lockKeyTemplate = "timeline::jobs::lock::%s"
+	// slotKeyTemplate Отметка о запуске задачи в слоте расписания (слот запускается одним экземпляром сервиса)
+	slotKeyTemplate = "timeline::jobs::slot::%s::%d"
+)
+
+var ErrJobExists = errors.New("job already registered")
+
+// JobFunc Функция периодической задачи
+type JobFunc func(ctx context.Context) error
+
+type cronJob struct {
+	name     string
+	schedule Schedule
+	fn       JobFunc
+	next     time.Time
+}
+
+// Scheduler Планировщик периодических задач
+type Scheduler struct {
+	config Config
+	log    *logrus.Logger
+	redis  *redis.Client
+	m      sync.Mutex
+	jobs   map[string]*cronJob
+	wg     sync.WaitGroup
+	close  chan struct{}
+}
+
+// New Инициализация планировщика
+func New(config Config, redis *redis.Client, logger *logrus.Logger) (*Scheduler, error) {
+	return &Scheduler{
+		config: config,
+		log:    logger,
+		redis:  redis,
+		jobs:   make(map[string]*cronJob),
+		close:  make(chan struct{}),
+	}, nil
+}
+
+// Schedule Регистрация периодической задачи name по расписанию spec (см. ParseSchedule)
+func (s *Scheduler) Schedule(name, spec string, fn JobFunc) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("%w: %s", ErrJobExists, name)
+	}
+	s.jobs[name] = &cronJob{
+		name:     name,
+		schedule: schedule,
+		fn:       fn,
+		next:     schedule.Next(time.Now()),
+	}
+	return nil
+}
+
+// Run Запуск планировщика
+func (s *Scheduler) Run(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+	logger.Info("started scheduler")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(s.config.PollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.close:
+			cancel()
+			s.wg.Wait()
+			return nil
+		case <-ctx.Done():
+			s.wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+		s.runDueJobs(ctx, logger, time.Now())
+	}
+}
+
+// Shutdown Graceful shutdown планировщика, ожидает завершения выполняющихся задач
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	logger := log.Ctx(ctx)
+	defer func() {
+		logger.Info("Stop scheduler")
+	}()
+	close(s.close)
+	return nil
+}
+
+// runDueJobs Запускает периодические задачи, время которых наступило
+func (s *Scheduler) runDueJobs(ctx context.Context, logger *logrus.Entry, now time.Time) {
+	s.m.Lock()
+	var due []*cronJob
+	for _, job := range s.jobs {
+		if job.next.IsZero() || job.next.After(now) {
+			continue
+		}
+		// Копия с временем текущего слота
+		due = append(due, &cronJob{name: job.name, fn: job.fn, next: job.next})
+		job.next = job.schedule.Next(now)
+	}
+	s.m.Unlock()
+
+	for _, job := range due {
+		s.wg.Add(1)
+		go func(job *cronJob) {
+			defer s.wg.Done()
+			s.runJob(ctx, logger.WithField("job", job.name), job)
+		}(job)
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, logger *logrus.Entry, job *cronJob) {
+	// Слот расписания выполняется только одним экземпляром сервиса
+	claimed, err := s.redis.SetNX(ctx, fmt.Sprintf(slotKeyTemplate, job.name, job.next.Unix()), 1, s.config.LockTTL).Result()
+	if err != nil {
+		logger.WithError(err).Error("failed to claim job slot")
+		return
+	}
+	if !claimed {
+		return
+	}
+	// Пропускаем запуск, если предыдущий еще выполняется
+	lock, err := Acquire(ctx, s.redis, fmt.Sprintf(lockKeyTemplate, job.name), s.config.LockTTL)
+	if err != nil {
+		logger.WithError(err).Error("failed to acquire job lock")
+		return
+	}
+	if lock == nil {
+		logger.Warn("job is still running, skipped")
+		return
+	}
+	defer func() {
+		if err := lock.Release(context.Background()); err != nil {
+			logger.WithError(err).Error("failed to release job lock")
+		}
+	}()
+
+	started := time.Now()
+	jobCtx, cancel := context.WithTimeout(log.WithContext(ctx, logger), s.config.LockTTL)
+	defer cancel()
+	if err := job.fn(jobCtx); err != nil {
+		jobsProcessed.WithLabelValues(job.name, outcomeFailed).Inc()
+		logger.WithError(err).Error("job failed")
+		return
+	}
+	jobsProcessed.WithLabelValues(job.name, outcomeSuccess).Inc()
+	logger.Infof("job done in %s", time.Since(started))
+}
@@ -0,0 +1,62 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestScheduler(t *testing.T) *Scheduler {
+	_, client := newTestRedis(t)
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	s, err := New(Config{PollPeriod: 10 * time.Millisecond, LockTTL: time.Minute}, client, logger)
+	if err != nil {
+		t.Fatalf("new: %s", err)
+	}
+	return s
+}
+
+func TestScheduleDuplicate(t *testing.T) {
+	s := newTestScheduler(t)
+	job := func(ctx context.Context) error { return nil }
+	if err := s.Schedule("job", "@hourly", job); err != nil {
+		t.Fatalf("schedule: %s", err)
+	}
+	if err := s.Schedule("job", "@daily", job); !errors.Is(err, ErrJobExists) {
+		t.Fatalf("expected duplicate job, got %v", err)
+	}
+	if err := s.Schedule("other", "bad", job); !errors.Is(err, ErrInvalidSchedule) {
+		t.Fatalf("expected invalid schedule, got %v", err)
+	}
+}
+
+func TestRunDueJobsOncePerSlot(t *testing.T) {
+	s := newTestScheduler(t)
+	var runs int32
+	if err := s.Schedule("job", "@hourly", func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}); err != nil {
+		t.Fatalf("schedule: %s", err)
+	}
+	slot := s.jobs["job"].next
+	logger := logrus.NewEntry(s.log)
+
+	s.runDueJobs(context.Background(), logger, slot)
+	s.wg.Wait()
+	// Второй экземпляр с тем же слотом задачу не запускает
+	s.jobs["job"].next = slot
+	s.runDueJobs(context.Background(), logger, slot)
+	s.wg.Wait()
+	if runs != 1 {
+		t.Fatalf("expected one run, got %d", runs)
+	}
+	if next := s.jobs["job"].next; !next.Equal(slot.Add(time.Hour)) {
+		t.Fatalf("unexpected next run %s", next)
+	}
+}
@@ -0,0 +1,566 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Типы кэшируемых данных
+const (
+	kindUser  = "user"
+	kindLogin = "login"
+	kindPost  = "post"
+)
+
+const (
+	// keyPrefix Версия в префиксе отделяет записи прежнего формата сериализации
+	keyPrefix               = "cache:v2:"
+	invalidationChannel     = "cache:invalidate"
+	kindInvalidationChannel = "cache:invalidate:kinds"
+	invalidationScanCount   = 1000
+)
+
+// missing Значение отсутствующей записи (негативное кэширование)
+var missing = []byte("-")
+
+// dbc Кэширование пользователей и публикаций: локальный LRU процесса, затем Redis, затем next.
+// Изменения через хранилище сбрасывают записи в Redis и рассылают сброс локальных кэшей других экземпляров
+type dbc struct {
+	storage.UserService
+	config Config
+	redis  *redis.Client
+	local  *lru
+	logger *logrus.Entry
+}
+
+// New Создание кэширующего хранилища поверх next
+func New(cfg Config, next storage.UserService, logger *logrus.Logger) (storage.UserService, error) {
+	redisClient := redis.NewClient(
+		&redis.Options{
+			Addr:     cfg.Address,
+			Username: cfg.UserName,
+			Password: cfg.Password,
+			DB:       cfg.Database,
+			PoolSize: cfg.PoolSize,
+		},
+	)
+	if err := redisClient.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+	d := NewWithClient(cfg, next, redisClient, logger)
+	go d.subscribe(context.Background())
+	logger.WithField("role", "cache").Infof("Using storage cache, ttl %s, local ttl %s", cfg.TTL, cfg.LocalTTL)
+	return d, nil
+}
+
+// NewWithClient Создание кэширующего хранилища с готовым клиентом Redis (без подписки на сброс)
+func NewWithClient(cfg Config, next storage.UserService, redisClient *redis.Client, logger *logrus.Logger) *dbc {
+	return &dbc{
+		UserService: next,
+		config:      cfg,
+		redis:       redisClient,
+		local:       newLRU(cfg.LocalSize, cfg.LocalTTL),
+		logger:      logger.WithField("role", "cache"),
+	}
+}
+
+// GetById Получение пользователя по id
+func (d *dbc) GetById(ctx context.Context, id int64) (model.User, error) {
+	key := userKey(id)
+	var user model.User
+	if found, err := d.load(ctx, kindUser, key, &user); found {
+		return user, err
+	}
+	user, err := d.UserService.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			d.store(ctx, key, missing, d.config.NegativeTTL)
+		}
+		return model.User{}, err
+	}
+	d.storeValue(ctx, key, user)
+	return user, nil
+}
+
+// GetByIds Получение пользователей по списку id, отсутствующие в кэше читаются одним запросом
+func (d *dbc) GetByIds(ctx context.Context, ids []int64) ([]model.User, error) {
+	if len(ids) == 0 {
+		return d.UserService.GetByIds(ctx, ids)
+	}
+	cached := make(map[int64]model.User, len(ids))
+	var misses []int64
+	values := d.loadMany(ctx, kindUser, ids)
+	for i, id := range ids {
+		value := values[i]
+		if value == nil {
+			misses = append(misses, id)
+			continue
+		}
+		if bytes.Equal(value, missing) {
+			continue
+		}
+		var user model.User
+		if err := decodeValue(value, &user); err != nil {
+			misses = append(misses, id)
+			continue
+		}
+		cached[id] = user
+	}
+
+	if len(misses) > 0 {
+		users, err := d.UserService.GetByIds(ctx, misses)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			cached[user.UserId] = user
+			d.storeValue(ctx, userKey(user.UserId), user)
+		}
+		for _, id := range misses {
+			if _, ok := cached[id]; !ok {
+				d.store(ctx, userKey(id), missing, d.config.NegativeTTL)
+			}
+		}
+	}
+
+	var users []model.User
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		if user, ok := cached[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// GetByLogin Поиск пользователя по логину: кэшируется соответствие логина и id
+func (d *dbc) GetByLogin(ctx context.Context, login string) (model.User, error) {
+	key := loginKey(login)
+	var id int64
+	if found, err := d.load(ctx, kindLogin, key, &id); found {
+		if err != nil {
+			return model.User{}, err
+		}
+		return d.GetById(ctx, id)
+	}
+	user, err := d.UserService.GetByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			d.store(ctx, key, missing, d.config.NegativeTTL)
+		}
+		return model.User{}, err
+	}
+	d.storeValue(ctx, key, user.UserId)
+	d.storeValue(ctx, userKey(user.UserId), user)
+	return user, nil
+}
+
+// GetUserName Получить имя пользователя
+func (d *dbc) GetUserName(ctx context.Context, userId int64) (string, error) {
+	user, err := d.GetById(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+	return user.Name + " " + user.Surname, nil
+}
+
+// GetLogin Получить логин пользователя
+func (d *dbc) GetLogin(ctx context.Context, userId int64) (string, error) {
+	user, err := d.GetById(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+// GetUserNames Получить имена пользователей по списку id
+func (d *dbc) GetUserNames(ctx context.Context, ids []int64) (map[int64]string, error) {
+	users, err := d.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(users))
+	for _, user := range users {
+		names[user.UserId] = user.Name + " " + user.Surname
+	}
+	return names, nil
+}
+
+// GetUserShards Получить шарды пользователей по списку id
+func (d *dbc) GetUserShards(ctx context.Context, ids []int64) (map[int64]string, error) {
+	users, err := d.GetByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[int64]string, len(users))
+	for _, user := range users {
+		shards[user.UserId] = user.ShardId
+	}
+	return shards, nil
+}
+
+// GetPostById Получить публикацию по id
+func (d *dbc) GetPostById(ctx context.Context, postId int64) (model.Post, error) {
+	key := postKey(postId)
+	var post model.Post
+	if found, err := d.load(ctx, kindPost, key, &post); found {
+		return post, err
+	}
+	post, err := d.UserService.GetPostById(ctx, postId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			d.store(ctx, key, missing, d.config.NegativeTTL)
+		}
+		return model.Post{}, err
+	}
+	d.storeValue(ctx, key, post)
+	return post, nil
+}
+
+// WithTx Записи, измененные в транзакции, сбрасываются повторно после ее завершения,
+// чтобы не оставить в кэше значение, прочитанное до фиксации
+func (d *dbc) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKeysKey{}).(*txKeys); ok {
+		return d.UserService.WithTx(ctx, fn)
+	}
+	keys := &txKeys{}
+	err := d.UserService.WithTx(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, txKeysKey{}, keys))
+	})
+	d.invalidate(ctx, keys.list()...)
+	return err
+}
+
+func (d *dbc) Create(ctx context.Context, user model.User) (model.User, error) {
+	created, err := d.UserService.Create(ctx, user)
+	d.invalidate(ctx, loginKey(user.Login))
+	if err != nil {
+		return created, err
+	}
+	d.invalidate(ctx, userKey(created.UserId))
+	return created, nil
+}
+
+func (d *dbc) Update(ctx context.Context, user model.User, fieldsForUpdating map[string]struct{}) (model.User, error) {
+	updated, err := d.UserService.Update(ctx, user, fieldsForUpdating)
+	d.invalidate(ctx, userKey(user.UserId))
+	return updated, err
+}
+
+// Изменения подписок меняют счетчики подписчиков и друзей пользователей
+
+func (d *dbc) AddFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	added, err := d.UserService.AddFriend(ctx, user, friend)
+	d.invalidate(ctx, userKey(user), userKey(friend))
+	return added, err
+}
+
+func (d *dbc) DelFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	removed, err := d.UserService.DelFriend(ctx, user, friend)
+	d.invalidate(ctx, userKey(user), userKey(friend))
+	return removed, err
+}
+
+func (d *dbc) RemoveFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	removed, err := d.UserService.RemoveFriend(ctx, user, friend)
+	d.invalidate(ctx, userKey(user), userKey(friend))
+	return removed, err
+}
+
+func (d *dbc) SendFriendRequest(ctx context.Context, from, to int64) (model.FriendRequest, error) {
+	request, err := d.UserService.SendFriendRequest(ctx, from, to)
+	if err == nil && request.Status == model.FriendRequestAccepted {
+		d.invalidate(ctx, userKey(from), userKey(to))
+	}
+	return request, err
+}
+
+func (d *dbc) AcceptFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error) {
+	request, err := d.UserService.AcceptFriendRequest(ctx, requestId, userId)
+	if err == nil {
+		d.invalidate(ctx, userKey(request.FromUserId), userKey(request.ToUserId))
+	}
+	return request, err
+}
+
+func (d *dbc) BlockUser(ctx context.Context, userId, targetId int64, kind string) (model.UserBlock, error) {
+	block, err := d.UserService.BlockUser(ctx, userId, targetId, kind)
+	d.invalidate(ctx, userKey(userId), userKey(targetId))
+	return block, err
+}
+
+// Комментарии и реакции меняют счетчики публикации
+
+func (d *dbc) AddComment(ctx context.Context, postId, userId int64, message string) (model.Comment, error) {
+	comment, err := d.UserService.AddComment(ctx, postId, userId, message)
+	d.invalidate(ctx, postKey(postId))
+	return comment, err
+}
+
+// DelComment Публикация комментария читается до удаления: удаленный комментарий уже не найти
+func (d *dbc) DelComment(ctx context.Context, commentId, userId int64) (bool, error) {
+	comment, err := d.UserService.GetCommentById(ctx, commentId)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := d.UserService.DelComment(ctx, commentId, userId)
+	if deleted {
+		d.invalidate(ctx, postKey(comment.PostId))
+	}
+	return deleted, err
+}
+
+func (d *dbc) ReactPost(ctx context.Context, postId, userId int64, reaction string) (model.ReactionCounts, error) {
+	counts, err := d.UserService.ReactPost(ctx, postId, userId, reaction)
+	d.invalidate(ctx, postKey(postId))
+	return counts, err
+}
+
+func (d *dbc) UnreactPost(ctx context.Context, postId, userId int64) (model.ReactionCounts, error) {
+	counts, err := d.UserService.UnreactPost(ctx, postId, userId)
+	d.invalidate(ctx, postKey(postId))
+	return counts, err
+}
+
+// ReconcileCounters Пересчет меняет счетчики напрямую в таблицах, поэтому после исправлений
+// сбрасываются все закэшированные пользователи и публикации
+func (d *dbc) ReconcileCounters(ctx context.Context) (int64, error) {
+	fixed, err := d.UserService.ReconcileCounters(ctx)
+	if fixed > 0 {
+		d.invalidateKinds(ctx, kindUser, kindPost)
+	}
+	return fixed, err
+}
+
+// Close Закрытие клиента Redis (завершает подписку на сброс) и хранилища next
+func (d *dbc) Close() error {
+	if err := d.redis.Close(); err != nil {
+		d.logger.WithError(err).Warn("cache close failed")
+	}
+	return d.UserService.Close()
+}
+
+// load Поиск значения в локальном кэше и Redis. found=false - значения нет ни на одном уровне,
+// для отсутствующей записи (негативный кэш) возвращается sql.ErrNoRows
+func (d *dbc) load(ctx context.Context, kind, key string, dest interface{}) (bool, error) {
+	value, result := d.local.Get(key)
+	if result {
+		return d.decode(kind, resultLocal, value, dest)
+	}
+	value, err := d.redis.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			d.logger.WithError(err).Warn("cache get failed")
+		}
+		cacheRequests.WithLabelValues(kind, resultMiss).Inc()
+		return false, nil
+	}
+	d.local.Set(key, value)
+	return d.decode(kind, resultRedis, value, dest)
+}
+
+func (d *dbc) decode(kind, level string, value []byte, dest interface{}) (bool, error) {
+	if bytes.Equal(value, missing) {
+		cacheRequests.WithLabelValues(kind, resultNegative).Inc()
+		return true, sql.ErrNoRows
+	}
+	if err := decodeValue(value, dest); err != nil {
+		cacheRequests.WithLabelValues(kind, resultMiss).Inc()
+		return false, nil
+	}
+	cacheRequests.WithLabelValues(kind, level).Inc()
+	return true, nil
+}
+
+// loadMany Значения по списку id (nil для отсутствующих в кэше)
+func (d *dbc) loadMany(ctx context.Context, kind string, ids []int64) [][]byte {
+	values := make([][]byte, len(ids))
+	var redisKeys []string
+	var redisIdx []int
+	for i, id := range ids {
+		key := userKey(id)
+		if value, ok := d.local.Get(key); ok {
+			values[i] = value
+			cacheRequests.WithLabelValues(kind, resultLocal).Inc()
+			continue
+		}
+		redisKeys = append(redisKeys, key)
+		redisIdx = append(redisIdx, i)
+	}
+	if len(redisKeys) == 0 {
+		return values
+	}
+	result, err := d.redis.MGet(ctx, redisKeys...).Result()
+	if err != nil {
+		d.logger.WithError(err).Warn("cache mget failed")
+		cacheRequests.WithLabelValues(kind, resultMiss).Add(float64(len(redisKeys)))
+		return values
+	}
+	for j, item := range result {
+		s, ok := item.(string)
+		if !ok {
+			cacheRequests.WithLabelValues(kind, resultMiss).Inc()
+			continue
+		}
+		values[redisIdx[j]] = []byte(s)
+		d.local.Set(redisKeys[j], []byte(s))
+		cacheRequests.WithLabelValues(kind, resultRedis).Inc()
+	}
+	return values
+}
+
+func (d *dbc) storeValue(ctx context.Context, key string, value interface{}) {
+	data, err := encodeValue(value)
+	if err != nil {
+		d.logger.WithError(err).Warn("cache encode failed")
+		return
+	}
+	d.store(ctx, key, data, d.config.TTL)
+}
+
+func (d *dbc) store(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	// Внутри транзакции не кэшируем: данные могут быть откачены
+	if _, ok := ctx.Value(txKeysKey{}).(*txKeys); ok {
+		return
+	}
+	d.local.Set(key, value)
+	if err := d.redis.Set(ctx, key, value, ttl).Err(); err != nil {
+		d.logger.WithError(err).Warn("cache set failed")
+	}
+}
+
+// invalidate Сброс записей в Redis и локальных кэшах всех экземпляров
+func (d *dbc) invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if tx, ok := ctx.Value(txKeysKey{}).(*txKeys); ok {
+		tx.add(keys...)
+	}
+	d.local.Delete(keys...)
+	// Сброс выполняется и при отмене запроса клиентом
+	ctx = context.Background()
+	if err := d.redis.Del(ctx, keys...).Err(); err != nil {
+		d.logger.WithError(err).Warn("cache invalidate failed")
+	}
+	data, _ := json.Marshal(keys)
+	if err := d.redis.Publish(ctx, invalidationChannel, data).Err(); err != nil {
+		d.logger.WithError(err).Warn("cache invalidate publish failed")
+	}
+}
+
+// invalidateKinds Сброс всех записей указанных типов в Redis и локальных кэшах всех экземпляров
+func (d *dbc) invalidateKinds(ctx context.Context, kinds ...string) {
+	for _, kind := range kinds {
+		d.local.DeletePrefix(kindPrefix(kind))
+	}
+	ctx = context.Background()
+	for _, kind := range kinds {
+		var cursor uint64
+		for {
+			keys, next, err := d.redis.Scan(ctx, cursor, kindPrefix(kind)+"*", invalidationScanCount).Result()
+			if err != nil {
+				d.logger.WithError(err).Warn("cache scan failed")
+				break
+			}
+			if len(keys) > 0 {
+				if err = d.redis.Del(ctx, keys...).Err(); err != nil {
+					d.logger.WithError(err).Warn("cache invalidate failed")
+				}
+			}
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+	}
+	data, _ := json.Marshal(kinds)
+	if err := d.redis.Publish(ctx, kindInvalidationChannel, data).Err(); err != nil {
+		d.logger.WithError(err).Warn("cache invalidate publish failed")
+	}
+}
+
+// subscribe Сброс локального кэша по сообщениям других экземпляров
+func (d *dbc) subscribe(ctx context.Context) {
+	pubsub := d.redis.Subscribe(ctx, invalidationChannel, kindInvalidationChannel)
+	defer pubsub.Close()
+	for msg := range pubsub.Channel() {
+		var keys []string
+		if err := json.Unmarshal([]byte(msg.Payload), &keys); err != nil {
+			continue
+		}
+		if msg.Channel == kindInvalidationChannel {
+			for _, kind := range keys {
+				d.local.DeletePrefix(kindPrefix(kind))
+			}
+			continue
+		}
+		d.local.Delete(keys...)
+	}
+}
+
+// txKeys Ключи, измененные в транзакции
+type txKeys struct {
+	m    sync.Mutex
+	keys []string
+}
+
+type txKeysKey struct{}
+
+func (t *txKeys) add(keys ...string) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.keys = append(t.keys, keys...)
+}
+
+func (t *txKeys) list() []string {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.keys
+}
+
+// encodeValue Сериализация значения для кэша. Используется gob, а не json: json-теги моделей скрывают
+// служебные поля (ShardId, PasswordHash), которые должны сохраняться в кэше
+func encodeValue(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeValue Чтение значения, сохраненного encodeValue
+func decodeValue(data []byte, dest interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(dest)
+}
+
+func kindPrefix(kind string) string {
+	return keyPrefix + kind + ":"
+}
+
+func userKey(id int64) string {
+	return kindPrefix(kindUser) + strconv.FormatInt(id, 10)
+}
+
+func loginKey(login string) string {
+	return kindPrefix(kindLogin) + login
+}
+
+func postKey(id int64) string {
+	return kindPrefix(kindPost) + strconv.FormatInt(id, 10)
+}
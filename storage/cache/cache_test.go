@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"context"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// usersStub Хранилище пользователей, считающее обращения
+type usersStub struct {
+	storage.UserService
+	users map[int64]model.User
+	calls int
+}
+
+func (u *usersStub) GetById(_ context.Context, id int64) (model.User, error) {
+	u.calls++
+	return u.users[id], nil
+}
+
+func (u *usersStub) GetByIds(_ context.Context, ids []int64) ([]model.User, error) {
+	u.calls++
+	result := make([]model.User, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := u.users[id]; ok {
+			result = append(result, user)
+		}
+	}
+	return result, nil
+}
+
+// newTestCache Кэш с недоступным Redis: значения читаются из локального LRU
+func newTestCache(next storage.UserService) *dbc {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", DialTimeout: 50 * time.Millisecond, MaxRetries: -1})
+	return NewWithClient(Config{TTL: time.Minute, NegativeTTL: time.Second, LocalTTL: time.Minute, LocalSize: 100}, next, client, logger)
+}
+
+func TestEncodeValueKeepsHiddenFields(t *testing.T) {
+	user := model.User{UserId: 1, Login: "user1", PasswordHash: "hash", ShardId: "shard-2"}
+	data, err := encodeValue(user)
+	if err != nil {
+		t.Fatalf("encode: %s", err)
+	}
+	var decoded model.User
+	if err := decodeValue(data, &decoded); err != nil {
+		t.Fatalf("decode: %s", err)
+	}
+	if decoded.ShardId != user.ShardId || decoded.PasswordHash != user.PasswordHash || decoded.Login != user.Login {
+		t.Fatalf("user changed after round trip: %+v", decoded)
+	}
+}
+
+func TestGetByIdCacheHitKeepsShard(t *testing.T) {
+	next := &usersStub{users: map[int64]model.User{7: {UserId: 7, Login: "user7", ShardId: "shard-1"}}}
+	d := newTestCache(next)
+	ctx := context.Background()
+
+	if _, err := d.GetById(ctx, 7); err != nil {
+		t.Fatalf("first get: %s", err)
+	}
+	user, err := d.GetById(ctx, 7)
+	if err != nil {
+		t.Fatalf("cached get: %s", err)
+	}
+	if next.calls != 1 {
+		t.Fatalf("expected cache hit, storage called %d times", next.calls)
+	}
+	if user.ShardId != "shard-1" {
+		t.Fatalf("shard lost in cache: %q", user.ShardId)
+	}
+}
+
+func TestGetUserShardsFromCache(t *testing.T) {
+	next := &usersStub{users: map[int64]model.User{
+		1: {UserId: 1, ShardId: "shard-1"},
+		2: {UserId: 2, ShardId: "shard-2"},
+	}}
+	d := newTestCache(next)
+	ctx := context.Background()
+
+	if _, err := d.GetByIds(ctx, []int64{1, 2}); err != nil {
+		t.Fatalf("warm up: %s", err)
+	}
+	shards, err := d.GetUserShards(ctx, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("shards: %s", err)
+	}
+	if next.calls != 1 {
+		t.Fatalf("expected cache hit, storage called %d times", next.calls)
+	}
+	if shards[1] != "shard-1" || shards[2] != "shard-2" {
+		t.Fatalf("unexpected shards %v", shards)
+	}
+}
+
+// commentsStub Хранилище, в котором удаленный комментарий больше не находится, как в mysql
+type commentsStub struct {
+	storage.UserService
+	comments   map[int64]model.Comment
+	reconciled int64
+}
+
+func (c *commentsStub) GetCommentById(_ context.Context, commentId int64) (model.Comment, error) {
+	comment, ok := c.comments[commentId]
+	if !ok {
+		return model.Comment{}, storage.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func (c *commentsStub) DelComment(_ context.Context, commentId, _ int64) (bool, error) {
+	delete(c.comments, commentId)
+	return true, nil
+}
+
+func (c *commentsStub) ReconcileCounters(_ context.Context) (int64, error) {
+	return c.reconciled, nil
+}
+
+func TestDelCommentInvalidatesPost(t *testing.T) {
+	next := &commentsStub{comments: map[int64]model.Comment{5: {Id: 5, PostId: 3}}}
+	d := newTestCache(next)
+	d.local.Set(postKey(3), []byte("post"))
+
+	deleted, err := d.DelComment(context.Background(), 5, 1)
+	if err != nil || !deleted {
+		t.Fatalf("delete: %v %v", deleted, err)
+	}
+	if _, ok := d.local.Get(postKey(3)); ok {
+		t.Fatal("post entry left in cache")
+	}
+}
+
+func TestReconcileCountersInvalidatesUsersAndPosts(t *testing.T) {
+	next := &commentsStub{}
+	d := newTestCache(next)
+	d.local.Set(userKey(1), []byte("user"))
+	d.local.Set(postKey(2), []byte("post"))
+	d.local.Set(loginKey("user1"), []byte("1"))
+
+	// Без исправлений кэш не сбрасывается
+	if _, err := d.ReconcileCounters(context.Background()); err != nil {
+		t.Fatalf("reconcile: %s", err)
+	}
+	if _, ok := d.local.Get(userKey(1)); !ok {
+		t.Fatal("user entry dropped without fixes")
+	}
+
+	next.reconciled = 2
+	if _, err := d.ReconcileCounters(context.Background()); err != nil {
+		t.Fatalf("reconcile: %s", err)
+	}
+	if _, ok := d.local.Get(userKey(1)); ok {
+		t.Fatal("user entry left in cache")
+	}
+	if _, ok := d.local.Get(postKey(2)); ok {
+		t.Fatal("post entry left in cache")
+	}
+	if _, ok := d.local.Get(loginKey("user1")); !ok {
+		t.Fatal("login entry should be kept")
+	}
+}
@@ -0,0 +1,16 @@
+package cache
+
+import "time"
+
+type Config struct {
+	Enable      bool          `env:"CACHE_ENABLE,default=false"`
+	Address     string        `env:"REDIS_ADDRESS,default=localhost:6379"`
+	UserName    string        `env:"REDIS_USERNAME"`
+	Password    string        `env:"REDIS_PASSWORD,default=pass"`
+	Database    int           `env:"REDIS_DATABASE,default=0"`
+	PoolSize    int           `env:"REDIS_POOL_SIZE,default=5"`
+	TTL         time.Duration `env:"CACHE_TTL,default=10m"`
+	NegativeTTL time.Duration `env:"CACHE_NEGATIVE_TTL,default=30s"`
+	LocalTTL    time.Duration `env:"CACHE_LOCAL_TTL,default=10s"`
+	LocalSize   int           `env:"CACHE_LOCAL_SIZE,default=10000"`
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lru Локальный кэш процесса ограниченного размера с временем жизни записей
+type lru struct {
+	m     sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[string]*list.Element
+	order *list.List
+}
+
+type lruItem struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func newLRU(size int, ttl time.Duration) *lru {
+	return &lru{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Get Значение по ключу, если оно есть и не истекло
+func (l *lru) Get(key string) ([]byte, bool) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := el.Value.(*lruItem)
+	if time.Now().After(item.expires) {
+		l.remove(el)
+		return nil, false
+	}
+	l.order.MoveToFront(el)
+	return item.value, true
+}
+
+// Set Сохранить значение, при переполнении вытесняется давно не использованная запись
+func (l *lru) Set(key string, value []byte) {
+	if l.size <= 0 || l.ttl <= 0 {
+		return
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	expires := time.Now().Add(l.ttl)
+	if el, ok := l.items[key]; ok {
+		item := el.Value.(*lruItem)
+		item.value, item.expires = value, expires
+		l.order.MoveToFront(el)
+		return
+	}
+	l.items[key] = l.order.PushFront(&lruItem{key: key, value: value, expires: expires})
+	for l.order.Len() > l.size {
+		l.remove(l.order.Back())
+	}
+}
+
+// Delete Удалить записи по ключам
+func (l *lru) Delete(keys ...string) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	for _, key := range keys {
+		if el, ok := l.items[key]; ok {
+			l.remove(el)
+		}
+	}
+}
+
+// DeletePrefix Удалить записи, ключи которых начинаются с prefix
+func (l *lru) DeletePrefix(prefix string) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	for key, el := range l.items {
+		if strings.HasPrefix(key, prefix) {
+			l.remove(el)
+		}
+	}
+}
+
+func (l *lru) remove(el *list.Element) {
+	l.order.Remove(el)
+	delete(l.items, el.Value.(*lruItem).key)
+}
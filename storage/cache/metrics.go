@@ -0,0 +1,21 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	resultLocal    = "local"
+	resultRedis    = "redis"
+	resultNegative = "negative"
+	resultMiss     = "miss"
+)
+
+// cacheRequests Обращения к кэшу в разрезе типа данных и уровня, на котором найдено значение
+var cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "storage",
+	Name:      "cache_requests_total",
+	Help:      "Number of storage cache lookups by kind and result",
+}, []string{"kind", "result"})
+
+func init() {
+	prometheus.MustRegister(cacheRequests)
+}
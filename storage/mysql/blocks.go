@@ -0,0 +1,166 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+// BlockUser Заблокировать или скрыть пользователя. Блокировка удаляет подписки в обе стороны
+// и закрывает ожидающие заявки в друзья между пользователями
+func (d *dbc) BlockUser(ctx context.Context, userId, targetId int64, kind string) (model.UserBlock, error) {
+	var result model.UserBlock
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.blockUser(ctx, userId, targetId, kind)
+		return err
+	})
+	return result, err
+}
+
+func (d *dbc) blockUser(ctx context.Context, userId, targetId int64, kind string) (model.UserBlock, error) {
+	if userId == targetId || !model.ValidBlockKind(kind) {
+		return model.UserBlock{}, storage.ErrInvalidBlock
+	}
+	// Блокировка пары исключает одновременную отправку заявки в друзья (см. sendFriendRequest)
+	found, err := d.lockUsers(ctx, userId, targetId)
+	if err != nil {
+		return model.UserBlock{}, err
+	}
+	if !found[targetId] {
+		return model.UserBlock{}, storage.ErrInvalidBlock
+	}
+
+	// Повторный вызов меняет тип (скрытие -> блокировка и наоборот)
+	_, err = d.conn(ctx).ExecContext(ctx,
+		"insert into user_blocks (user_id, target_id, kind) values (?, ?, ?) "+
+			"on duplicate key update kind = values(kind), created_at = now();",
+		userId, targetId, kind)
+	if err != nil {
+		return model.UserBlock{}, err
+	}
+	d.written(userKeys(userId, targetId)...)
+
+	if kind == model.BlockKindBlock {
+		if _, err = d.RemoveFriend(ctx, userId, targetId); err != nil {
+			return model.UserBlock{}, err
+		}
+		_, err = d.conn(ctx).ExecContext(ctx,
+			"update friend_requests set status = if(from_user_id = ?, ?, ?) "+
+				"where status = ? and ((from_user_id = ? and to_user_id = ?) or (from_user_id = ? and to_user_id = ?));",
+			userId, model.FriendRequestCancelled, model.FriendRequestDeclined,
+			model.FriendRequestPending, userId, targetId, targetId, userId)
+		if err != nil {
+			return model.UserBlock{}, err
+		}
+	}
+
+	var block model.UserBlock
+	err = d.conn(ctx).GetContext(ctx, &block, "SELECT * from user_blocks where user_id=? and target_id=?", userId, targetId)
+	if err != nil {
+		return model.UserBlock{}, err
+	}
+	return block, nil
+}
+
+// UnblockUser Снять блокировку (или скрытие) пользователя
+func (d *dbc) UnblockUser(ctx context.Context, userId, targetId int64, kind string) (bool, error) {
+	var result sql.Result
+	err := d.retry(ctx, func() (err error) {
+		result, err = d.conn(ctx).ExecContext(ctx,
+			"delete from user_blocks where user_id = ? and target_id = ? and kind = ?;", userId, targetId, kind)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	d.written(userKeys(userId, targetId)...)
+	return affected > 0, nil
+}
+
+// GetBlocks Получить список заблокированных (скрытых) пользователем пользователей (опционально по типу)
+func (d *dbc) GetBlocks(ctx context.Context, userId int64, kind string, limit, offset int64) ([]model.UserBlock, error) {
+	var blocks []model.UserBlock
+	var sb strings.Builder
+	var args []interface{}
+
+	sb.WriteString("SELECT * from user_blocks WHERE user_id=? ")
+	args = append(args, userId)
+	if kind != "" {
+		sb.WriteString(" AND kind=? ")
+		args = append(args, kind)
+	}
+	sb.WriteString(" ORDER BY created_at DESC, target_id DESC ")
+
+	if limit > 0 {
+		sb.WriteString(" LIMIT ? ")
+		args = append(args, limit)
+		if offset > 0 {
+			sb.WriteString(" OFFSET ? ")
+			args = append(args, offset)
+		}
+	}
+	err := d.conn(ctx).SelectContext(ctx, &blocks, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// IsBlocked Проверка, что один из пользователей заблокировал другого
+func (d *dbc) IsBlocked(ctx context.Context, userId, otherId int64) (bool, error) {
+	if userId == 0 || otherId == 0 || userId == otherId {
+		return false, nil
+	}
+	return d.hasBlocks(ctx, []int64{userId, otherId}, []int64{userId, otherId})
+}
+
+// GetBlockedByIds Получить id пользователей, заблокировавших или скрывших пользователя targetId
+func (d *dbc) GetBlockedByIds(ctx context.Context, targetId int64) ([]int64, error) {
+	var ids []int64
+	connection := d.reader(ctx, userKey(targetId))
+	err := connection.SelectContext(ctx, &ids, "SELECT user_id from user_blocks where target_id=?", targetId)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetBlockedIds Получить id пользователей, с которыми у пользователя есть блокировка в любую сторону
+func (d *dbc) GetBlockedIds(ctx context.Context, userId int64) ([]int64, error) {
+	var ids []int64
+	connection := d.reader(ctx, userKey(userId))
+	err := connection.SelectContext(ctx, &ids,
+		"SELECT target_id from user_blocks where user_id=? and kind=? "+
+			"UNION SELECT user_id from user_blocks where target_id=? and kind=?",
+		userId, model.BlockKindBlock, userId, model.BlockKindBlock)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// hasBlocks Есть ли блокировки между пользователями users, в которых участвует кто-то из involved
+func (d *dbc) hasBlocks(ctx context.Context, users []int64, involved []int64) (bool, error) {
+	if len(users) < 2 || len(involved) == 0 {
+		return false, nil
+	}
+	query, args, err := sqlx.In("SELECT count(*) from user_blocks WHERE kind = ? "+
+		"and user_id IN (?) and target_id IN (?) and (user_id IN (?) or target_id IN (?))",
+		model.BlockKindBlock, users, users, involved, involved)
+	if err != nil {
+		return false, err
+	}
+	var count int
+	err = d.conn(ctx).GetContext(ctx, &count, d.conn(ctx).Rebind(query), args...)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/basicus/hla-course/model"
+	"testing"
+	"time"
+)
+
+const (
+	queryLockUsers     = `SELECT user_id from users where user_id IN \(\?, \?\) order by user_id for update`
+	queryBlockUpsert   = `insert into user_blocks .* on duplicate key update`
+	queryRequestsClose = `update friend_requests set status`
+	queryBlockGet      = `SELECT \* from user_blocks where user_id=\? and target_id=\?`
+	blockUserId        = 1
+	blockTargetId      = 2
+)
+
+func lockedRows(ids ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"user_id"})
+	for _, id := range ids {
+		rows.AddRow(id)
+	}
+	return rows
+}
+
+func expectBlockStart(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryLockUsers).WithArgs(blockUserId, blockTargetId).
+		WillReturnRows(lockedRows(blockUserId, blockTargetId))
+	mock.ExpectExec(queryBlockUpsert).WithArgs(blockUserId, blockTargetId, model.BlockKindBlock).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Подписка была только со стороны пользователя
+	mock.ExpectExec(queryFollowDelete).WithArgs(blockUserId, blockTargetId).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryFollowMutual).WillReturnRows(mutualRows(0))
+	mock.ExpectExec(queryFollowCounts).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(queryFollowDelete).WithArgs(blockTargetId, blockUserId).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestBlockUserInOneTx(t *testing.T) {
+	d, mock := newMockDbc(t)
+	expectBlockStart(mock)
+	mock.ExpectExec(queryRequestsClose).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryBlockGet).WithArgs(blockUserId, blockTargetId).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "target_id", "kind", "created_at"}).
+			AddRow(blockUserId, blockTargetId, model.BlockKindBlock, time.Now()))
+	mock.ExpectCommit()
+
+	block, err := d.BlockUser(context.Background(), blockUserId, blockTargetId, model.BlockKindBlock)
+	if err != nil {
+		t.Fatalf("block: %s", err)
+	}
+	if block.TargetId != blockTargetId || block.Kind != model.BlockKindBlock {
+		t.Fatalf("unexpected block %+v", block)
+	}
+	checkMock(t, mock)
+}
+
+func TestBlockUserRollbackOnRequestsError(t *testing.T) {
+	d, mock := newMockDbc(t)
+	expectBlockStart(mock)
+	failure := errors.New("lock wait timeout")
+	mock.ExpectExec(queryRequestsClose).WillReturnError(failure)
+	mock.ExpectRollback()
+
+	if _, err := d.BlockUser(context.Background(), blockUserId, blockTargetId, model.BlockKindBlock); !errors.Is(err, failure) {
+		t.Fatalf("expected requests error, got %v", err)
+	}
+	checkMock(t, mock)
+}
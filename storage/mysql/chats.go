@@ -2,20 +2,22 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // UserGetChats Получить список чатов
 func (d *dbc) UserGetChats(ctx context.Context, userId int64) ([]model.Chat, error) {
 	var userChats []model.Chat
-	// If RO connection is enabled use it
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
-	}
+	connection := d.reader(ctx, userKey(userId))
 	// Get user chats
 	chats, err := d.getUserChatIds(ctx, connection, userId)
 	if err != nil {
@@ -32,9 +34,9 @@ func (d *dbc) UserGetChats(ctx context.Context, userId int64) ([]model.Chat, err
 }
 
 // Получение id чатов пользователя
-func (d *dbc) getUserChatIds(ctx context.Context, connection *sqlx.DB, userId int64) ([]int64, error) {
+func (d *dbc) getUserChatIds(ctx context.Context, connection executor, userId int64) ([]int64, error) {
 	var chats []model.ChatParticipant
-	err := connection.SelectContext(ctx, &chats, "SELECT * from chat_participants where user_id=?", userId)
+	err := connection.SelectContext(ctx, &chats, "SELECT * from chat_participants where user_id=? and status=?", userId, model.ParticipantActive)
 	if err != nil {
 		return nil, err
 	}
@@ -47,29 +49,140 @@ func (d *dbc) getUserChatIds(ctx context.Context, connection *sqlx.DB, userId in
 	return chatIds, nil
 }
 
-func (d *dbc) getChatList(ctx context.Context, connection *sqlx.DB, chatIds []int64) ([]model.Chat, error) {
+func (d *dbc) getChatList(ctx context.Context, connection executor, chatIds []int64) ([]model.Chat, error) {
 	var chats []model.Chat
 	queryFriends, args, err := sqlx.In("SELECT * FROM chats WHERE chats.id IN (?)", chatIds)
 	if err != nil {
 		return nil, err
 	}
-	err = connection.SelectContext(ctx, &chats, d.connection.Rebind(queryFriends), args...)
+	err = connection.SelectContext(ctx, &chats, d.conn(ctx).Rebind(queryFriends), args...)
 	if err != nil {
 		return nil, err
 	}
 	return chats, nil
 }
 
-// ChatCreate Создать новый чат
-func (d *dbc) ChatCreate(ctx context.Context, title string, participants ...int64) (model.Chat, error) {
-	sql := "insert into chats (title) values (?);"
-	stmt, err := d.connection.Prepare(sql)
+// ChatCreate Создать новый чат. Чат с одним собеседником создается как диалог, повторное создание
+// возвращает существующий диалог
+func (d *dbc) ChatCreate(ctx context.Context, createdBy int64, title string, participants ...int64) (model.Chat, error) {
+	users := uniqueIds(append(append([]int64{}, participants...), createdBy))
+	if len(users) == 2 {
+		peerId := users[0]
+		if peerId == createdBy {
+			peerId = users[1]
+		}
+		return d.dialogCreate(ctx, createdBy, peerId, title)
+	}
+
+	var result model.Chat
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.chatCreate(ctx, createdBy, title, false, users...)
+		return err
+	})
+	return result, err
+}
+
+// dialogCreate Получение или создание диалога. При одновременном создании одного диалога
+// уникальный индекс пары отклоняет второй запрос, и он возвращает созданный первым диалог.
+// Вместо закрытого диалога создается новый
+func (d *dbc) dialogCreate(ctx context.Context, userId, peerId int64, title string) (model.Chat, error) {
+	var result model.Chat
+	create := func(ctx context.Context) (err error) {
+		result, err = d.dialogGetOrCreate(ctx, userId, peerId, title)
+		return err
+	}
+	err := d.WithTx(ctx, create)
+	if duplicateError(err) && !inTx(ctx) {
+		err = d.WithTx(ctx, create)
+	}
+	return result, err
+}
+
+func (d *dbc) dialogGetOrCreate(ctx context.Context, userId, peerId int64, title string) (model.Chat, error) {
+	low, high := dialogPair(userId, peerId)
+	var chatId int64
+	err := d.conn(ctx).GetContext(ctx, &chatId, "SELECT chat_id from chat_dialogs where user_low=? and user_high=? for update", low, high)
+	if err == nil {
+		chat, err := d.chatLock(ctx, chatId)
+		switch {
+		case err == nil && !chat.Closed:
+			// Покинувшие диалог возвращаются в него
+			if _, err = d.addParticipants(ctx, chatId, []int64{userId, peerId}); err != nil {
+				return model.Chat{}, err
+			}
+			return chat, nil
+		case err == nil, errors.Is(err, storage.ErrChatNotFound):
+			// Закрытый диалог остается в истории только для чтения, для пары создается новый диалог
+			_, err = d.conn(ctx).ExecContext(ctx, "delete from chat_dialogs where user_low=? and user_high=?;", low, high)
+			if err != nil {
+				return model.Chat{}, err
+			}
+		default:
+			return model.Chat{}, err
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return model.Chat{}, err
+	}
+
+	chat, err := d.chatCreate(ctx, userId, title, true, userId, peerId)
+	if err != nil {
+		return model.Chat{}, err
+	}
+	_, err = d.conn(ctx).ExecContext(ctx, "insert into chat_dialogs (user_low, user_high, chat_id) values (?, ?, ?);", low, high, chat.Id)
+	if err != nil {
+		return model.Chat{}, err
+	}
+	return chat, nil
+}
+
+// dialogUsers Пользователи диалога chatId
+func (d *dbc) dialogUsers(ctx context.Context, chatId int64) ([]int64, error) {
+	var pair struct {
+		Low  int64 `db:"user_low"`
+		High int64 `db:"user_high"`
+	}
+	err := d.conn(ctx).GetContext(ctx, &pair, "SELECT user_low, user_high from chat_dialogs where chat_id=?", chatId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []int64{pair.Low, pair.High}, nil
+}
+
+// ChatGetDialog Получить диалог двух пользователей
+func (d *dbc) ChatGetDialog(ctx context.Context, userId, peerId int64) (model.Chat, error) {
+	low, high := dialogPair(userId, peerId)
+	var chat model.Chat
+	connection := d.reader(ctx, userKey(userId), userKey(peerId))
+	err := connection.GetContext(ctx, &chat, "SELECT c.* from chats c join chat_dialogs d on d.chat_id = c.id where d.user_low=? and d.user_high=?", low, high)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Chat{}, storage.ErrChatNotFound
+		}
+		return model.Chat{}, err
+	}
+	return chat, nil
+}
+
+func (d *dbc) chatCreate(ctx context.Context, createdBy int64, title string, dialog bool, participants ...int64) (model.Chat, error) {
+	blocked, err := d.hasBlocks(ctx, participants, participants)
+	if err != nil {
+		return model.Chat{}, err
+	}
+	if blocked {
+		return model.Chat{}, storage.ErrBlocked
+	}
+
+	sql := "insert into chats (title, created_by, dialog) values (?, ?, ?);"
+	stmt, err := d.conn(ctx).Prepare(sql)
 	defer stmt.Close()
 	if err != nil {
 		return model.Chat{}, err
 	}
 
-	result, err := stmt.ExecContext(ctx, title)
+	result, err := stmt.ExecContext(ctx, title, createdBy, dialog)
 	if err != nil {
 		return model.Chat{}, err
 	}
@@ -82,7 +195,11 @@ func (d *dbc) ChatCreate(ctx context.Context, title string, participants ...int6
 		return model.Chat{}, err
 	}
 	// Add participants
-	err = d.ChatAddParticipants(ctx, chatId, participants)
+	_, err = d.addParticipants(ctx, chatId, participants)
+	if err != nil {
+		return model.Chat{}, err
+	}
+	_, err = d.conn(ctx).ExecContext(ctx, "update chat_participants set role = ? where chat_id = ? and user_id = ?;", model.ChatRoleOwner, chatId, createdBy)
 	if err != nil {
 		return model.Chat{}, err
 	}
@@ -90,135 +207,601 @@ func (d *dbc) ChatCreate(ctx context.Context, title string, participants ...int6
 	return chat, nil
 }
 
-// ChatDelete Удалить чат
-func (d *dbc) ChatDelete(ctx context.Context, chatId int64) (model.Chat, error) {
-	panic("not implemented")
+// ChatDelete Удалить чат вместе с сообщениями и реакциями на них
+func (d *dbc) ChatDelete(ctx context.Context, chatId, userId int64) (model.Chat, error) {
+	var chat model.Chat
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		// Закрытый чат тоже можно удалить
+		chat, err = d.chatLock(ctx, chatId)
+		if err != nil {
+			return err
+		}
+		if _, err = d.chatMember(ctx, chatId, userId, model.ChatRoleOwner); err != nil {
+			return err
+		}
+		queries := []string{
+			"delete from reaction_counts where item_type = 'message' and item_id in (select id from messages where chat_id = ?);",
+			"delete from reactions where item_type = 'message' and item_id in (select id from messages where chat_id = ?);",
+			"delete from messages where chat_id = ?;",
+			"delete from chat_participants where chat_id = ?;",
+			"delete from chat_dialogs where chat_id = ?;",
+			"delete from chats where id = ?;",
+		}
+		for _, query := range queries {
+			if _, err = d.conn(ctx).ExecContext(ctx, query, chatId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return model.Chat{}, err
+	}
+	d.written(chatKey(chatId))
+	return chat, nil
 }
 
 // ChatGetParticipants Получить список участников чата
 func (d *dbc) ChatGetParticipants(ctx context.Context, chatId int64) ([]int64, error) {
-	var chats []model.ChatParticipant
-	// If RO connection is enabled use it
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
-	}
-	err := connection.SelectContext(ctx, &chats, "SELECT * from chat_participants where chat_id=?", chatId)
+	members, err := d.ChatGetMembers(ctx, chatId)
 	if err != nil {
 		return nil, err
 	}
 
 	var participants []int64
 
-	for _, chat := range chats {
-		participants = append(participants, chat.UserId)
+	for _, member := range members {
+		participants = append(participants, member.UserId)
 	}
 	return participants, nil
 }
 
+// ChatGetMembers Получить участников чата с их ролями
+func (d *dbc) ChatGetMembers(ctx context.Context, chatId int64) ([]model.ChatParticipant, error) {
+	var members []model.ChatParticipant
+	connection := d.reader(ctx, chatKey(chatId))
+	err := connection.SelectContext(ctx, &members, "SELECT * from chat_participants where chat_id=? and status=? order by id", chatId, model.ParticipantActive)
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ChatGetParticipant Получить участника чата. ErrNotParticipant, если пользователь не участник
+func (d *dbc) ChatGetParticipant(ctx context.Context, chatId, userId int64) (model.ChatParticipant, error) {
+	var participant model.ChatParticipant
+	connection := d.reader(ctx, chatKey(chatId))
+	err := connection.GetContext(ctx, &participant, "SELECT * from chat_participants where chat_id=? and user_id=? and status=?", chatId, userId, model.ParticipantActive)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.ChatParticipant{}, storage.ErrNotParticipant
+		}
+		return model.ChatParticipant{}, err
+	}
+	return participant, nil
+}
+
 // ChatAddParticipants Добавить участников чата
-func (d *dbc) ChatAddParticipants(ctx context.Context, chatId int64, userIds []int64) error {
-	participants, err := d.ChatGetParticipants(ctx, chatId)
+func (d *dbc) ChatAddParticipants(ctx context.Context, chatId, userId int64, userIds []int64) error {
+	return d.WithTx(ctx, func(ctx context.Context) error {
+		chat, participant, err := d.chatForParticipant(ctx, chatId, userId)
+		if err != nil {
+			return err
+		}
+		// Диалог не превращается в групповой чат
+		if chat.Dialog {
+			return storage.ErrDialogChat
+		}
+		if !model.RoleAllows(participant.Role, chat.Invite) {
+			return storage.ErrAccessDenied
+		}
+		added, err := d.addParticipants(ctx, chatId, userIds)
+		if err != nil || len(added) == 0 {
+			return err
+		}
+		return d.systemMessage(ctx, chatId, userId, "added participants: "+joinIds(added))
+	})
+}
+
+// addParticipants Добавление участников в чат, в том числе возврат покинувших чат.
+// Возвращает id пользователей, которые не были участниками
+func (d *dbc) addParticipants(ctx context.Context, chatId int64, userIds []int64) ([]int64, error) {
+	var rows []model.ChatParticipant
+	err := d.conn(ctx).SelectContext(ctx, &rows, "SELECT * from chat_participants where chat_id=?", chatId)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	statuses := make(map[int64]model.ParticipantStatus, len(rows))
+	var participants []int64
+	for _, row := range rows {
+		statuses[row.UserId] = row.Status
+		if row.Status == model.ParticipantActive {
+			participants = append(participants, row.UserId)
+		}
 	}
-	alreadyParticipant := false
 
-	for _, participant := range participants {
-		for _, userId := range userIds {
-			if participant == userId {
-				alreadyParticipant = true
-				break
-			}
+	var added []int64
+	for _, id := range userIds {
+		if status, ok := statuses[id]; ok && status == model.ParticipantActive {
+			continue
 		}
-		if alreadyParticipant {
-			break
+		if containsId(added, id) {
+			continue
 		}
+		added = append(added, id)
+	}
+	if len(added) == 0 {
+		return nil, nil
 	}
-	c := 0
-	if !alreadyParticipant {
-		sql := "insert into chat_participants (chat_id,user_id) values (?, ?);"
-		stmt, err := d.connection.Prepare(sql)
-		defer stmt.Close()
+
+	// Новые участники не должны быть заблокированы участниками чата (и друг другом)
+	blocked, err := d.hasBlocks(ctx, append(append([]int64{}, participants...), added...), added)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, storage.ErrBlocked
+	}
+
+	for _, id := range added {
+		// Вернувшиеся участники получают роль обычного участника
+		if _, ok := statuses[id]; ok {
+			_, err = d.conn(ctx).ExecContext(ctx, "update chat_participants set status = ?, role = ? where chat_id = ? and user_id = ?;", model.ParticipantActive, model.ChatRoleMember, chatId, id)
+		} else {
+			_, err = d.conn(ctx).ExecContext(ctx, "insert into chat_participants (chat_id, user_id, status, role) values (?, ?, ?, ?);", chatId, id, model.ParticipantActive, model.ChatRoleMember)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	d.written(append(userKeys(added...), chatKey(chatId))...)
+	return added, nil
+}
+
+// ChatRemoveParticipants Исключить участников из чата. Исключать можно только участников с младшей ролью
+func (d *dbc) ChatRemoveParticipants(ctx context.Context, chatId, userId int64, userIds []int64) error {
+	return d.WithTx(ctx, func(ctx context.Context) error {
+		chat, participant, err := d.chatForRole(ctx, chatId, userId, model.ChatRoleAdmin)
 		if err != nil {
 			return err
 		}
-		for _, userId := range userIds {
-			result, err := stmt.ExecContext(ctx, chatId, userId)
+		if chat.Dialog {
+			return storage.ErrDialogChat
+		}
+		var removed []int64
+		for _, id := range userIds {
+			// Себя исключить нельзя, для этого нужно покинуть чат
+			if id == userId || containsId(removed, id) {
+				continue
+			}
+			target, err := d.ChatGetParticipant(ctx, chatId, id)
+			if errors.Is(err, storage.ErrNotParticipant) {
+				continue
+			}
 			if err != nil {
 				return err
 			}
-			affected, err := result.RowsAffected()
-			if err != nil {
+			if model.RoleAllows(target.Role, participant.Role) {
+				return storage.ErrAccessDenied
+			}
+			if _, err = d.setParticipantStatus(ctx, chatId, id, model.ParticipantRemoved); err != nil {
 				return err
 			}
-			if affected > 0 {
-				c++
+			removed = append(removed, id)
+		}
+		if len(removed) == 0 {
+			return storage.ErrNotParticipant
+		}
+		return d.systemMessage(ctx, chatId, userId, "removed participants: "+joinIds(removed))
+	})
+}
+
+// ChatLeave Покинуть чат. Если чат покидает владелец, владельцем становится старейший администратор,
+// а при их отсутствии - старейший участник
+func (d *dbc) ChatLeave(ctx context.Context, chatId, userId int64) error {
+	return d.WithTx(ctx, func(ctx context.Context) error {
+		// Закрытый чат тоже можно покинуть
+		if _, err := d.chatLock(ctx, chatId); err != nil {
+			return err
+		}
+		participant, err := d.chatMember(ctx, chatId, userId, model.ChatRoleMember)
+		if errors.Is(err, storage.ErrAccessDenied) {
+			return storage.ErrNotParticipant
+		}
+		if err != nil {
+			return err
+		}
+		if _, err = d.setParticipantStatus(ctx, chatId, userId, model.ParticipantLeft); err != nil {
+			return err
+		}
+		if err = d.systemMessage(ctx, chatId, userId, "left the chat"); err != nil {
+			return err
+		}
+		if participant.Role != model.ChatRoleOwner {
+			return nil
+		}
+
+		members, err := d.ChatGetMembers(ctx, chatId)
+		if err != nil || len(members) == 0 {
+			return err
+		}
+		next := members[0]
+		for _, member := range members {
+			if member.Role == model.ChatRoleAdmin {
+				next = member
+				break
+			}
+		}
+		if err = d.setOwner(ctx, chatId, next.UserId); err != nil {
+			return err
+		}
+		return d.systemMessage(ctx, chatId, next.UserId, "became the owner of the chat")
+	})
+}
+
+// ChatSetRole Сменить роль участника (только владельцем чата). Назначение владельцем передает права владельца,
+// прежний владелец становится администратором
+func (d *dbc) ChatSetRole(ctx context.Context, chatId, userId, targetId int64, role string) error {
+	if !model.ValidChatRole(role) {
+		return storage.ErrInvalidChatRole
+	}
+	return d.WithTx(ctx, func(ctx context.Context) error {
+		chat, _, err := d.chatForRole(ctx, chatId, userId, model.ChatRoleOwner)
+		if err != nil {
+			return err
+		}
+		if chat.Dialog {
+			return storage.ErrDialogChat
+		}
+		if targetId == userId {
+			return storage.ErrInvalidChatRole
+		}
+		target, err := d.ChatGetParticipant(ctx, chatId, targetId)
+		if err != nil {
+			return err
+		}
+		if target.Role == role {
+			return nil
+		}
+
+		if role == model.ChatRoleOwner {
+			if _, err = d.conn(ctx).ExecContext(ctx, "update chat_participants set role = ? where chat_id = ? and user_id = ?;", model.ChatRoleAdmin, chatId, userId); err != nil {
+				return err
 			}
+			err = d.setOwner(ctx, chatId, targetId)
+		} else {
+			_, err = d.conn(ctx).ExecContext(ctx, "update chat_participants set role = ? where chat_id = ? and user_id = ?;", role, chatId, targetId)
+		}
+		if err != nil {
+			return err
+		}
+		d.written(chatKey(chatId))
+		return d.systemMessage(ctx, chatId, userId, fmt.Sprintf("changed role of user %d to %s", targetId, role))
+	})
+}
+
+// ChatUpdateSettings Изменить настройки чата (только владельцем чата)
+func (d *dbc) ChatUpdateSettings(ctx context.Context, chatId, userId int64, settings model.ChatSettings) (model.Chat, error) {
+	if !settings.Valid() {
+		return model.Chat{}, storage.ErrInvalidChatRole
+	}
+	var chat model.Chat
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		chat, _, err = d.chatForRole(ctx, chatId, userId, model.ChatRoleOwner)
+		if err != nil {
+			return err
 		}
-		if c > 0 {
+		if chat.ChatSettings == settings {
 			return nil
 		}
+		_, err = d.conn(ctx).ExecContext(ctx, "update chats set invite_role = ?, post_role = ?, rename_role = ? where id = ?;",
+			settings.Invite, settings.Post, settings.Rename, chatId)
+		if err != nil {
+			return err
+		}
+		chat.ChatSettings = settings
+		return d.systemMessage(ctx, chatId, userId, fmt.Sprintf("changed chat settings: invite %s, post %s, rename %s",
+			settings.Invite, settings.Post, settings.Rename))
+	})
+	if err != nil {
+		return model.Chat{}, err
 	}
-	return nil
+	d.written(chatKey(chatId))
+	return chat, nil
 }
 
-// ChatLeave Покинуть чат
-func (d *dbc) ChatLeave(ctx context.Context, chatId, userId int64) error {
-	sql := "delete from chat_participants where chat_id = ? and user_id =? ;"
-	stmt, err := d.connection.Prepare(sql)
-	defer stmt.Close()
+// ChatRename Переименовать чат
+func (d *dbc) ChatRename(ctx context.Context, chatId, userId int64, title string) (model.Chat, error) {
+	var chat model.Chat
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		var participant model.ChatParticipant
+		chat, participant, err = d.chatForParticipant(ctx, chatId, userId)
+		if err != nil {
+			return err
+		}
+		if !model.RoleAllows(participant.Role, chat.Rename) {
+			return storage.ErrAccessDenied
+		}
+		if chat.Title == title {
+			return nil
+		}
+		if _, err = d.conn(ctx).ExecContext(ctx, "update chats set title = ? where id = ?;", title, chatId); err != nil {
+			return err
+		}
+		chat.Title = title
+		return d.systemMessage(ctx, chatId, userId, fmt.Sprintf("renamed the chat to %q", title))
+	})
 	if err != nil {
-		return err
+		return model.Chat{}, err
 	}
+	d.written(chatKey(chatId))
+	return chat, nil
+}
 
-	result, err := stmt.ExecContext(ctx, chatId, userId)
+// ChatClose Закрыть чат
+func (d *dbc) ChatClose(ctx context.Context, chatId, userId int64) (model.Chat, error) {
+	var chat model.Chat
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		chat, _, err = d.chatForRole(ctx, chatId, userId, model.ChatRoleOwner)
+		if err != nil {
+			return err
+		}
+		if _, err = d.conn(ctx).ExecContext(ctx, "update chats set closed = true where id = ?;", chatId); err != nil {
+			return err
+		}
+		chat.Closed = true
+		return d.systemMessage(ctx, chatId, userId, "closed the chat")
+	})
 	if err != nil {
-		return err
+		return model.Chat{}, err
 	}
-	affected, err := result.RowsAffected()
+	d.written(chatKey(chatId))
+	return chat, nil
+}
+
+// ChatAccess Проверка доступа к чату: чат существует и пользователь является его участником
+func (d *dbc) ChatAccess(ctx context.Context, chatId, userId int64) (model.Chat, model.ChatParticipant, error) {
+	var chat model.Chat
+	connection := d.reader(ctx, chatKey(chatId))
+	err := connection.GetContext(ctx, &chat, "SELECT * from chats where id=?", chatId)
 	if err != nil {
-		return err
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Chat{}, model.ChatParticipant{}, storage.ErrChatNotFound
+		}
+		return model.Chat{}, model.ChatParticipant{}, err
 	}
-	if affected > 0 {
-		return nil
+	participant, err := d.ChatGetParticipant(ctx, chatId, userId)
+	if errors.Is(err, storage.ErrNotParticipant) {
+		return model.Chat{}, model.ChatParticipant{}, storage.ErrAccessDenied
+	}
+	if err != nil {
+		return model.Chat{}, model.ChatParticipant{}, err
 	}
-	return fmt.Errorf("user is no participant")
+	return chat, participant, nil
 }
 
-// MessageSave Отправить сообщение в чат
-func (d *dbc) MessageSave(ctx context.Context, chatId, userFromId int64, date time.Time, message string) (model.Message, error) {
-	sql := "insert into messages (chat_id, user_from, send_at, message) values (?,?,?,?);"
-	stmt, err := d.connection.Prepare(sql)
-	defer stmt.Close()
+// ChatMessage Сообщение чата, доступное участнику чата
+func (d *dbc) ChatMessage(ctx context.Context, chatId, messageId, userId int64) (model.Message, error) {
+	message, err := d.MessageGet(ctx, messageId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Message{}, storage.ErrMessageNotFound
+		}
 		return model.Message{}, err
 	}
+	if message.ChatId != chatId {
+		return model.Message{}, storage.ErrMessageNotFound
+	}
+	if _, _, err = d.ChatAccess(ctx, chatId, userId); err != nil {
+		return model.Message{}, err
+	}
+	return message, nil
+}
 
-	result, err := stmt.ExecContext(ctx, chatId, userFromId, date, message)
+// chatWriteAccess Проверка доступа участника к записи в чат внутри транзакции: закрытый чат доступен только для чтения.
+// Чат блокируется на чтение, поэтому закрытие чата ожидает завершения записи
+func (d *dbc) chatWriteAccess(ctx context.Context, chatId, userId int64) (model.Chat, model.ChatParticipant, error) {
+	var chat model.Chat
+	err := d.conn(ctx).GetContext(ctx, &chat, "SELECT * from chats where id=? lock in share mode", chatId)
 	if err != nil {
-		return model.Message{}, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Chat{}, model.ChatParticipant{}, storage.ErrChatNotFound
+		}
+		return model.Chat{}, model.ChatParticipant{}, err
 	}
-	messageId, err := result.LastInsertId()
+	participant, err := d.chatMember(ctx, chatId, userId, model.ChatRoleMember)
 	if err != nil {
-		return model.Message{}, err
+		return model.Chat{}, model.ChatParticipant{}, err
+	}
+	if chat.Closed {
+		return model.Chat{}, model.ChatParticipant{}, storage.ErrChatClosed
 	}
-	messageSaved, err := d.MessageGet(ctx, messageId)
+	return chat, participant, nil
+}
+
+// chatLock Блокировка чата (в том числе закрытого) до конца транзакции
+func (d *dbc) chatLock(ctx context.Context, chatId int64) (model.Chat, error) {
+	var chat model.Chat
+	err := d.conn(ctx).GetContext(ctx, &chat, "SELECT * from chats where id=? for update", chatId)
 	if err != nil {
-		return model.Message{}, err
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Chat{}, storage.ErrChatNotFound
+		}
+		return model.Chat{}, err
 	}
+	return chat, nil
+}
 
+// chatForUpdate Блокировка открытого чата для изменения
+func (d *dbc) chatForUpdate(ctx context.Context, chatId int64) (model.Chat, error) {
+	chat, err := d.chatLock(ctx, chatId)
+	if err != nil {
+		return model.Chat{}, err
+	}
+	if chat.Closed {
+		return model.Chat{}, storage.ErrChatClosed
+	}
+	return chat, nil
+}
+
+// chatMember Участник чата с ролью не младше role. ErrAccessDenied, если пользователь не участник или роль младше
+func (d *dbc) chatMember(ctx context.Context, chatId, userId int64, role string) (model.ChatParticipant, error) {
+	participant, err := d.ChatGetParticipant(ctx, chatId, userId)
+	if errors.Is(err, storage.ErrNotParticipant) {
+		return model.ChatParticipant{}, storage.ErrAccessDenied
+	}
+	if err != nil {
+		return model.ChatParticipant{}, err
+	}
+	if !model.RoleAllows(participant.Role, role) {
+		return model.ChatParticipant{}, storage.ErrAccessDenied
+	}
+	return participant, nil
+}
+
+// chatForParticipant Блокировка открытого чата для изменения участником чата
+func (d *dbc) chatForParticipant(ctx context.Context, chatId, userId int64) (model.Chat, model.ChatParticipant, error) {
+	return d.chatForRole(ctx, chatId, userId, model.ChatRoleMember)
+}
+
+// chatForRole Блокировка открытого чата для изменения участником с ролью не младше role
+func (d *dbc) chatForRole(ctx context.Context, chatId, userId int64, role string) (model.Chat, model.ChatParticipant, error) {
+	chat, err := d.chatForUpdate(ctx, chatId)
+	if err != nil {
+		return model.Chat{}, model.ChatParticipant{}, err
+	}
+	participant, err := d.chatMember(ctx, chatId, userId, role)
+	if err != nil {
+		return model.Chat{}, model.ChatParticipant{}, err
+	}
+	return chat, participant, nil
+}
+
+// setOwner Назначение владельца чата
+func (d *dbc) setOwner(ctx context.Context, chatId, userId int64) error {
+	_, err := d.conn(ctx).ExecContext(ctx, "update chat_participants set role = ? where chat_id = ? and user_id = ?;", model.ChatRoleOwner, chatId, userId)
+	if err != nil {
+		return err
+	}
+	_, err = d.conn(ctx).ExecContext(ctx, "update chats set created_by = ? where id = ?;", userId, chatId)
+	return err
+}
+
+// setParticipantStatus Смена статуса участника чата. false, если пользователь не участник
+func (d *dbc) setParticipantStatus(ctx context.Context, chatId, userId int64, status model.ParticipantStatus) (bool, error) {
+	result, err := d.conn(ctx).ExecContext(ctx, "update chat_participants set status = ? where chat_id = ? and user_id = ? and status = ?;", status, chatId, userId, model.ParticipantActive)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected > 0 {
+		d.written(userKey(userId), chatKey(chatId))
+	}
+	return affected > 0, nil
+}
+
+// systemMessage Запись системного сообщения об изменении чата в историю
+func (d *dbc) systemMessage(ctx context.Context, chatId, userId int64, message string) error {
+	_, err := d.conn(ctx).ExecContext(ctx, "insert into messages (chat_id, user_from, send_at, message, `system`) values (?,?,?,?,true);", chatId, userId, time.Now(), message)
+	if err != nil {
+		return err
+	}
+	d.written(chatKey(chatId))
+	return nil
+}
+
+func joinIds(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(s, ", ")
+}
+
+func uniqueIds(ids []int64) []int64 {
+	var result []int64
+	for _, id := range ids {
+		if !containsId(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// dialogPair Упорядоченная пара собеседников диалога
+func dialogPair(userId, peerId int64) (int64, int64) {
+	if userId < peerId {
+		return userId, peerId
+	}
+	return peerId, userId
+}
+
+// duplicateError Нарушение уникального индекса
+func duplicateError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == erDupEntry
+}
+
+func containsId(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageSave Отправить сообщение в чат
+func (d *dbc) MessageSave(ctx context.Context, chatId, userFromId int64, date time.Time, message string) (model.Message, error) {
+	var messageSaved model.Message
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		chat, participant, err := d.chatWriteAccess(ctx, chatId, userFromId)
+		if err != nil {
+			return err
+		}
+		if !model.RoleAllows(participant.Role, chat.Post) {
+			return storage.ErrAccessDenied
+		}
+		// В диалоге нельзя писать пользователю, с которым есть блокировка (даже если он покинул диалог)
+		if chat.Dialog {
+			users, err := d.dialogUsers(ctx, chatId)
+			if err != nil {
+				return err
+			}
+			blocked, err := d.hasBlocks(ctx, users, users)
+			if err != nil {
+				return err
+			}
+			if blocked {
+				return storage.ErrBlocked
+			}
+		}
+
+		result, err := d.conn(ctx).ExecContext(ctx, "insert into messages (chat_id, user_from, send_at, message) values (?,?,?,?);", chatId, userFromId, date, message)
+		if err != nil {
+			return err
+		}
+		messageId, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		messageSaved, err = d.MessageGet(ctx, messageId)
+		return err
+	})
+	if err != nil {
+		return model.Message{}, err
+	}
+	d.written(chatKey(chatId))
 	return messageSaved, nil
 }
 
 // ChatMessages Получение списка сообщений из чата
 func (d *dbc) ChatMessages(ctx context.Context, chatId int64, limit, offset int64) ([]model.Message, error) {
 	var messages []model.Message
-	// If RO connection is enabled use it
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
-	}
+	connection := d.reader(ctx, chatKey(chatId))
 	err := connection.SelectContext(ctx, &messages, "SELECT * from messages where chat_id=?", chatId)
 	if err != nil {
 		return nil, err
@@ -228,7 +811,7 @@ func (d *dbc) ChatMessages(ctx context.Context, chatId int64, limit, offset int6
 
 func (d *dbc) MessageGet(ctx context.Context, id int64) (model.Message, error) {
 	var message model.Message
-	err := d.connection.GetContext(ctx, &message, "SELECT * from messages where id=?", id)
+	err := d.conn(ctx).GetContext(ctx, &message, "SELECT * from messages where id=?", id)
 	if err != nil {
 		return model.Message{}, err
 	}
@@ -238,7 +821,7 @@ func (d *dbc) MessageGet(ctx context.Context, id int64) (model.Message, error) {
 // GetChat Получить информацию о чате по id
 func (d *dbc) GetChat(ctx context.Context, chatId int64) (model.Chat, error) {
 	var chat model.Chat
-	err := d.connection.GetContext(ctx, &chat, "SELECT * from chats where id=?", chatId)
+	err := d.conn(ctx).GetContext(ctx, &chat, "SELECT * from chats where id=?", chatId)
 	if err != nil {
 		return model.Chat{}, err
 	}
@@ -0,0 +1,365 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+const (
+	queryChatLock        = `SELECT \* from chats where id=\? for update`
+	queryChatParticipant = `SELECT \* from chat_participants where chat_id=\? and user_id=\? and status=\?`
+	queryDialogPair      = `SELECT chat_id from chat_dialogs where user_low=\? and user_high=\? for update`
+	queryBlocks          = `SELECT count\(\*\) from user_blocks`
+	querySystemMessage   = "insert into messages \\(chat_id, user_from, send_at, message, `system`\\)"
+)
+
+// newMockDbc Хранилище поверх sqlmock
+func newMockDbc(t *testing.T) (*dbc, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %s", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	return &dbc{logger: logger, connection: sqlx.NewDb(db, "mysql")}, mock
+}
+
+func chatRow(chat model.Chat) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "title", "created_at", "closed", "created_by", "dialog", "invite_role", "post_role", "rename_role"}).
+		AddRow(chat.Id, chat.Title, time.Now(), chat.Closed, chat.CreatedBy, chat.Dialog, chat.Invite, chat.Post, chat.Rename)
+}
+
+func participantRows(participants ...model.ChatParticipant) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "chat_id", "user_id", "status", "role"})
+	for i, p := range participants {
+		rows.AddRow(int64(i+1), p.ChatId, p.UserId, model.ParticipantActive, p.Role)
+	}
+	return rows
+}
+
+func dialogChat(closed bool) model.Chat {
+	chat := groupChat(closed)
+	chat.Id, chat.Title, chat.Dialog = 20, "", true
+	return chat
+}
+
+func groupChat(closed bool) model.Chat {
+	return model.Chat{Id: 10, Title: "group", Closed: closed, CreatedBy: 1, ChatSettings: model.ChatSettings{
+		Invite: model.ChatRoleAdmin, Post: model.ChatRoleMember, Rename: model.ChatRoleAdmin,
+	}}
+}
+
+func member(userId int64, role string) model.ChatParticipant {
+	return model.ChatParticipant{ChatId: 10, UserId: userId, Role: role}
+}
+
+func checkMock(t *testing.T, mock sqlmock.Sqlmock) {
+	t.Helper()
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChatDeleteClosedChat(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(true)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(1, model.ChatRoleOwner)))
+	for _, table := range []string{"reaction_counts", "reactions", "messages", "chat_participants", "chat_dialogs", "chats"} {
+		mock.ExpectExec("delete from " + table + " where").WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	if _, err := d.ChatDelete(context.Background(), 10, 1); err != nil {
+		t.Fatalf("delete closed chat: %s", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatDeleteRequiresOwner(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(2, model.ChatRoleAdmin)))
+	mock.ExpectRollback()
+
+	if _, err := d.ChatDelete(context.Background(), 10, 2); !errors.Is(err, storage.ErrAccessDenied) {
+		t.Fatalf("expected ErrAccessDenied, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatLeaveClosedChat(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(true)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(2, model.ChatRoleMember)))
+	mock.ExpectExec("update chat_participants set status").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(querySystemMessage).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := d.ChatLeave(context.Background(), 10, 2); err != nil {
+		t.Fatalf("leave closed chat: %s", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatLeaveNotParticipant(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows())
+	mock.ExpectRollback()
+
+	if err := d.ChatLeave(context.Background(), 10, 3); !errors.Is(err, storage.ErrNotParticipant) {
+		t.Fatalf("expected ErrNotParticipant, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatRenameClosedChat(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(true)))
+	mock.ExpectRollback()
+
+	if _, err := d.ChatRename(context.Background(), 10, 1, "new"); !errors.Is(err, storage.ErrChatClosed) {
+		t.Fatalf("expected ErrChatClosed, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatNotFound(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	if _, err := d.ChatClose(context.Background(), 10, 1); !errors.Is(err, storage.ErrChatNotFound) {
+		t.Fatalf("expected ErrChatNotFound, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatCreateReturnsExistingDialog(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryDialogPair).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"chat_id"}).AddRow(20))
+	mock.ExpectQuery(queryChatLock).WithArgs(20).WillReturnRows(chatRow(dialogChat(false)))
+	mock.ExpectQuery(`SELECT \* from chat_participants where chat_id=\?`).WillReturnRows(participantRows(
+		model.ChatParticipant{ChatId: 20, UserId: 1, Role: model.ChatRoleOwner},
+		model.ChatParticipant{ChatId: 20, UserId: 2, Role: model.ChatRoleMember},
+	))
+	mock.ExpectCommit()
+
+	// Повторы собеседника и создателя в списке участников не превращают диалог в групповой чат
+	chat, err := d.ChatCreate(context.Background(), 2, "", 1, 1, 2)
+	if err != nil {
+		t.Fatalf("create dialog: %s", err)
+	}
+	if chat.Id != 20 || !chat.Dialog {
+		t.Fatalf("expected existing dialog 20, got %+v", chat)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatCreateReplacesClosedDialog(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryDialogPair).WithArgs(1, 2).WillReturnRows(sqlmock.NewRows([]string{"chat_id"}).AddRow(20))
+	mock.ExpectQuery(queryChatLock).WithArgs(20).WillReturnRows(chatRow(dialogChat(true)))
+	mock.ExpectExec(`delete from chat_dialogs where user_low=\? and user_high=\?`).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryBlocks).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectPrepare(`insert into chats`).ExpectExec().WillReturnResult(sqlmock.NewResult(21, 1))
+	created := dialogChat(false)
+	created.Id = 21
+	mock.ExpectQuery(`SELECT \* from chats where id=\?`).WithArgs(21).WillReturnRows(chatRow(created))
+	mock.ExpectQuery(`SELECT \* from chat_participants where chat_id=\?`).WillReturnRows(participantRows())
+	mock.ExpectQuery(queryBlocks).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`insert into chat_participants`).WithArgs(21, 1, model.ParticipantActive, model.ChatRoleMember).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`insert into chat_participants`).WithArgs(21, 2, model.ParticipantActive, model.ChatRoleMember).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(`update chat_participants set role`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`insert into chat_dialogs`).WithArgs(1, 2, 21).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	chat, err := d.ChatCreate(context.Background(), 1, "", 2)
+	if err != nil {
+		t.Fatalf("create dialog: %s", err)
+	}
+	if chat.Id != 21 {
+		t.Fatalf("expected new dialog 21, got %+v", chat)
+	}
+	checkMock(t, mock)
+}
+
+func TestDialogForbidsParticipantChanges(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(dialogChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(1, model.ChatRoleOwner)))
+	mock.ExpectRollback()
+
+	if err := d.ChatAddParticipants(context.Background(), 20, 1, []int64{3}); !errors.Is(err, storage.ErrDialogChat) {
+		t.Fatalf("expected ErrDialogChat, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatSetRoleRequiresOwner(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(2, model.ChatRoleAdmin)))
+	mock.ExpectRollback()
+
+	err := d.ChatSetRole(context.Background(), 10, 2, 3, model.ChatRoleAdmin)
+	if !errors.Is(err, storage.ErrAccessDenied) {
+		t.Fatalf("expected ErrAccessDenied, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatSetRoleInvalidRole(t *testing.T) {
+	d, mock := newMockDbc(t)
+	if err := d.ChatSetRole(context.Background(), 10, 1, 3, "superuser"); !errors.Is(err, storage.ErrInvalidChatRole) {
+		t.Fatalf("expected ErrInvalidChatRole, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatRemoveParticipantsSameRole(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WithArgs(10, 2, model.ParticipantActive).WillReturnRows(participantRows(member(2, model.ChatRoleAdmin)))
+	mock.ExpectQuery(queryChatParticipant).WithArgs(10, 3, model.ParticipantActive).WillReturnRows(participantRows(member(3, model.ChatRoleAdmin)))
+	mock.ExpectRollback()
+
+	// Администратор не может исключить другого администратора
+	err := d.ChatRemoveParticipants(context.Background(), 10, 2, []int64{3})
+	if !errors.Is(err, storage.ErrAccessDenied) {
+		t.Fatalf("expected ErrAccessDenied, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestChatAddParticipantsInviteRole(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatLock).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(3, model.ChatRoleMember)))
+	mock.ExpectRollback()
+
+	// Приглашать участников по настройкам чата могут только администраторы
+	err := d.ChatAddParticipants(context.Background(), 10, 3, []int64{4})
+	if !errors.Is(err, storage.ErrAccessDenied) {
+		t.Fatalf("expected ErrAccessDenied, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+const queryChatShared = `SELECT \* from chats where id=\? lock in share mode`
+
+func TestMessageSaveClosedChat(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatShared).WillReturnRows(chatRow(groupChat(true)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(2, model.ChatRoleMember)))
+	mock.ExpectRollback()
+
+	if _, err := d.MessageSave(context.Background(), 10, 2, time.Now(), "hi"); !errors.Is(err, storage.ErrChatClosed) {
+		t.Fatalf("expected ErrChatClosed, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestMessageSaveNotParticipant(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatShared).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows())
+	mock.ExpectRollback()
+
+	if _, err := d.MessageSave(context.Background(), 10, 5, time.Now(), "hi"); !errors.Is(err, storage.ErrAccessDenied) {
+		t.Fatalf("expected ErrAccessDenied, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestMessageSavePostRole(t *testing.T) {
+	d, mock := newMockDbc(t)
+	chat := groupChat(false)
+	chat.Post = model.ChatRoleAdmin
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatShared).WillReturnRows(chatRow(chat))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(3, model.ChatRoleMember)))
+	mock.ExpectRollback()
+
+	if _, err := d.MessageSave(context.Background(), 10, 3, time.Now(), "hi"); !errors.Is(err, storage.ErrAccessDenied) {
+		t.Fatalf("expected ErrAccessDenied, got %v", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestMessageSaveInTransaction(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatShared).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(2, model.ChatRoleMember)))
+	mock.ExpectExec(`insert into messages \(chat_id, user_from, send_at, message\)`).WillReturnResult(sqlmock.NewResult(100, 1))
+	mock.ExpectQuery(`SELECT \* from messages where id=\?`).WithArgs(100).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "chat_id", "user_from", "send_at", "message", "system"}).AddRow(100, 10, 2, time.Now(), "hi", false))
+	mock.ExpectCommit()
+
+	message, err := d.MessageSave(context.Background(), 10, 2, time.Now(), "hi")
+	if err != nil {
+		t.Fatalf("save message: %s", err)
+	}
+	if message.Id != 100 {
+		t.Fatalf("unexpected message %+v", message)
+	}
+	checkMock(t, mock)
+}
+
+const queryDialogUsers = `SELECT user_low, user_high from chat_dialogs where chat_id=\?`
+
+func TestMessageSaveGroupOfTwoIgnoresBlocks(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatShared).WillReturnRows(chatRow(groupChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(2, model.ChatRoleMember)))
+	// Блокировка проверяется только в диалогах, а не по числу участников
+	mock.ExpectExec(`insert into messages \(chat_id, user_from, send_at, message\)`).WillReturnResult(sqlmock.NewResult(100, 1))
+	mock.ExpectQuery(`SELECT \* from messages where id=\?`).WithArgs(100).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "chat_id", "user_from", "send_at", "message", "system"}).AddRow(100, 10, 2, time.Now(), "hi", false))
+	mock.ExpectCommit()
+
+	if _, err := d.MessageSave(context.Background(), 10, 2, time.Now(), "hi"); err != nil {
+		t.Fatalf("save message: %s", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestMessageSaveDialogBlocked(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryChatShared).WillReturnRows(chatRow(dialogChat(false)))
+	mock.ExpectQuery(queryChatParticipant).WillReturnRows(participantRows(member(2, model.ChatRoleMember)))
+	mock.ExpectQuery(queryDialogUsers).WithArgs(20).WillReturnRows(sqlmock.NewRows([]string{"user_low", "user_high"}).AddRow(1, 2))
+	mock.ExpectQuery(queryBlocks).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	if _, err := d.MessageSave(context.Background(), 20, 2, time.Now(), "hi"); !errors.Is(err, storage.ErrBlocked) {
+		t.Fatalf("expected ErrBlocked, got %v", err)
+	}
+	checkMock(t, mock)
+}
@@ -0,0 +1,135 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"strings"
+)
+
+// AddComment Добавить комментарий к публикации. Комментарий и счетчик публикации сохраняются в одной транзакции
+func (d *dbc) AddComment(ctx context.Context, postId, userId int64, message string) (model.Comment, error) {
+	var result model.Comment
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.addComment(ctx, postId, userId, message)
+		return err
+	})
+	return result, err
+}
+
+func (d *dbc) addComment(ctx context.Context, postId, userId int64, message string) (model.Comment, error) {
+	post, err := d.GetPostById(ctx, postId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Comment{}, storage.ErrPostNotFound
+		}
+		return model.Comment{}, err
+	}
+	if post.Deleted {
+		return model.Comment{}, storage.ErrPostNotFound
+	}
+
+	result, err := d.conn(ctx).ExecContext(ctx, "insert into post_comments (post_id, user_id, message) values (?, ?, ?);",
+		postId, userId, message)
+	if err != nil {
+		return model.Comment{}, err
+	}
+	commentId, err := result.LastInsertId()
+	if err != nil {
+		return model.Comment{}, err
+	}
+
+	// Обновляем счетчик комментариев у публикации
+	_, err = d.conn(ctx).ExecContext(ctx, "update posts set comments_count = comments_count + 1 where id = ?;", postId)
+	if err != nil {
+		return model.Comment{}, err
+	}
+	d.written(postKey(postId))
+
+	return d.GetCommentById(ctx, commentId)
+}
+
+// GetComments Получить список комментариев к публикации
+func (d *dbc) GetComments(ctx context.Context, postId int64, limit, offset int64) ([]model.Comment, error) {
+	var comments []model.Comment
+	var sb strings.Builder
+	var args []interface{}
+
+	sb.WriteString("SELECT * from post_comments WHERE post_id=? and deleted=false order by created_at, id ")
+	args = append(args, postId)
+
+	if limit > 0 {
+		sb.WriteString(" LIMIT ? ")
+		args = append(args, limit)
+		if offset > 0 {
+			sb.WriteString(" OFFSET ? ")
+			args = append(args, offset)
+		}
+	}
+	connection := d.reader(ctx, postKey(postId))
+	err := connection.SelectContext(ctx, &comments, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetCommentById Получить комментарий по его Id
+func (d *dbc) GetCommentById(ctx context.Context, commentId int64) (model.Comment, error) {
+	var comment model.Comment
+	err := d.conn(ctx).GetContext(ctx, &comment, "SELECT * from post_comments where id=? and deleted=false", commentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Comment{}, storage.ErrCommentNotFound
+		}
+		return model.Comment{}, err
+	}
+	return comment, nil
+}
+
+// DelComment Удалить комментарий (автором комментария или автором публикации)
+func (d *dbc) DelComment(ctx context.Context, commentId, userId int64) (bool, error) {
+	var deleted bool
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		deleted, err = d.delComment(ctx, commentId, userId)
+		return err
+	})
+	return deleted, err
+}
+
+func (d *dbc) delComment(ctx context.Context, commentId, userId int64) (bool, error) {
+	comment, err := d.GetCommentById(ctx, commentId)
+	if err != nil {
+		return false, err
+	}
+	if comment.UserId != userId {
+		post, err := d.GetPostById(ctx, comment.PostId)
+		if err != nil {
+			return false, err
+		}
+		if post.UserId != userId {
+			return false, storage.ErrAccessDenied
+		}
+	}
+
+	result, err := d.conn(ctx).ExecContext(ctx, "update post_comments set deleted = true where id = ? and deleted = false;", commentId)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	_, err = d.conn(ctx).ExecContext(ctx, "update posts set comments_count = comments_count - 1 where id = ? and comments_count > 0;", comment.PostId)
+	if err != nil {
+		return false, err
+	}
+	d.written(postKey(comment.PostId))
+	return true, nil
+}
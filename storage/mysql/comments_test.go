@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"testing"
+)
+
+const (
+	queryPostById       = `SELECT \* from posts where id=\?`
+	queryReactionCounts = `FROM reaction_counts`
+	queryCommentInsert  = `insert into post_comments`
+	queryCommentsCount  = `update posts set comments_count = comments_count \+ 1`
+)
+
+func TestAddCommentRollbackOnCountError(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryPostById).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "deleted"}).AddRow(1, 2, false))
+	mock.ExpectQuery(queryReactionCounts).WillReturnRows(sqlmock.NewRows([]string{"item_id", "reaction", "count"}))
+	mock.ExpectExec(queryCommentInsert).WithArgs(1, 3, "hello").WillReturnResult(sqlmock.NewResult(10, 1))
+	failure := errors.New("lock wait timeout")
+	mock.ExpectExec(queryCommentsCount).WithArgs(1).WillReturnError(failure)
+	// Комментарий без счетчика не сохраняется
+	mock.ExpectRollback()
+
+	if _, err := d.AddComment(context.Background(), 1, 3, "hello"); !errors.Is(err, failure) {
+		t.Fatalf("expected count error, got %v", err)
+	}
+	checkMock(t, mock)
+}
@@ -1,9 +1,24 @@
 package mysql
 
+import "time"
+
 type Config struct {
+	// Кандидаты в мастера через запятую, используется первый доступный для записи
 	DSN                  string `env:"DB_DSN,default=root:pass@tcp(localhost:3306)/project"`
 	MaxOpenConnections   int    `env:"DB_MAX_OPEN_CONNECTIONS,default=5"`
 	DSNro                string `env:"DB_DSN_RO"`
 	MaxOpenConnectionsRo int    `env:"DB_RO_MAX_OPEN_CONNECTIONS,default=5"`
 	RoDisable            bool   `env:"DB_RO_DISABLE,default=false"`
+	// Выбор реплики: round-robin или least-connections
+	RoBalance      string        `env:"DB_RO_BALANCE,default=round-robin"`
+	RoCheckPeriod  time.Duration `env:"DB_RO_CHECK_PERIOD,default=5s"`
+	RoMaxLag       time.Duration `env:"DB_RO_MAX_LAG,default=10s"`
+	RoStickyWindow time.Duration `env:"DB_RO_STICKY_WINDOW,default=10s"`
+	// Применение миграций при старте сервиса. При нескольких экземплярах лучше отключить и запускать cmd/migrate
+	AutoMigrate bool `env:"DB_AUTO_MIGRATE,default=true"`
+	// Периодичность проверки кандидатов в мастера
+	PrimaryCheckPeriod time.Duration `env:"DB_PRIMARY_CHECK_PERIOD,default=2s"`
+	// Таймаут подключения к кандидату в мастера и таймаут чтения ответа сервера
+	DialTimeout time.Duration `env:"DB_DIAL_TIMEOUT,default=2s"`
+	ReadTimeout time.Duration `env:"DB_READ_TIMEOUT,default=30s"`
 }
@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"context"
+)
+
+// reconcileQueries Запросы пересчета денормализованных счетчиков по исходным таблицам
+var reconcileQueries = []string{
+	// Счетчики комментариев публикаций
+	"UPDATE posts p LEFT JOIN " +
+		"(SELECT post_id, count(*) AS cnt FROM post_comments WHERE deleted = false GROUP BY post_id) c ON c.post_id = p.id " +
+		"SET p.comments_count = COALESCE(c.cnt, 0) WHERE p.comments_count <> COALESCE(c.cnt, 0);",
+	// Реакции на сообщения, которых больше нет (например, удаленных вместе с чатом)
+	"DELETE r FROM reactions r LEFT JOIN messages m ON m.id = r.item_id " +
+		"WHERE r.item_type = 'message' AND m.id IS NULL;",
+	"DELETE rc FROM reaction_counts rc LEFT JOIN messages m ON m.id = rc.item_id " +
+		"WHERE rc.item_type = 'message' AND m.id IS NULL;",
+	// Счетчики реакций на публикации и сообщения, по которым не осталось реакций
+	"UPDATE reaction_counts rc LEFT JOIN " +
+		"(SELECT item_type, item_id, reaction, count(*) AS cnt FROM reactions GROUP BY item_type, item_id, reaction) r " +
+		"ON r.item_type = rc.item_type AND r.item_id = rc.item_id AND r.reaction = rc.reaction " +
+		"SET rc.count = 0 WHERE r.cnt IS NULL AND rc.count <> 0;",
+	// Счетчики реакций по фактическим реакциям
+	"UPDATE reaction_counts rc JOIN " +
+		"(SELECT item_type, item_id, reaction, count(*) AS cnt FROM reactions GROUP BY item_type, item_id, reaction) r " +
+		"ON r.item_type = rc.item_type AND r.item_id = rc.item_id AND r.reaction = rc.reaction " +
+		"SET rc.count = r.cnt WHERE rc.count <> r.cnt;",
+	// Отсутствующие счетчики реакций
+	"INSERT INTO reaction_counts (item_type, item_id, reaction, count) " +
+		"SELECT r.item_type, r.item_id, r.reaction, count(*) FROM reactions r " +
+		"LEFT JOIN reaction_counts rc ON rc.item_type = r.item_type AND rc.item_id = r.item_id AND rc.reaction = r.reaction " +
+		"WHERE rc.item_id IS NULL GROUP BY r.item_type, r.item_id, r.reaction;",
+	// Счетчики подписок пользователей
+	"UPDATE users u LEFT JOIN (SELECT user_id, count(*) AS cnt FROM user_friend GROUP BY user_id) f ON f.user_id = u.user_id " +
+		"SET u.following_count = COALESCE(f.cnt, 0) WHERE u.following_count <> COALESCE(f.cnt, 0);",
+	// Счетчики подписчиков пользователей
+	"UPDATE users u LEFT JOIN (SELECT friend_id, count(*) AS cnt FROM user_friend GROUP BY friend_id) f ON f.friend_id = u.user_id " +
+		"SET u.followers_count = COALESCE(f.cnt, 0) WHERE u.followers_count <> COALESCE(f.cnt, 0);",
+	// Счетчики друзей (взаимных подписок) пользователей
+	"UPDATE users u LEFT JOIN (SELECT f.user_id, count(*) AS cnt FROM user_friend f " +
+		"JOIN user_friend r ON r.user_id = f.friend_id AND r.friend_id = f.user_id GROUP BY f.user_id) f ON f.user_id = u.user_id " +
+		"SET u.friends_count = COALESCE(f.cnt, 0) WHERE u.friends_count <> COALESCE(f.cnt, 0);",
+}
+
+// ReconcileCounters Пересчитать денормализованные счетчики (комментарии, реакции на публикации и сообщения, подписки), возвращает количество исправленных
+func (d *dbc) ReconcileCounters(ctx context.Context) (int64, error) {
+	var fixed int64
+	for _, query := range reconcileQueries {
+		result, err := d.conn(ctx).ExecContext(ctx, query)
+		if err != nil {
+			return fixed, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fixed, err
+		}
+		fixed += affected
+	}
+	return fixed, nil
+}
@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestReconcileCountersCoversMessageReactions(t *testing.T) {
+	messageQueries := 0
+	for _, query := range reconcileQueries {
+		if strings.Contains(query, "item_type = 'message'") {
+			messageQueries++
+		}
+	}
+	if messageQueries != 2 {
+		t.Fatalf("expected cleanup of reactions and counts of missing messages, got %d queries", messageQueries)
+	}
+
+	d, mock := newMockDbc(t)
+	for _, query := range reconcileQueries {
+		mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	fixed, err := d.ReconcileCounters(context.Background())
+	if err != nil {
+		t.Fatalf("reconcile: %s", err)
+	}
+	if fixed != int64(len(reconcileQueries)) {
+		t.Fatalf("expected %d fixed, got %d", len(reconcileQueries), fixed)
+	}
+	checkMock(t, mock)
+}
@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"strings"
+	"time"
+)
+
+// SaveDraft Сохранить новый черновик (запланированную публикацию, если задан publishAt)
+func (d *dbc) SaveDraft(ctx context.Context, userId int64, draft model.DraftPojo) (model.Draft, error) {
+	result, err := d.conn(ctx).ExecContext(ctx,
+		"insert into post_drafts (user_id, title, message, visibility, status, publish_at) values (?, ?, ?, ?, ?, ?);",
+		userId, draft.Title, draft.Message, draftVisibility(draft), draftStatus(draft), draft.PublishAt)
+	if err != nil {
+		return model.Draft{}, err
+	}
+	draftId, err := result.LastInsertId()
+	if err != nil {
+		return model.Draft{}, err
+	}
+	return d.GetDraftById(ctx, draftId, userId)
+}
+
+// UpdateDraft Изменить черновик пользователя
+func (d *dbc) UpdateDraft(ctx context.Context, draftId, userId int64, draft model.DraftPojo) (model.Draft, error) {
+	current, err := d.GetDraftById(ctx, draftId, userId)
+	if err != nil {
+		return model.Draft{}, err
+	}
+	if !current.Editable() {
+		return model.Draft{}, storage.ErrDraftNotEditable
+	}
+
+	result, err := d.conn(ctx).ExecContext(ctx,
+		"update post_drafts set title=?, message=?, visibility=?, status=?, publish_at=? where id=? and user_id=? and status in (?, ?);",
+		draft.Title, draft.Message, draftVisibility(draft), draftStatus(draft), draft.PublishAt, draftId, userId,
+		model.DraftStatusDraft, model.DraftStatusScheduled)
+	if err != nil {
+		return model.Draft{}, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return model.Draft{}, err
+	}
+	if affected == 0 {
+		// Черновик успели опубликовать или отменить
+		current, err = d.GetDraftById(ctx, draftId, userId)
+		if err != nil {
+			return model.Draft{}, err
+		}
+		if !current.Editable() {
+			return model.Draft{}, storage.ErrDraftNotEditable
+		}
+	}
+	return d.GetDraftById(ctx, draftId, userId)
+}
+
+// GetDraftById Получить черновик пользователя
+func (d *dbc) GetDraftById(ctx context.Context, draftId, userId int64) (model.Draft, error) {
+	var draft model.Draft
+	err := d.conn(ctx).GetContext(ctx, &draft, "SELECT * from post_drafts where id=? and user_id=?", draftId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Draft{}, storage.ErrDraftNotFound
+		}
+		return model.Draft{}, err
+	}
+	return draft, nil
+}
+
+// GetDrafts Получить черновики пользователя (опционально по статусу)
+func (d *dbc) GetDrafts(ctx context.Context, userId int64, status string, limit, offset int64) ([]model.Draft, error) {
+	var drafts []model.Draft
+	var sb strings.Builder
+	var args []interface{}
+
+	sb.WriteString("SELECT * from post_drafts WHERE user_id=? ")
+	args = append(args, userId)
+	if status != "" {
+		sb.WriteString(" AND status=? ")
+		args = append(args, status)
+	}
+	sb.WriteString(" ORDER BY created_at DESC, id DESC ")
+
+	if limit > 0 {
+		sb.WriteString(" LIMIT ? ")
+		args = append(args, limit)
+		if offset > 0 {
+			sb.WriteString(" OFFSET ? ")
+			args = append(args, offset)
+		}
+	}
+	err := d.conn(ctx).SelectContext(ctx, &drafts, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+// CancelDraft Отменить черновик или запланированную публикацию
+func (d *dbc) CancelDraft(ctx context.Context, draftId, userId int64) (bool, error) {
+	current, err := d.GetDraftById(ctx, draftId, userId)
+	if err != nil {
+		return false, err
+	}
+	if !current.Editable() {
+		return false, storage.ErrDraftNotEditable
+	}
+	result, err := d.conn(ctx).ExecContext(ctx,
+		"update post_drafts set status=? where id=? and user_id=? and status in (?, ?);",
+		model.DraftStatusCancelled, draftId, userId, model.DraftStatusDraft, model.DraftStatusScheduled)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, storage.ErrDraftNotEditable
+	}
+	return true, nil
+}
+
+// GetDueDrafts Получить запланированные публикации, время которых наступило
+func (d *dbc) GetDueDrafts(ctx context.Context, now time.Time, limit int64) ([]model.Draft, error) {
+	var drafts []model.Draft
+	err := d.conn(ctx).SelectContext(ctx, &drafts,
+		"SELECT * from post_drafts WHERE status=? and publish_at <= ? ORDER BY publish_at, id LIMIT ?",
+		model.DraftStatusScheduled, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+// ClaimDraft Захватить черновик для публикации (переводит в статус published), false - уже захвачен или отменен
+func (d *dbc) ClaimDraft(ctx context.Context, draftId int64) (bool, error) {
+	result, err := d.conn(ctx).ExecContext(ctx,
+		"update post_drafts set status=? where id=? and status in (?, ?);",
+		model.DraftStatusPublished, draftId, model.DraftStatusDraft, model.DraftStatusScheduled)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// SetDraftPost Сохранить id публикации, созданной из черновика
+func (d *dbc) SetDraftPost(ctx context.Context, draftId, postId int64) error {
+	_, err := d.conn(ctx).ExecContext(ctx, "update post_drafts set post_id=? where id=?;", postId, draftId)
+	return err
+}
+
+func draftVisibility(draft model.DraftPojo) string {
+	if draft.Visibility == "" {
+		return model.PostVisibilityPublic
+	}
+	return draft.Visibility
+}
+
+func draftStatus(draft model.DraftPojo) string {
+	if draft.PublishAt != nil {
+		return model.DraftStatusScheduled
+	}
+	return model.DraftStatusDraft
+}
@@ -0,0 +1,115 @@
+package mysql
+
+import (
+	"context"
+	"github.com/basicus/hla-course/model"
+	"strings"
+)
+
+// mutualFriendsFrom Общие друзья пользователей: a - друзья первого пользователя, b - второго
+const mutualFriendsFrom = "FROM user_friend a " +
+	"JOIN user_friend ar ON ar.user_id = a.friend_id AND ar.friend_id = a.user_id " +
+	"JOIN user_friend b ON b.friend_id = a.friend_id AND b.user_id = ? " +
+	"JOIN user_friend br ON br.user_id = b.friend_id AND br.friend_id = b.user_id " +
+	"JOIN users u ON u.user_id = a.friend_id " +
+	"WHERE a.user_id = ? "
+
+// GetFollowers Получить страницу подписчиков пользователя
+func (d *dbc) GetFollowers(ctx context.Context, userId int64, limit, offset int64) ([]model.User, error) {
+	return d.selectUsers(ctx, d.reader(ctx, userKey(userId)), "SELECT u.* FROM user_friend f JOIN users u ON u.user_id = f.user_id "+
+		"WHERE f.friend_id = ? ORDER BY f.user_id ", limit, offset, userId)
+}
+
+// GetFollowing Получить страницу пользователей, на которых подписан пользователь
+func (d *dbc) GetFollowing(ctx context.Context, userId int64, limit, offset int64) ([]model.User, error) {
+	return d.selectUsers(ctx, d.reader(ctx, userKey(userId)), "SELECT u.* FROM user_friend f JOIN users u ON u.user_id = f.friend_id "+
+		"WHERE f.user_id = ? ORDER BY f.friend_id ", limit, offset, userId)
+}
+
+// GetMutualFriends Получить страницу общих друзей пользователей
+func (d *dbc) GetMutualFriends(ctx context.Context, userId, otherId int64, limit, offset int64) ([]model.User, error) {
+	return d.selectUsers(ctx, d.reader(ctx, userKeys(userId, otherId)...), "SELECT u.* "+mutualFriendsFrom+"ORDER BY u.user_id ", limit, offset, otherId, userId)
+}
+
+// CountMutualFriends Количество общих друзей пользователей
+func (d *dbc) CountMutualFriends(ctx context.Context, userId, otherId int64) (int64, error) {
+	var count int64
+	connection := d.reader(ctx, userKeys(userId, otherId)...)
+	err := connection.GetContext(ctx, &count, "SELECT count(*) "+mutualFriendsFrom, otherId, userId)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// selectUsers Выборка страницы пользователей запросом query
+func (d *dbc) selectUsers(ctx context.Context, connection executor, query string, limit, offset int64, args ...interface{}) ([]model.User, error) {
+	var users []model.User
+	var sb strings.Builder
+	sb.WriteString(query)
+	if limit > 0 {
+		sb.WriteString(" LIMIT ? ")
+		args = append(args, limit)
+		if offset > 0 {
+			sb.WriteString(" OFFSET ? ")
+			args = append(args, offset)
+		}
+	}
+	err := connection.SelectContext(ctx, &users, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// follow Подписка пользователя user на friend и изменение счетчиков в той же транзакции.
+// Возвращает false, если подписка уже была
+func (d *dbc) follow(ctx context.Context, user, friend int64) (bool, error) {
+	result, err := d.conn(ctx).ExecContext(ctx, "insert ignore into user_friend (user_id, friend_id) values (?, ?);", user, friend)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+	return true, d.changeFollowCounts(ctx, user, friend, 1)
+}
+
+// unfollow Отписка пользователя user от friend и изменение счетчиков в той же транзакции.
+// Возвращает false, если подписки не было
+func (d *dbc) unfollow(ctx context.Context, user, friend int64) (bool, error) {
+	result, err := d.conn(ctx).ExecContext(ctx, "delete from user_friend where user_id = ? and friend_id = ?;", user, friend)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, err
+	}
+	return true, d.changeFollowCounts(ctx, user, friend, -1)
+}
+
+// changeFollowCounts Изменение счетчиков на delta после добавления или удаления подписки user на friend.
+// Должен вызываться в транзакции изменения подписки, полный пересчет выполняет ReconcileCounters
+func (d *dbc) changeFollowCounts(ctx context.Context, user, friend int64, delta int64) error {
+	d.written(userKeys(user, friend)...)
+	// При встречной подписке пользователи становятся (перестают быть) друзьями
+	var mutual int
+	err := d.conn(ctx).GetContext(ctx, &mutual,
+		"SELECT count(*) from user_friend where user_id = ? and friend_id = ? lock in share mode", friend, user)
+	if err != nil {
+		return err
+	}
+	var friendsDelta int64
+	if mutual > 0 {
+		friendsDelta = delta
+	}
+	// Оба пользователя обновляются одним запросом, чтобы строки блокировались в одном порядке
+	_, err = d.conn(ctx).ExecContext(ctx, "update users set "+
+		"following_count = greatest(following_count + if(user_id = ?, ?, 0), 0), "+
+		"followers_count = greatest(followers_count + if(user_id = ?, ?, 0), 0), "+
+		"friends_count = greatest(friends_count + ?, 0) "+
+		"where user_id in (?, ?);", user, delta, friend, delta, friendsDelta, user, friend)
+	return err
+}
@@ -0,0 +1,76 @@
+package mysql
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"testing"
+)
+
+const (
+	queryFollowInsert = `insert ignore into user_friend`
+	queryFollowDelete = `delete from user_friend where user_id = \? and friend_id = \?`
+	queryFollowMutual = `SELECT count\(\*\) from user_friend where user_id = \? and friend_id = \? lock in share mode`
+	queryFollowCounts = `update users set following_count`
+)
+
+func mutualRows(count int) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"count"}).AddRow(count)
+}
+
+func TestAddFriendMutualCountsInTx(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryBlocks).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(queryFollowInsert).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryFollowMutual).WithArgs(2, 1).WillReturnRows(mutualRows(1))
+	mock.ExpectExec(queryFollowCounts).WithArgs(1, 1, 2, 1, 1, 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	added, err := d.AddFriend(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("add friend: %s", err)
+	}
+	if !added {
+		t.Fatal("expected new subscription")
+	}
+	checkMock(t, mock)
+}
+
+func TestAddFriendExistingKeepsCounts(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryBlocks).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(queryFollowInsert).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	added, err := d.AddFriend(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("add friend: %s", err)
+	}
+	if added {
+		t.Fatal("expected existing subscription")
+	}
+	checkMock(t, mock)
+}
+
+func TestRemoveFriendDecrementsOnce(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(queryFollowDelete).WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryFollowMutual).WithArgs(2, 1).WillReturnRows(mutualRows(1))
+	mock.ExpectExec(queryFollowCounts).WithArgs(1, -1, 2, -1, -1, 1, 2).WillReturnResult(sqlmock.NewResult(0, 2))
+	// Встречная подписка удаляется уже без изменения числа друзей
+	mock.ExpectExec(queryFollowDelete).WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryFollowMutual).WithArgs(1, 2).WillReturnRows(mutualRows(0))
+	mock.ExpectExec(queryFollowCounts).WithArgs(2, -1, 1, -1, 0, 2, 1).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	removed, err := d.RemoveFriend(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("remove friend: %s", err)
+	}
+	if !removed {
+		t.Fatal("expected removed friendship")
+	}
+	checkMock(t, mock)
+}
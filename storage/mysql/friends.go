@@ -0,0 +1,279 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+// GetFriendIds Получение id друзей (взаимных подписок) пользователя
+func (d *dbc) GetFriendIds(ctx context.Context, id int64) ([]int64, error) {
+	var friendsId []int64
+	connection := d.reader(ctx, userKey(id))
+	err := connection.SelectContext(ctx, &friendsId,
+		"SELECT uf.friend_id from user_friend uf "+
+			"JOIN user_friend r ON r.user_id = uf.friend_id AND r.friend_id = uf.user_id "+
+			"WHERE uf.user_id=?", id)
+	if err != nil {
+		return nil, err
+	}
+	return friendsId, nil
+}
+
+// AreFriends Проверка, что пользователи являются друзьями
+func (d *dbc) AreFriends(ctx context.Context, userId, otherId int64) (bool, error) {
+	var count int
+	err := d.conn(ctx).GetContext(ctx, &count,
+		"SELECT count(*) from user_friend where (user_id=? and friend_id=?) or (user_id=? and friend_id=?)",
+		userId, otherId, otherId, userId)
+	if err != nil {
+		return false, err
+	}
+	return count == 2, nil
+}
+
+// RemoveFriend Удалить пользователя из друзей (удаляет подписки в обе стороны)
+func (d *dbc) RemoveFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	var result bool
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.removeFriend(ctx, user, friend)
+		return err
+	})
+	return result, err
+}
+
+func (d *dbc) removeFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	removed, err := d.unfollow(ctx, user, friend)
+	if err != nil {
+		return false, err
+	}
+	reverse, err := d.unfollow(ctx, friend, user)
+	if err != nil {
+		return false, err
+	}
+	return removed || reverse, nil
+}
+
+// SendFriendRequest Отправить заявку в друзья. Встречная заявка принимается автоматически
+func (d *dbc) SendFriendRequest(ctx context.Context, from, to int64) (model.FriendRequest, error) {
+	var result model.FriendRequest
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.sendFriendRequest(ctx, from, to)
+		return err
+	})
+	return result, err
+}
+
+func (d *dbc) sendFriendRequest(ctx context.Context, from, to int64) (model.FriendRequest, error) {
+	if from == to {
+		return model.FriendRequest{}, storage.ErrInvalidFriendRequest
+	}
+	// Блокировка пары первой в транзакции: встречные заявки и блокировка пользователей выполняются по очереди,
+	// а следующие чтения видят их результат
+	found, err := d.lockUsers(ctx, from, to)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	if !found[to] {
+		return model.FriendRequest{}, storage.ErrInvalidFriendRequest
+	}
+	requests, err := d.lockFriendRequestPair(ctx, from, to)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	blocked, err := d.IsBlocked(ctx, from, to)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	if blocked {
+		return model.FriendRequest{}, storage.ErrBlocked
+	}
+	friends, err := d.AreFriends(ctx, from, to)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	if friends {
+		return model.FriendRequest{}, storage.ErrAlreadyFriends
+	}
+
+	// Встречная заявка: пользователи хотят дружить друг с другом
+	var pending bool
+	for _, request := range requests {
+		if request.Status != model.FriendRequestPending {
+			continue
+		}
+		if request.FromUserId == to {
+			return d.acceptFriendRequest(ctx, request.Id, from)
+		}
+		pending = true
+	}
+	if pending {
+		return model.FriendRequest{}, storage.ErrFriendRequestExists
+	}
+
+	// Повторная заявка после отказа или отзыва переиспользует запись пары
+	_, err = d.conn(ctx).ExecContext(ctx,
+		"insert into friend_requests (from_user_id, to_user_id, status) values (?, ?, ?) "+
+			"on duplicate key update status = values(status), created_at = now();",
+		from, to, model.FriendRequestPending)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	return d.getFriendRequestByPair(ctx, from, to)
+}
+
+// GetFriendRequests Получить входящие (incoming) или исходящие заявки пользователя (опционально по статусу)
+func (d *dbc) GetFriendRequests(ctx context.Context, userId int64, incoming bool, status string, limit, offset int64) ([]model.FriendRequest, error) {
+	var requests []model.FriendRequest
+	var sb strings.Builder
+	var args []interface{}
+
+	if incoming {
+		sb.WriteString("SELECT * from friend_requests WHERE to_user_id=? ")
+	} else {
+		sb.WriteString("SELECT * from friend_requests WHERE from_user_id=? ")
+	}
+	args = append(args, userId)
+	if status != "" {
+		sb.WriteString(" AND status=? ")
+		args = append(args, status)
+	}
+	sb.WriteString(" ORDER BY created_at DESC, id DESC ")
+
+	if limit > 0 {
+		sb.WriteString(" LIMIT ? ")
+		args = append(args, limit)
+		if offset > 0 {
+			sb.WriteString(" OFFSET ? ")
+			args = append(args, offset)
+		}
+	}
+	err := d.conn(ctx).SelectContext(ctx, &requests, sb.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// AcceptFriendRequest Принять входящую заявку, пользователи становятся друзьями
+func (d *dbc) AcceptFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error) {
+	var result model.FriendRequest
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.acceptFriendRequest(ctx, requestId, userId)
+		return err
+	})
+	return result, err
+}
+
+func (d *dbc) acceptFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error) {
+	request, err := d.answerFriendRequest(ctx, requestId, userId, true, model.FriendRequestAccepted)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+
+	// Дружба - взаимная подписка
+	if _, err = d.follow(ctx, request.FromUserId, request.ToUserId); err != nil {
+		return model.FriendRequest{}, err
+	}
+	if _, err = d.follow(ctx, request.ToUserId, request.FromUserId); err != nil {
+		return model.FriendRequest{}, err
+	}
+	return request, nil
+}
+
+// DeclineFriendRequest Отклонить входящую заявку
+func (d *dbc) DeclineFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error) {
+	return d.answerFriendRequest(ctx, requestId, userId, true, model.FriendRequestDeclined)
+}
+
+// CancelFriendRequest Отозвать исходящую заявку
+func (d *dbc) CancelFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error) {
+	return d.answerFriendRequest(ctx, requestId, userId, false, model.FriendRequestCancelled)
+}
+
+// answerFriendRequest Переводит ожидающую заявку в статус status. Заявку может изменить
+// получатель (recipient) или отправитель
+func (d *dbc) answerFriendRequest(ctx context.Context, requestId, userId int64, recipient bool, status string) (model.FriendRequest, error) {
+	request, err := d.getFriendRequest(ctx, requestId)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	if (recipient && request.ToUserId != userId) || (!recipient && request.FromUserId != userId) {
+		return model.FriendRequest{}, storage.ErrFriendRequestNotFound
+	}
+	if request.Status != model.FriendRequestPending {
+		return model.FriendRequest{}, storage.ErrFriendRequestClosed
+	}
+
+	result, err := d.conn(ctx).ExecContext(ctx, "update friend_requests set status=? where id=? and status=?;",
+		status, requestId, model.FriendRequestPending)
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return model.FriendRequest{}, err
+	}
+	if affected == 0 {
+		return model.FriendRequest{}, storage.ErrFriendRequestClosed
+	}
+	request.Status = status
+	return request, nil
+}
+
+func (d *dbc) getFriendRequest(ctx context.Context, requestId int64) (model.FriendRequest, error) {
+	var request model.FriendRequest
+	err := d.conn(ctx).GetContext(ctx, &request, "SELECT * from friend_requests where id=?", requestId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.FriendRequest{}, storage.ErrFriendRequestNotFound
+		}
+		return model.FriendRequest{}, err
+	}
+	return request, nil
+}
+
+func (d *dbc) getFriendRequestByPair(ctx context.Context, from, to int64) (model.FriendRequest, error) {
+	var request model.FriendRequest
+	err := d.conn(ctx).GetContext(ctx, &request, "SELECT * from friend_requests where from_user_id=? and to_user_id=?", from, to)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.FriendRequest{}, storage.ErrFriendRequestNotFound
+		}
+		return model.FriendRequest{}, err
+	}
+	return request, nil
+}
+
+// lockFriendRequestPair Заявки пары пользователей в обе стороны с блокировкой строк до конца транзакции
+func (d *dbc) lockFriendRequestPair(ctx context.Context, userId, otherId int64) ([]model.FriendRequest, error) {
+	var requests []model.FriendRequest
+	err := d.conn(ctx).SelectContext(ctx, &requests,
+		"SELECT * from friend_requests where (from_user_id=? and to_user_id=?) or (from_user_id=? and to_user_id=?) for update",
+		userId, otherId, otherId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// lockUsers Блокировка строк пользователей до конца транзакции, возвращает найденных пользователей
+func (d *dbc) lockUsers(ctx context.Context, ids ...int64) (map[int64]bool, error) {
+	query, args, err := sqlx.In("SELECT user_id from users where user_id IN (?) order by user_id for update", ids)
+	if err != nil {
+		return nil, err
+	}
+	var locked []int64
+	if err = d.conn(ctx).SelectContext(ctx, &locked, d.conn(ctx).Rebind(query), args...); err != nil {
+		return nil, err
+	}
+	found := make(map[int64]bool, len(locked))
+	for _, id := range locked {
+		found[id] = true
+	}
+	return found, nil
+}
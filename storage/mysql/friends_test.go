@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"testing"
+	"time"
+)
+
+const (
+	queryRequestPair   = `SELECT \* from friend_requests where \(from_user_id=\? and to_user_id=\?\) or \(from_user_id=\? and to_user_id=\?\) for update`
+	queryRequestById   = `SELECT \* from friend_requests where id=\?`
+	queryRequestAnswer = `update friend_requests set status=\? where id=\? and status=\?`
+	queryRequestInsert = `insert into friend_requests .* on duplicate key update`
+	queryAreFriends    = `SELECT count\(\*\) from user_friend where \(user_id=\? and friend_id=\?\)`
+	requestFrom        = 1
+	requestTo          = 2
+)
+
+func requestRows(requests ...model.FriendRequest) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "from_user_id", "to_user_id", "status", "created_at", "updated_at"})
+	for _, r := range requests {
+		rows.AddRow(r.Id, r.FromUserId, r.ToUserId, r.Status, time.Now(), nil)
+	}
+	return rows
+}
+
+// expectRequestStart Блокировка пары и проверки до изменения заявок
+func expectRequestStart(mock sqlmock.Sqlmock, requests ...model.FriendRequest) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryLockUsers).WithArgs(requestFrom, requestTo).WillReturnRows(lockedRows(requestFrom, requestTo))
+	mock.ExpectQuery(queryRequestPair).WithArgs(requestFrom, requestTo, requestTo, requestFrom).
+		WillReturnRows(requestRows(requests...))
+	mock.ExpectQuery(queryBlocks).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(queryAreFriends).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+}
+
+func TestSendFriendRequestAcceptsCrossingInTx(t *testing.T) {
+	d, mock := newMockDbc(t)
+	reverse := model.FriendRequest{Id: 7, FromUserId: requestTo, ToUserId: requestFrom, Status: model.FriendRequestPending}
+	expectRequestStart(mock, reverse)
+	mock.ExpectQuery(queryRequestById).WithArgs(reverse.Id).WillReturnRows(requestRows(reverse))
+	mock.ExpectExec(queryRequestAnswer).WithArgs(model.FriendRequestAccepted, reverse.Id, model.FriendRequestPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(queryFollowInsert).WithArgs(requestTo, requestFrom).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryFollowMutual).WillReturnRows(mutualRows(0))
+	mock.ExpectExec(queryFollowCounts).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(queryFollowInsert).WithArgs(requestFrom, requestTo).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryFollowMutual).WillReturnRows(mutualRows(1))
+	mock.ExpectExec(queryFollowCounts).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	request, err := d.SendFriendRequest(context.Background(), requestFrom, requestTo)
+	if err != nil {
+		t.Fatalf("send: %s", err)
+	}
+	if request.Id != reverse.Id || request.Status != model.FriendRequestAccepted {
+		t.Fatalf("expected accepted reverse request, got %+v", request)
+	}
+	checkMock(t, mock)
+}
+
+func TestSendFriendRequestInsertsInTx(t *testing.T) {
+	d, mock := newMockDbc(t)
+	declined := model.FriendRequest{Id: 3, FromUserId: requestFrom, ToUserId: requestTo, Status: model.FriendRequestDeclined}
+	expectRequestStart(mock, declined)
+	mock.ExpectExec(queryRequestInsert).WithArgs(requestFrom, requestTo, model.FriendRequestPending).
+		WillReturnResult(sqlmock.NewResult(3, 2))
+	declined.Status = model.FriendRequestPending
+	mock.ExpectQuery(`SELECT \* from friend_requests where from_user_id=\? and to_user_id=\?`).
+		WithArgs(requestFrom, requestTo).WillReturnRows(requestRows(declined))
+	mock.ExpectCommit()
+
+	request, err := d.SendFriendRequest(context.Background(), requestFrom, requestTo)
+	if err != nil {
+		t.Fatalf("send: %s", err)
+	}
+	if request.Status != model.FriendRequestPending {
+		t.Fatalf("expected pending request, got %+v", request)
+	}
+	checkMock(t, mock)
+}
+
+func TestSendFriendRequestBlockedRollsBack(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryLockUsers).WithArgs(requestFrom, requestTo).WillReturnRows(lockedRows(requestFrom, requestTo))
+	mock.ExpectQuery(queryRequestPair).WillReturnRows(requestRows())
+	// Блокировка, зафиксированная до получения блокировки пары, видна проверке
+	mock.ExpectQuery(queryBlocks).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	if _, err := d.SendFriendRequest(context.Background(), requestFrom, requestTo); !errors.Is(err, storage.ErrBlocked) {
+		t.Fatalf("expected blocked, got %v", err)
+	}
+	checkMock(t, mock)
+}
@@ -0,0 +1,31 @@
+package mysql
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Состояние кластера БД
+var (
+	primaryCurrent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "storage",
+		Name:      "primary",
+		Help:      "Current primary node (1) among candidates",
+	}, []string{"pool", "node"})
+	primaryFailovers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "storage",
+		Name:      "failovers_total",
+		Help:      "Number of primary switches",
+	}, []string{"pool"})
+	nodeAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "storage",
+		Name:      "node_available",
+		Help:      "Node is available for its role (writable primary candidate or healthy replica)",
+	}, []string{"pool", "node"})
+	replicaLagSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "storage",
+		Name:      "replica_lag_seconds",
+		Help:      "Replica lag behind primary (Seconds_Behind_Master)",
+	}, []string{"node"})
+)
+
+func init() {
+	prometheus.MustRegister(primaryCurrent, primaryFailovers, nodeAvailable, replicaLagSeconds)
+}
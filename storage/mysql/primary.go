@@ -0,0 +1,394 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/basicus/hla-course/model"
+	"github.com/go-sql-driver/mysql"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Коды ошибок MySQL/MariaDB: запись на сервер только для чтения и нарушение уникального индекса
+const (
+	erOptionPreventsStatement = 1290
+	erReadOnlyMode            = 1836
+	erReadOnlyTransaction     = 1792
+	erDupEntry                = 1062
+)
+
+var ErrNoPrimary = errors.New("no writable primary available")
+
+// primaryNode Кандидат в мастера и его состояние по последнему подключению или проверке
+type primaryNode struct {
+	name      string
+	dsn       string
+	connector driver.Connector
+	available int32
+	readOnly  int32
+}
+
+// primarySet Подключение к одному из кандидатов в мастера. Новые соединения открываются к текущему мастеру,
+// при обнаружении сервера только для чтения или недоступности выбирается следующий записываемый кандидат,
+// а соединения к прежнему мастеру перестают использоваться
+type primarySet struct {
+	logger    *logrus.Entry
+	pool      string
+	nodes     []*primaryNode
+	current   int32
+	epoch     int64
+	failovers int64
+	m         sync.Mutex
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// newPrimarySet Кандидаты в мастера из списка DSN через запятую. Таймауты не дают зависшему кандидату
+// задерживать подключение и проверку остальных
+func newPrimarySet(config Config, pool string, logger *logrus.Entry) (*primarySet, error) {
+	p := &primarySet{logger: logger, pool: pool, stop: make(chan struct{})}
+	for _, dsn := range strings.Split(config.DSN, ",") {
+		dsn = strings.TrimSpace(dsn)
+		if dsn == "" {
+			continue
+		}
+		cfg, err := mysql.ParseDSN(dsn + "?parseTime=true")
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Timeout == 0 {
+			cfg.Timeout = config.DialTimeout
+		}
+		if cfg.ReadTimeout == 0 {
+			cfg.ReadTimeout = config.ReadTimeout
+		}
+		connector, err := mysql.NewConnector(cfg)
+		if err != nil {
+			return nil, err
+		}
+		p.nodes = append(p.nodes, &primaryNode{name: replicaName(dsn), dsn: dsn, connector: connector})
+	}
+	if len(p.nodes) == 0 {
+		return nil, ErrNoPrimary
+	}
+	return p, nil
+}
+
+// Connect Соединение с текущим мастером, при его недоступности - с первым записываемым кандидатом.
+// Подключение и проверка кандидатов выполняются без блокировки, под ней только меняется текущий мастер
+func (p *primarySet) Connect(ctx context.Context) (driver.Conn, error) {
+	start := int(atomic.LoadInt32(&p.current))
+	// Если мастер сменится во время подключения, соединение не вернется в пул
+	epoch := atomic.LoadInt64(&p.epoch)
+	err := ErrNoPrimary
+	for i := 0; i < len(p.nodes); i++ {
+		idx := (start + i) % len(p.nodes)
+		conn, connErr := p.open(ctx, p.nodes[idx])
+		if connErr != nil {
+			err = connErr
+			continue
+		}
+		if idx != start {
+			epoch = p.switchFrom(start, idx, epoch)
+		}
+		return &primaryConn{Conn: conn, set: p, epoch: epoch}, nil
+	}
+	return nil, err
+}
+
+// switchFrom Смена мастера start на idx, если его еще не сменили параллельно. Возвращает эпоху соединения с idx
+func (p *primarySet) switchFrom(start, idx int, epoch int64) int64 {
+	p.m.Lock()
+	defer p.m.Unlock()
+	switch int(atomic.LoadInt32(&p.current)) {
+	case start:
+		p.switchTo(idx, "current primary is not writable")
+	case idx:
+	default:
+		// Параллельно выбран другой мастер: соединение используется один раз
+		return epoch
+	}
+	return atomic.LoadInt64(&p.epoch)
+}
+
+func (p *primarySet) Driver() driver.Driver {
+	return p.nodes[0].connector.Driver()
+}
+
+// open Подключение к кандидату с проверкой, что он доступен для записи
+func (p *primarySet) open(ctx context.Context, node *primaryNode) (driver.Conn, error) {
+	conn, err := node.connector.Connect(ctx)
+	if err != nil {
+		p.setState(node, false, false)
+		return nil, err
+	}
+	readOnly, err := connReadOnly(ctx, conn)
+	if err != nil {
+		_ = conn.Close()
+		p.setState(node, false, false)
+		return nil, err
+	}
+	p.setState(node, true, readOnly)
+	if readOnly {
+		_ = conn.Close()
+		return nil, fmt.Errorf("%s: %w", node.name, ErrNoPrimary)
+	}
+	return conn, nil
+}
+
+// Primary Адрес текущего мастера
+func (p *primarySet) Primary() string {
+	return p.nodes[atomic.LoadInt32(&p.current)].name
+}
+
+// PrimaryDSN DSN текущего мастера (для миграций)
+func (p *primarySet) PrimaryDSN() string {
+	return p.nodes[atomic.LoadInt32(&p.current)].dsn
+}
+
+// topology Состояние кандидатов в мастера
+func (p *primarySet) topology() model.StorageTopology {
+	current := int(atomic.LoadInt32(&p.current))
+	topology := model.StorageTopology{
+		Primary:   p.nodes[current].name,
+		Failovers: atomic.LoadInt64(&p.failovers),
+	}
+	for i, node := range p.nodes {
+		role := model.NodeRoleCandidate
+		if i == current {
+			role = model.NodeRolePrimary
+		}
+		topology.Nodes = append(topology.Nodes, model.StorageNode{
+			Name:      node.name,
+			Role:      role,
+			Available: atomic.LoadInt32(&node.available) == 1,
+			ReadOnly:  atomic.LoadInt32(&node.readOnly) == 1,
+		})
+	}
+	return topology
+}
+
+// failed Проверка, что ошибка вызвана недоступностью мастера или переводом его в режим только для чтения.
+// В этом случае открытые соединения отбрасываются, а новое подключение заново выберет записываемого кандидата
+func (p *primarySet) failed(err error) bool {
+	if !failoverError(err) {
+		return false
+	}
+	atomic.AddInt64(&p.epoch, 1)
+	return true
+}
+
+// switchTo Смена текущего мастера, вызывается под блокировкой
+func (p *primarySet) switchTo(idx int, reason string) {
+	prev := int(atomic.SwapInt32(&p.current, int32(idx)))
+	if prev == idx {
+		return
+	}
+	atomic.AddInt64(&p.epoch, 1)
+	atomic.AddInt64(&p.failovers, 1)
+	primaryFailovers.WithLabelValues(p.pool).Inc()
+	for i, node := range p.nodes {
+		value := 0.0
+		if i == idx {
+			value = 1
+		}
+		primaryCurrent.WithLabelValues(p.pool, node.name).Set(value)
+	}
+	p.logger.WithField("from", p.nodes[prev].name).WithField("to", p.nodes[idx].name).
+		Warnf("primary switched: %s", reason)
+}
+
+// check Проверка кандидатов; если текущий мастер стал недоступен или только для чтения, переключаемся
+func (p *primarySet) check(ctx context.Context) {
+	for _, node := range p.nodes {
+		conn, err := node.connector.Connect(ctx)
+		if err != nil {
+			p.setState(node, false, false)
+			continue
+		}
+		readOnly, err := connReadOnly(ctx, conn)
+		_ = conn.Close()
+		p.setState(node, err == nil, readOnly)
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	current := int(atomic.LoadInt32(&p.current))
+	if p.writable(p.nodes[current]) {
+		primaryCurrent.WithLabelValues(p.pool, p.nodes[current].name).Set(1)
+		return
+	}
+	for i := 1; i < len(p.nodes); i++ {
+		idx := (current + i) % len(p.nodes)
+		if p.writable(p.nodes[idx]) {
+			p.switchTo(idx, "primary check failed")
+			return
+		}
+	}
+}
+
+// run Периодическая проверка кандидатов до вызова close
+func (p *primarySet) run(period time.Duration) {
+	if period <= 0 {
+		return
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), period)
+		p.check(ctx)
+		cancel()
+	}
+}
+
+// close Остановка периодической проверки
+func (p *primarySet) close() {
+	p.stopOnce.Do(func() { close(p.stop) })
+}
+
+func (p *primarySet) writable(node *primaryNode) bool {
+	return atomic.LoadInt32(&node.available) == 1 && atomic.LoadInt32(&node.readOnly) == 0
+}
+
+func (p *primarySet) setState(node *primaryNode, available, readOnly bool) {
+	atomic.StoreInt32(&node.available, boolInt(available))
+	atomic.StoreInt32(&node.readOnly, boolInt(readOnly))
+	nodeAvailable.WithLabelValues(p.pool, node.name).Set(float64(boolInt(available && !readOnly)))
+}
+
+// connReadOnly Значение @@global.read_only сервера
+func connReadOnly(ctx context.Context, conn driver.Conn) (bool, error) {
+	queryer, ok := conn.(driver.QueryerContext)
+	if !ok {
+		return false, driver.ErrSkip
+	}
+	rows, err := queryer.QueryContext(ctx, "SELECT @@global.read_only", nil)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	dest := make([]driver.Value, 1)
+	if err = rows.Next(dest); err != nil {
+		return false, err
+	}
+	switch v := dest[0].(type) {
+	case int64:
+		return v != 0, nil
+	case []byte:
+		n, err := strconv.Atoi(string(v))
+		return n != 0, err
+	}
+	return false, nil
+}
+
+// failoverError Ошибка соединения или записи на сервер только для чтения
+func failoverError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case erOptionPreventsStatement, erReadOnlyMode, erReadOnlyTransaction:
+			return true
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, ErrNoPrimary) || errors.As(err, &netErr)
+}
+
+func boolInt(v bool) int32 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// primaryConn Соединение с мастером. После переключения мастера соединение не возвращается в пул
+type primaryConn struct {
+	driver.Conn
+	set   *primarySet
+	epoch int64
+}
+
+func (c *primaryConn) stale() bool {
+	return atomic.LoadInt64(&c.set.epoch) != c.epoch
+}
+
+// IsValid Соединение можно переиспользовать
+func (c *primaryConn) IsValid() bool {
+	if c.stale() {
+		return false
+	}
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *primaryConn) ResetSession(ctx context.Context) error {
+	if c.stale() {
+		return driver.ErrBadConn
+	}
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *primaryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *primaryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *primaryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := c.Conn.(driver.ExecerContext); ok {
+		return e.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *primaryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := c.Conn.(driver.QueryerContext); ok {
+		return q.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *primaryConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *primaryConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
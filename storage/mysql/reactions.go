@@ -0,0 +1,233 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type reactionCount struct {
+	ItemId   int64  `db:"item_id"`
+	Reaction string `db:"reaction"`
+	Count    int64  `db:"count"`
+}
+
+// ReactPost Поставить (или заменить) реакцию на публикацию
+func (d *dbc) ReactPost(ctx context.Context, postId, userId int64, reaction string) (model.ReactionCounts, error) {
+	post, err := d.GetPostById(ctx, postId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, storage.ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.Deleted {
+		return nil, storage.ErrPostNotFound
+	}
+	return d.react(ctx, model.ReactionItemPost, postId, userId, reaction)
+}
+
+// UnreactPost Убрать реакцию с публикации
+func (d *dbc) UnreactPost(ctx context.Context, postId, userId int64) (model.ReactionCounts, error) {
+	return d.unreact(ctx, model.ReactionItemPost, postId, userId)
+}
+
+// GetPostsReactions Получить агрегированные реакции для списка публикаций
+func (d *dbc) GetPostsReactions(ctx context.Context, postIds []int64) (map[int64]model.ReactionCounts, error) {
+	return d.getReactions(ctx, model.ReactionItemPost, postIds)
+}
+
+// ReactMessage Поставить (или заменить) реакцию на сообщение
+func (d *dbc) ReactMessage(ctx context.Context, messageId, userId int64, reaction string) (model.ReactionCounts, error) {
+	var counts model.ReactionCounts
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		if err = d.messageWriteAccess(ctx, messageId, userId); err != nil {
+			return err
+		}
+		counts, err = d.react(ctx, model.ReactionItemMessage, messageId, userId, reaction)
+		return err
+	})
+	return counts, err
+}
+
+// UnreactMessage Убрать реакцию с сообщения
+func (d *dbc) UnreactMessage(ctx context.Context, messageId, userId int64) (model.ReactionCounts, error) {
+	var counts model.ReactionCounts
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		if err = d.messageWriteAccess(ctx, messageId, userId); err != nil {
+			return err
+		}
+		counts, err = d.unreact(ctx, model.ReactionItemMessage, messageId, userId)
+		return err
+	})
+	return counts, err
+}
+
+// messageWriteAccess Сообщение существует, пользователь участник чата и чат не закрыт (внутри транзакции)
+func (d *dbc) messageWriteAccess(ctx context.Context, messageId, userId int64) error {
+	message, err := d.MessageGet(ctx, messageId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrMessageNotFound
+		}
+		return err
+	}
+	_, _, err = d.chatWriteAccess(ctx, message.ChatId, userId)
+	return err
+}
+
+// GetMessagesReactions Получить агрегированные реакции для списка сообщений
+func (d *dbc) GetMessagesReactions(ctx context.Context, messageIds []int64) (map[int64]model.ReactionCounts, error) {
+	return d.getReactions(ctx, model.ReactionItemMessage, messageIds)
+}
+
+// react Сохраняет реакцию пользователя и обновляет агрегированные счетчики в одной транзакции.
+// Вставка с on duplicate key блокирует строку реакции, поэтому одновременные запросы пользователя выполняются по очереди
+func (d *dbc) react(ctx context.Context, itemType string, itemId, userId int64, reaction string) (model.ReactionCounts, error) {
+	if !model.ValidReaction(reaction) {
+		return nil, storage.ErrInvalidReaction
+	}
+
+	var counts model.ReactionCounts
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		result, err := d.conn(ctx).ExecContext(ctx,
+			"insert into reactions (item_type, item_id, user_id, reaction) values (?, ?, ?, ?) "+
+				"on duplicate key update user_id = user_id;", itemType, itemId, userId, reaction)
+		if err != nil {
+			return err
+		}
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if inserted == 0 {
+			// Реакция уже была: строка заблокирована вставкой, читаем ее актуальное значение
+			var current string
+			err = d.conn(ctx).GetContext(ctx, &current,
+				"SELECT reaction from reactions where item_type=? and item_id=? and user_id=? for update", itemType, itemId, userId)
+			if err != nil {
+				return err
+			}
+			if current == reaction {
+				counts, err = d.getItemReactions(ctx, itemType, itemId)
+				return err
+			}
+			_, err = d.conn(ctx).ExecContext(ctx,
+				"update reactions set reaction=?, created_at=now() where item_type=? and item_id=? and user_id=?;",
+				reaction, itemType, itemId, userId)
+			if err != nil {
+				return err
+			}
+			if err = d.decrementReaction(ctx, itemType, itemId, current); err != nil {
+				return err
+			}
+		}
+
+		_, err = d.conn(ctx).ExecContext(ctx,
+			"insert into reaction_counts (item_type, item_id, reaction, count) values (?, ?, ?, 1) "+
+				"on duplicate key update count = count + 1;", itemType, itemId, reaction)
+		if err != nil {
+			return err
+		}
+		counts, err = d.getItemReactions(ctx, itemType, itemId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// unreact Удаляет реакцию пользователя и обновляет агрегированные счетчики в одной транзакции
+func (d *dbc) unreact(ctx context.Context, itemType string, itemId, userId int64) (model.ReactionCounts, error) {
+	var counts model.ReactionCounts
+	err := d.WithTx(ctx, func(ctx context.Context) error {
+		var current string
+		err := d.conn(ctx).GetContext(ctx, &current,
+			"SELECT reaction from reactions where item_type=? and item_id=? and user_id=? for update", itemType, itemId, userId)
+		switch {
+		case err == nil:
+			_, err = d.conn(ctx).ExecContext(ctx,
+				"delete from reactions where item_type=? and item_id=? and user_id=?;", itemType, itemId, userId)
+			if err != nil {
+				return err
+			}
+			if err = d.decrementReaction(ctx, itemType, itemId, current); err != nil {
+				return err
+			}
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+		counts, err = d.getItemReactions(ctx, itemType, itemId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (d *dbc) decrementReaction(ctx context.Context, itemType string, itemId int64, reaction string) error {
+	_, err := d.conn(ctx).ExecContext(ctx,
+		"update reaction_counts set count = count - 1 where item_type=? and item_id=? and reaction=? and count > 0;",
+		itemType, itemId, reaction)
+	return err
+}
+
+func (d *dbc) getItemReactions(ctx context.Context, itemType string, itemId int64) (model.ReactionCounts, error) {
+	reactions, err := d.getReactions(ctx, itemType, []int64{itemId})
+	if err != nil {
+		return nil, err
+	}
+	if counts, ok := reactions[itemId]; ok {
+		return counts, nil
+	}
+	return model.ReactionCounts{}, nil
+}
+
+// getReactions Получение агрегированных счетчиков реакций (без подсчета по таблице реакций)
+func (d *dbc) getReactions(ctx context.Context, itemType string, itemIds []int64) (map[int64]model.ReactionCounts, error) {
+	result := make(map[int64]model.ReactionCounts)
+	if len(itemIds) == 0 {
+		return result, nil
+	}
+	query, args, err := sqlx.In("SELECT item_id, reaction, count FROM reaction_counts WHERE item_type = ? AND item_id IN (?) AND count > 0",
+		itemType, itemIds)
+	if err != nil {
+		return nil, err
+	}
+	var counts []reactionCount
+	err = d.conn(ctx).SelectContext(ctx, &counts, d.conn(ctx).Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range counts {
+		if _, ok := result[c.ItemId]; !ok {
+			result[c.ItemId] = make(model.ReactionCounts)
+		}
+		result[c.ItemId][c.Reaction] = c.Count
+	}
+	return result, nil
+}
+
+// attachPostReactions Заполняет реакции у списка публикаций
+func (d *dbc) attachPostReactions(ctx context.Context, posts []model.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(posts))
+	for i, post := range posts {
+		ids[i] = post.Id
+	}
+	reactions, err := d.GetPostsReactions(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range posts {
+		posts[i].Reactions = reactions[posts[i].Id]
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/basicus/hla-course/model"
+	"testing"
+)
+
+const (
+	queryReactionUpsert  = `insert into reactions .* on duplicate key update user_id = user_id`
+	queryReactionCurrent = `SELECT reaction from reactions .* for update`
+	queryReactionChange  = `update reactions set reaction=\?`
+	queryReactionDelete  = `delete from reactions where`
+	queryCountIncrement  = `insert into reaction_counts .* count = count \+ 1`
+	queryCountDecrement  = `update reaction_counts set count = count - 1`
+	queryCounts          = `SELECT item_id, reaction, count FROM reaction_counts`
+)
+
+func countRows(itemId int64, reaction string, count int) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"item_id", "reaction", "count"}).AddRow(itemId, reaction, count)
+}
+
+func TestReactNewReaction(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(queryReactionUpsert).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(queryCountIncrement).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryCounts).WillReturnRows(countRows(5, model.ReactionLike, 1))
+	mock.ExpectCommit()
+
+	counts, err := d.react(context.Background(), model.ReactionItemMessage, 5, 1, model.ReactionLike)
+	if err != nil {
+		t.Fatalf("react: %s", err)
+	}
+	if counts[model.ReactionLike] != 1 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+	checkMock(t, mock)
+}
+
+func TestReactSameReactionKeepsCounts(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(queryReactionUpsert).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryReactionCurrent).WillReturnRows(sqlmock.NewRows([]string{"reaction"}).AddRow(model.ReactionLike))
+	mock.ExpectQuery(queryCounts).WillReturnRows(countRows(5, model.ReactionLike, 1))
+	mock.ExpectCommit()
+
+	if _, err := d.react(context.Background(), model.ReactionItemMessage, 5, 1, model.ReactionLike); err != nil {
+		t.Fatalf("react: %s", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestReactChangeReaction(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(queryReactionUpsert).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryReactionCurrent).WillReturnRows(sqlmock.NewRows([]string{"reaction"}).AddRow(model.ReactionLike))
+	mock.ExpectExec(queryReactionChange).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(queryCountDecrement).WithArgs(model.ReactionItemMessage, 5, model.ReactionLike).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(queryCountIncrement).WithArgs(model.ReactionItemMessage, 5, model.ReactionSad).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryCounts).WillReturnRows(countRows(5, model.ReactionSad, 1))
+	mock.ExpectCommit()
+
+	if _, err := d.react(context.Background(), model.ReactionItemMessage, 5, 1, model.ReactionSad); err != nil {
+		t.Fatalf("react: %s", err)
+	}
+	checkMock(t, mock)
+}
+
+func TestUnreactDecrementsInTx(t *testing.T) {
+	d, mock := newMockDbc(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryReactionCurrent).WillReturnRows(sqlmock.NewRows([]string{"reaction"}).AddRow(model.ReactionLike))
+	mock.ExpectExec(queryReactionDelete).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(queryCountDecrement).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(queryCounts).WillReturnRows(sqlmock.NewRows([]string{"item_id", "reaction", "count"}))
+	mock.ExpectCommit()
+
+	counts, err := d.unreact(context.Background(), model.ReactionItemMessage, 5, 1)
+	if err != nil {
+		t.Fatalf("unreact: %s", err)
+	}
+	if len(counts) != 0 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+	checkMock(t, mock)
+}
@@ -0,0 +1,258 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"github.com/basicus/hla-course/model"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Способы выбора реплики для чтения
+const (
+	BalanceRoundRobin       = "round-robin"
+	BalanceLeastConnections = "least-connections"
+)
+
+// replica Реплика для чтения и ее состояние по последней проверке
+type replica struct {
+	name    string
+	db      *sqlx.DB
+	healthy int32
+	lag     int64 // Отставание от мастера в секундах
+}
+
+// replicaPool Пул реплик для чтения. Отстающие и недоступные реплики исключаются до следующей проверки,
+// а чтения данных, недавно измененных через этот экземпляр, направляются на мастер (read-your-writes)
+type replicaPool struct {
+	logger   *logrus.Entry
+	replicas []*replica
+	balance  string
+	maxLag   time.Duration
+	window   time.Duration
+	next     uint32
+
+	m      sync.Mutex
+	writes map[string]time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// newReplicaPool Подключение к репликам из списка DSN через запятую и запуск периодической проверки
+func newReplicaPool(cfg Config, logger *logrus.Entry) (*replicaPool, error) {
+	switch cfg.RoBalance {
+	case BalanceRoundRobin, BalanceLeastConnections:
+	default:
+		return nil, fmt.Errorf("unknown replica balance %q", cfg.RoBalance)
+	}
+	p := &replicaPool{
+		logger:  logger,
+		balance: cfg.RoBalance,
+		maxLag:  cfg.RoMaxLag,
+		window:  cfg.RoStickyWindow,
+		writes:  make(map[string]time.Time),
+		stop:    make(chan struct{}),
+	}
+	for _, dsn := range strings.Split(cfg.DSNro, ",") {
+		dsn = strings.TrimSpace(dsn)
+		if dsn == "" {
+			continue
+		}
+		conn, err := sqlx.Open("mysql", dsn+"?parseTime=true")
+		if err != nil {
+			return nil, err
+		}
+		conn.SetMaxOpenConns(cfg.MaxOpenConnectionsRo)
+		p.replicas = append(p.replicas, &replica{name: replicaName(dsn), db: conn})
+	}
+	if len(p.replicas) == 0 {
+		return nil, nil
+	}
+
+	p.check(context.Background())
+	if cfg.RoCheckPeriod > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.RoCheckPeriod)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-p.stop:
+					return
+				case <-ticker.C:
+				}
+				p.check(context.Background())
+			}
+		}()
+	}
+	return p, nil
+}
+
+// close Остановка проверок и закрытие подключений к репликам
+func (p *replicaPool) close() error {
+	p.stopOnce.Do(func() { close(p.stop) })
+	var err error
+	for _, r := range p.replicas {
+		if closeErr := r.db.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// pick Выбор доступной реплики, nil если доступных нет
+func (p *replicaPool) pick() *sqlx.DB {
+	var healthy []*replica
+	for _, r := range p.replicas {
+		if atomic.LoadInt32(&r.healthy) == 1 {
+			healthy = append(healthy, r)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	if p.balance == BalanceLeastConnections {
+		best := healthy[0]
+		for _, r := range healthy[1:] {
+			if r.db.Stats().InUse < best.db.Stats().InUse {
+				best = r
+			}
+		}
+		return best.db
+	}
+	n := atomic.AddUint32(&p.next, 1)
+	return healthy[int(n)%len(healthy)].db
+}
+
+// written Отметить запись данных по ключам, чтения по ним до конца окна идут на мастер
+func (p *replicaPool) written(keys ...string) {
+	if p.window <= 0 {
+		return
+	}
+	until := time.Now().Add(p.window)
+	p.m.Lock()
+	defer p.m.Unlock()
+	for _, key := range keys {
+		p.writes[key] = until
+	}
+}
+
+// sticky Данные по одному из ключей недавно изменялись
+func (p *replicaPool) sticky(keys ...string) bool {
+	if p.window <= 0 || len(keys) == 0 {
+		return false
+	}
+	now := time.Now()
+	p.m.Lock()
+	defer p.m.Unlock()
+	for _, key := range keys {
+		if until, ok := p.writes[key]; ok && now.Before(until) {
+			return true
+		}
+	}
+	return false
+}
+
+// check Проверка доступности и отставания реплик, очистка истекших отметок записи
+func (p *replicaPool) check(ctx context.Context) {
+	for _, r := range p.replicas {
+		lag, err := replicaLag(ctx, r.db)
+		healthy := err == nil && (p.maxLag <= 0 || lag <= p.maxLag)
+		atomic.StoreInt64(&r.lag, int64(lag/time.Second))
+		replicaLagSeconds.WithLabelValues(r.name).Set(lag.Seconds())
+
+		var state int32
+		if healthy {
+			state = 1
+		}
+		nodeAvailable.WithLabelValues("replicas", r.name).Set(float64(state))
+		if atomic.SwapInt32(&r.healthy, state) != state {
+			entry := p.logger.WithField("replica", r.name).WithField("lag", lag)
+			if healthy {
+				entry.Info("replica is back in pool")
+			} else {
+				entry.WithError(err).Warn("replica is ejected from pool")
+			}
+		}
+	}
+
+	now := time.Now()
+	p.m.Lock()
+	defer p.m.Unlock()
+	for key, until := range p.writes {
+		if now.After(until) {
+			delete(p.writes, key)
+		}
+	}
+}
+
+// topology Состояние реплик
+func (p *replicaPool) topology() []model.StorageNode {
+	nodes := make([]model.StorageNode, 0, len(p.replicas))
+	for _, r := range p.replicas {
+		nodes = append(nodes, model.StorageNode{
+			Name:       r.name,
+			Role:       model.NodeRoleReplica,
+			Available:  atomic.LoadInt32(&r.healthy) == 1,
+			ReadOnly:   true,
+			LagSeconds: atomic.LoadInt64(&r.lag),
+		})
+	}
+	return nodes
+}
+
+// replicaLag Отставание реплики по Seconds_Behind_Master. Ошибка, если репликация остановлена
+func replicaLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	rows, err := db.QueryxContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		// Сервер не является репликой (например, тот же мастер) - отставания нет
+		return 0, rows.Err()
+	}
+	status := make(map[string]interface{})
+	if err = rows.MapScan(status); err != nil {
+		return 0, err
+	}
+	value, ok := status["Seconds_Behind_Master"].([]byte)
+	if !ok {
+		return 0, fmt.Errorf("replication is not running")
+	}
+	seconds, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// replicaName Адрес реплики из DSN без учетных данных (для логов)
+func replicaName(dsn string) string {
+	if i := strings.LastIndex(dsn, "@"); i >= 0 {
+		return dsn[i+1:]
+	}
+	return dsn
+}
+
+// Ключи данных для read-your-writes
+func userKey(id int64) string { return "user:" + strconv.FormatInt(id, 10) }
+func chatKey(id int64) string { return "chat:" + strconv.FormatInt(id, 10) }
+func postKey(id int64) string { return "post:" + strconv.FormatInt(id, 10) }
+
+// userKeys Ключи данных пользователей
+func userKeys(ids ...int64) []string {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, userKey(id))
+	}
+	return keys
+}
@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"net"
+	"testing"
+	"time"
+)
+
+const queryUserShards = `SELECT user_id, shard_id from users where user_id IN \(\?, \?\)`
+
+func TestReplicaErrorFallsBackToPrimary(t *testing.T) {
+	d, mock := newMockDbc(t)
+	replicaDb, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %s", err)
+	}
+	t.Cleanup(func() { _ = replicaDb.Close() })
+	d.primary = &primarySet{}
+	d.replicas = &replicaPool{replicas: []*replica{{name: "replica", db: sqlx.NewDb(replicaDb, "mysql"), healthy: 1}}}
+
+	failure := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	replicaMock.ExpectQuery(queryUserShards).WillReturnError(failure)
+	mock.ExpectQuery(queryUserShards).WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "shard_id"}).AddRow(1, "shard-1").AddRow(2, "shard-2"))
+
+	shards, err := d.GetUserShards(context.Background(), []int64{1, 2})
+	if err != nil {
+		t.Fatalf("shards: %s", err)
+	}
+	if shards[1] != "shard-1" || shards[2] != "shard-2" {
+		t.Fatalf("unexpected shards %v", shards)
+	}
+	// Ошибка реплики не сбрасывает соединения мастера
+	if d.primary.epoch != 0 {
+		t.Fatalf("primary epoch changed to %d", d.primary.epoch)
+	}
+	checkMock(t, mock)
+	checkMock(t, replicaMock)
+}
+
+func TestCloseStopsHealthChecks(t *testing.T) {
+	d, mock := newMockDbc(t)
+	d.primary = &primarySet{stop: make(chan struct{})}
+	stopped := make(chan struct{})
+	go func() {
+		d.primary.run(time.Hour)
+		close(stopped)
+	}()
+	mock.ExpectClose()
+
+	if err := d.Close(); err != nil {
+		t.Fatalf("close: %s", err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("primary check is not stopped")
+	}
+	// Повторное закрытие не паникует
+	d.primary.close()
+	checkMock(t, mock)
+}
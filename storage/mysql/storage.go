@@ -1,110 +1,204 @@
 package mysql
 
 import (
+	"context"
+	"database/sql"
 	"github.com/basicus/hla-course/migrations"
+	"github.com/basicus/hla-course/model"
 	"github.com/basicus/hla-course/storage"
 	"github.com/go-redis/redis/v8"
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/mysql"
 	bindata "github.com/golang-migrate/migrate/v4/source/go_bindata"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// Повторы идемпотентных операций при переключении мастера
+const (
+	retryAttempts = 3
+	retryDelay    = 200 * time.Millisecond
 )
 
 type dbc struct {
-	logger       *logrus.Logger
-	connection   *sqlx.DB
-	connectionRo *sqlx.DB
-	redis        *redis.Client
-	roEnable     bool
+	logger     *logrus.Logger
+	connection *sqlx.DB
+	primary    *primarySet
+	replicas   *replicaPool
+	redis      *redis.Client
 }
 
 func New(cfg Config, logger *logrus.Logger) (storage.UserService, error) {
-
-	// Migrations
-	source := bindata.Resource(migrations.AssetNames(), migrations.Asset)
-	d, err := bindata.WithInstance(source)
+	conn, primary, err := connect(cfg, "storage", logger)
 	if err != nil {
 		return nil, err
 	}
-	m, err := migrate.NewWithSourceInstance("go-bindata", d, "mysql://"+cfg.DSN)
-	if err != nil {
-		return nil, err
-	}
-	if err = m.Up(); err != nil {
-		if err != migrate.ErrNoChange {
+
+	var replicas *replicaPool
+	if cfg.DSNro != "" && !cfg.RoDisable {
+		replicas, err = newReplicaPool(cfg, logger.WithField("role", "storage"))
+		if err != nil {
 			return nil, err
 		}
 	}
+	logger.WithField("role", "storage").Logger.Infof("Using readonly connection pooling: %t", replicas != nil)
+	return &dbc{
+		logger:     logger.WithField("role", "storage").Logger,
+		connection: conn,
+		primary:    primary,
+		replicas:   replicas,
+	}, nil
+}
 
-	conn, err := sqlx.Open("mysql", cfg.DSN+"?parseTime=true")
+func NewChats(cfg Config, logger *logrus.Logger) (storage.ChatsService, error) {
+	conn, primary, err := connect(cfg, "storage-chats", logger)
 	if err != nil {
 		return nil, err
 	}
-	err = conn.Ping()
-	conn.SetMaxOpenConns(cfg.MaxOpenConnections)
+
+	logger.WithField("role", "storage-chats").Logger.Infof("Using readonly connection pooling: %t", false)
+	return &dbc{
+		logger:     logger.WithField("role", "storage-chats").Logger,
+		connection: conn,
+		primary:    primary,
+	}, nil
+}
+
+// connect Подключение к записываемому мастеру из списка кандидатов и применение миграций
+func connect(cfg Config, role string, logger *logrus.Logger) (*sqlx.DB, *primarySet, error) {
+	primary, err := newPrimarySet(cfg, role, logger.WithField("role", role))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	conn := sqlx.NewDb(sql.OpenDB(primary), "mysql")
+	conn.SetMaxOpenConns(cfg.MaxOpenConnections)
+	if err = conn.Ping(); err != nil {
+		return nil, nil, err
 	}
+	logger.WithField("role", role).Infof("Using primary %s", primary.Primary())
 
-	var roEnable bool
-	var connRo *sqlx.DB
-	if cfg.DSNro != "" && !cfg.RoDisable {
-		roEnable = true
-		connRo, err = sqlx.Open("mysql", cfg.DSNro+"?parseTime=true")
+	// Migrations
+	if cfg.AutoMigrate {
+		m, err := newMigrate(primary.PrimaryDSN())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		err = connRo.Ping()
-		connRo.SetMaxOpenConns(cfg.MaxOpenConnections)
-		if err != nil {
-			return nil, err
+		if err = m.Up(); err != nil {
+			if err != migrate.ErrNoChange {
+				return nil, nil, err
+			}
 		}
 	}
-	logger.WithField("role", "storage").Logger.Infof("Using readonly connection pooling: %t", roEnable)
-	return &dbc{
-		logger:       logger.WithField("role", "storage").Logger,
-		connection:   conn,
-		connectionRo: connRo,
-		roEnable:     roEnable,
-	}, nil
+
+	go primary.run(cfg.PrimaryCheckPeriod)
+	return conn, primary, nil
 }
 
-func NewChats(cfg Config, logger *logrus.Logger) (storage.ChatsService, error) {
+// Close Остановка проверок мастера и реплик, закрытие подключений
+func (d *dbc) Close() error {
+	if d.primary != nil {
+		d.primary.close()
+	}
+	var err error
+	if d.replicas != nil {
+		err = d.replicas.close()
+	}
+	if closeErr := d.connection.Close(); closeErr != nil {
+		err = closeErr
+	}
+	return err
+}
 
-	// Migrations
-	source := bindata.Resource(migrations.AssetNames(), migrations.Asset)
-	d, err := bindata.WithInstance(source)
+// NewMigrate Миграции схемы БД на текущем мастере
+func NewMigrate(cfg Config, logger *logrus.Logger) (*migrate.Migrate, error) {
+	primary, err := newPrimarySet(cfg, "migrate", logger.WithField("role", "migrate"))
 	if err != nil {
 		return nil, err
 	}
-	m, err := migrate.NewWithSourceInstance("go-bindata", d, "mysql://"+cfg.DSN)
-	if err != nil {
+	conn := sql.OpenDB(primary)
+	defer conn.Close()
+	if err = conn.Ping(); err != nil {
 		return nil, err
 	}
-	if err = m.Up(); err != nil {
-		if err != migrate.ErrNoChange {
-			return nil, err
-		}
-	}
+	logger.WithField("role", "migrate").Infof("Using primary %s", primary.Primary())
+	return newMigrate(primary.PrimaryDSN())
+}
 
-	conn, err := sqlx.Open("mysql", cfg.DSN+"?parseTime=true")
+func newMigrate(dsn string) (*migrate.Migrate, error) {
+	source := bindata.Resource(migrations.AssetNames(), migrations.Asset)
+	d, err := bindata.WithInstance(source)
 	if err != nil {
 		return nil, err
 	}
-	err = conn.Ping()
-	conn.SetMaxOpenConns(cfg.MaxOpenConnections)
-	if err != nil {
-		return nil, err
+	return migrate.NewWithSourceInstance("go-bindata", d, "mysql://"+dsn)
+}
+
+// reader Подключение для чтения данных по ключам: доступная реплика, либо мастер (транзакция),
+// если реплик нет или данные недавно изменялись
+func (d *dbc) reader(ctx context.Context, keys ...string) executor {
+	if db := d.replica(ctx, keys...); db != nil {
+		return db
+	}
+	return d.conn(ctx)
+}
+
+// replica Реплика для чтения данных по ключам, nil если читать нужно с мастера
+func (d *dbc) replica(ctx context.Context, keys ...string) *sqlx.DB {
+	if inTx(ctx) || d.replicas == nil || d.replicas.sticky(keys...) {
+		return nil
 	}
+	return d.replicas.pick()
+}
 
-	var roEnable bool
+// written Отметить изменение данных по ключам для чтения своих записей
+func (d *dbc) written(keys ...string) {
+	if d.replicas != nil {
+		d.replicas.written(keys...)
+	}
+}
 
-	logger.WithField("role", "storage-chats").Logger.Infof("Using readonly connection pooling: %t", roEnable)
-	return &dbc{
-		logger:     logger.WithField("role", "storage-chats").Logger,
-		connection: conn,
-		roEnable:   roEnable,
-	}, nil
+// retry Выполнение идемпотентной операции с повтором после переключения мастера
+func (d *dbc) retry(ctx context.Context, op func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = op()
+		// В транзакции повтор невозможен: при ошибке откатывается вся транзакция
+		if err == nil || d.primary == nil || inTx(ctx) || attempt > retryAttempts || !d.primary.failed(err) {
+			return err
+		}
+		d.logger.WithError(err).Warnf("storage operation failed, retry %d", attempt)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * retryDelay):
+		}
+	}
+}
+
+// readRetry Выполнение чтения на реплике, при ее недоступности - на мастере с повтором после переключения.
+// Ошибки реплики не считаются ошибками мастера и не сбрасывают его соединения
+func (d *dbc) readRetry(ctx context.Context, keys []string, op func(connection executor) error) error {
+	if db := d.replica(ctx, keys...); db != nil {
+		err := op(db)
+		if !failoverError(err) {
+			return err
+		}
+		d.logger.WithError(err).Warn("replica read failed, fallback to primary")
+	}
+	return d.retry(ctx, func() error {
+		return op(d.conn(ctx))
+	})
+}
+
+// Topology Текущая топология кластера БД: кандидаты в мастера и реплики
+func (d *dbc) Topology() model.StorageTopology {
+	var topology model.StorageTopology
+	if d.primary != nil {
+		topology = d.primary.topology()
+	}
+	if d.replicas != nil {
+		topology.Nodes = append(topology.Nodes, d.replicas.topology()...)
+	}
+	return topology
 }
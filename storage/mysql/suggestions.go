@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"context"
+	"github.com/basicus/hla-course/model"
+)
+
+// GetSuggestionCandidates Получить кандидатов в друзья среди друзей друзей с количеством общих друзей.
+// Существующие подписки, а также заблокированные и скрытые пользователи исключаются. Кандидаты отбираются
+// по предварительной оценке (общие друзья, город, страна) с теми же весами, что и при ранжировании,
+// поэтому ограничение limit не отсекает совпадения по городу и стране; интересы учитываются при ранжировании
+func (d *dbc) GetSuggestionCandidates(ctx context.Context, userId int64, limit int64) ([]model.FriendSuggestion, error) {
+	var candidates []model.FriendSuggestion
+	connection := d.reader(ctx, userKey(userId))
+	// f1 - друзья пользователя, f2 - их друзья (дружба - подписка в обе стороны)
+	err := connection.SelectContext(ctx, &candidates,
+		"SELECT c.* FROM ("+
+			"SELECT u.user_id, u.name, u.surname, u.country, u.city, u.interests, count(distinct f1.friend_id) as mutual_friends "+
+			"FROM user_friend f1 "+
+			"JOIN user_friend r1 ON r1.user_id = f1.friend_id AND r1.friend_id = f1.user_id "+
+			"JOIN user_friend f2 ON f2.user_id = f1.friend_id "+
+			"JOIN user_friend r2 ON r2.user_id = f2.friend_id AND r2.friend_id = f2.user_id "+
+			"JOIN users u ON u.user_id = f2.friend_id "+
+			"WHERE f1.user_id = ? AND f2.friend_id <> ? "+
+			"AND NOT EXISTS (SELECT 1 FROM user_friend e WHERE e.user_id = ? AND e.friend_id = f2.friend_id) "+
+			"AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE (b.user_id = ? AND b.target_id = f2.friend_id) "+
+			"OR (b.user_id = f2.friend_id AND b.target_id = ? AND b.kind = ?)) "+
+			"GROUP BY u.user_id, u.name, u.surname, u.country, u.city, u.interests) c "+
+			"JOIN users me ON me.user_id = ? "+
+			"ORDER BY ? * c.mutual_friends + ? * (me.city <> '' AND c.city = me.city) + ? * (me.country <> '' AND c.country = me.country) DESC, "+
+			"c.mutual_friends DESC, c.user_id LIMIT ?",
+		userId, userId, userId, userId, userId, model.BlockKindBlock, userId,
+		model.SuggestionWeightMutual, model.SuggestionWeightCity, model.SuggestionWeightCountry, limit)
+	if err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+// executor Общие методы подключения и транзакции, запросы хранилища выполняются через него
+type executor interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+type txKey struct{}
+
+// WithTx Выполнение fn в транзакции. Методы хранилища, вызванные с переданным в fn контекстом,
+// выполняются в этой транзакции. Вложенный вызов присоединяется к внешней транзакции
+func (d *dbc) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := d.connection.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			d.logger.WithError(rollbackErr).Error("transaction rollback failed")
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// conn Транзакция из контекста или подключение к мастеру
+func (d *dbc) conn(ctx context.Context) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return d.connection
+}
+
+// inTx Контекст содержит транзакцию
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return ok
+}
@@ -9,6 +9,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 const (
@@ -22,87 +23,173 @@ const (
 	SqlComma = " , "
 )
 
-var fieldsOrder = []string{"user_id", "name", "surname", "age", "country", "city", "interests"}
-
 func (d *dbc) GetById(ctx context.Context, id int64) (model.User, error) {
 	var user model.User
-	err := d.connection.GetContext(ctx, &user, "SELECT * from users where user_id=?", id)
+	err := d.retry(ctx, func() error {
+		return d.conn(ctx).GetContext(ctx, &user, "SELECT * from users where user_id=?", id)
+	})
 	if err != nil {
 		return model.User{}, err
 	}
 	return user, nil
 }
 
+// GetByIds Получение информации о пользователях по списку id
+func (d *dbc) GetByIds(ctx context.Context, ids []int64) ([]model.User, error) {
+	var users []model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	query, args, err := sqlx.In("SELECT * from users where user_id IN (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	err = d.retry(ctx, func() error {
+		users = users[:0]
+		return d.conn(ctx).SelectContext(ctx, &users, d.conn(ctx).Rebind(query), args...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (d *dbc) GetByLogin(ctx context.Context, login string) (model.User, error) {
 	var user model.User
-	err := d.connection.GetContext(ctx, &user, "SELECT * from users where login=?", login)
+	err := d.retry(ctx, func() error {
+		return d.conn(ctx).GetContext(ctx, &user, "SELECT * from users where login=?", login)
+	})
 	if err != nil {
 		return model.User{}, err
 	}
 	return user, nil
 }
 
-func (d *dbc) GetUsers(ctx context.Context, filter map[string]string, order map[string]string, offset, limit int) ([]model.User, error) {
-	var users []model.User
-	var sb strings.Builder
+// SearchUsers Поиск пользователей по фильтрам с сортировкой по разрешенному полю и keyset пагинацией
+func (d *dbc) SearchUsers(ctx context.Context, viewer int64, search model.UserSearch) (model.UserSearchResult, error) {
+	if search.Sort == "" {
+		search.Sort = model.SearchSortId
+	}
+	if !model.ValidSearchSort(search.Sort) {
+		return model.UserSearchResult{}, storage.ErrInvalidSearch
+	}
+
+	var where []string
 	var args []interface{}
-	c := 0
-	sb.WriteString("SELECT * from users ")
-	if len(filter) > 0 {
-		sb.WriteString(SqlWhere)
-	}
-	for _, field := range fieldsOrder {
-		v, ok := filter[field]
-		if ok {
-			if c == 0 {
-				sb.WriteString(SqlN)
-			} else {
-				sb.WriteString(SqlAnd)
-			}
-			sb.WriteString(field)
-			sb.WriteString(SqlLike)
-			args = append(args, v)
-			c++
+	if search.Name != "" {
+		where = append(where, "name"+SqlLike)
+		args = append(args, likePrefix(search.Name))
+	}
+	if search.Surname != "" {
+		where = append(where, "surname"+SqlLike)
+		args = append(args, likePrefix(search.Surname))
+	}
+	if search.City != "" {
+		where = append(where, "city"+SqlEq)
+		args = append(args, search.City)
+	}
+	if search.Country != "" {
+		where = append(where, "country"+SqlEq)
+		args = append(args, search.Country)
+	}
+	if search.Sex != "" {
+		where = append(where, "sex"+SqlEq)
+		args = append(args, search.Sex)
+	}
+	if search.AgeFrom > 0 {
+		where = append(where, "age >= ?")
+		args = append(args, search.AgeFrom)
+	}
+	if search.AgeTo > 0 {
+		where = append(where, "age <= ?")
+		args = append(args, search.AgeTo)
+	}
+	if search.Interests != "" {
+		terms := fulltextTerms(search.Interests)
+		if terms == "" {
+			return model.UserSearchResult{Users: []model.User{}}, nil
 		}
+		where = append(where, "MATCH (interests) AGAINST (? IN BOOLEAN MODE)")
+		args = append(args, terms)
 	}
-	c = 0
-	if len(order) > 0 {
-		sb.WriteString(SqlOrder)
+	if viewer > 0 {
+		// Исключаем пользователей, с которыми у viewer есть блокировка в любую сторону
+		where = append(where, "NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.kind = ? AND "+
+			"((b.user_id = ? AND b.target_id = users.user_id) OR (b.user_id = users.user_id AND b.target_id = ?)))")
+		args = append(args, model.BlockKindBlock, viewer, viewer)
 	}
 
-	for _, field := range fieldsOrder {
-		v, ok := order[field]
-		if ok {
-			if c == 0 {
-				sb.WriteString(SqlN)
-			} else {
-				sb.WriteString(SqlComma)
-			}
-			sb.WriteString(field)
-			sb.WriteString(SqlN)
-			sb.WriteString(v)
-			c++
+	connection := d.reader(ctx, userKey(viewer))
+
+	var result model.UserSearchResult
+	var sb strings.Builder
+	sb.WriteString("SELECT count(*) from users ")
+	if len(where) > 0 {
+		sb.WriteString(SqlWhere + strings.Join(where, SqlAnd))
+	}
+	if err := connection.GetContext(ctx, &result.Count, sb.String(), args...); err != nil {
+		return model.UserSearchResult{}, err
+	}
+
+	// Позиция страницы: строки после (значение поля сортировки, user_id) курсора
+	compare, direction := ">", "ASC"
+	if search.Desc {
+		compare, direction = "<", "DESC"
+	}
+	if search.After != nil {
+		if search.Sort == model.SearchSortId {
+			where = append(where, "user_id "+compare+" ?")
+			args = append(args, search.After.Id)
+		} else {
+			where = append(where, "("+search.Sort+", user_id) "+compare+" (?, ?)")
+			args = append(args, search.After.Value, search.After.Id)
 		}
 	}
 
-	if offset > 0 {
-		sb.WriteString(" OFFSET ? ")
-		args = append(args, offset)
+	sb.Reset()
+	sb.WriteString("SELECT * from users ")
+	if len(where) > 0 {
+		sb.WriteString(SqlWhere + strings.Join(where, SqlAnd))
 	}
-	if limit > 0 {
+	sb.WriteString(SqlOrder)
+	if search.Sort != model.SearchSortId {
+		sb.WriteString(search.Sort + SqlN + direction + SqlComma)
+	}
+	sb.WriteString("user_id" + SqlN + direction)
+	if search.Limit > 0 {
 		sb.WriteString(" LIMIT ? ")
-		args = append(args, limit)
+		args = append(args, search.Limit)
+		if search.Offset > 0 {
+			sb.WriteString(" OFFSET ? ")
+			args = append(args, search.Offset)
+		}
 	}
-	// If RO connection is enabled use it
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
+
+	if err := connection.SelectContext(ctx, &result.Users, sb.String(), args...); err != nil {
+		return model.UserSearchResult{}, err
 	}
-	err := connection.SelectContext(ctx, &users, sb.String(), args...)
-	if err != nil {
-		return nil, err
+	if search.Limit > 0 && int64(len(result.Users)) == search.Limit {
+		result.NextCursor = search.CursorFor(result.Users[len(result.Users)-1]).Encode()
 	}
-	return users, nil
+	return result, nil
+}
+
+// likePrefix Шаблон LIKE для поиска по префиксу с экранированием спецсимволов
+func likePrefix(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+	return replacer.Replace(value) + "%"
+}
+
+// fulltextTerms Запрос полнотекстового поиска: все слова обязательны, с поиском по префиксу.
+// Операторы BOOLEAN MODE из пользовательского ввода отбрасываются
+func fulltextTerms(value string) string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		terms = append(terms, "+"+word+"*")
+	}
+	return strings.Join(terms, SqlN)
 }
 
 func (d *dbc) ValidateUser(ctx context.Context, login string, password string) (bool, error) {
@@ -126,9 +213,18 @@ func (d *dbc) CheckPasswordHash(_ context.Context, password, hash string) bool {
 }
 
 func (d *dbc) Create(ctx context.Context, user model.User) (model.User, error) {
+	var result model.User
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.create(ctx, user)
+		return err
+	})
+	return result, err
+}
+
+func (d *dbc) create(ctx context.Context, user model.User) (model.User, error) {
 	sql := "insert into users (login, email, phone, password, name, surname, age, sex, country, city, interests, shard_id) " +
 		"values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
-	stmt, err := d.connection.Prepare(sql)
+	stmt, err := d.conn(ctx).Prepare(sql)
 	defer stmt.Close()
 	if err != nil {
 		return model.User{}, err
@@ -171,10 +267,15 @@ func (d *dbc) Update(ctx context.Context, user model.User, fieldsForUpdating map
 		sb.WriteString("where user_id=:user_id")
 	}
 
-	_, err := d.connection.NamedExecContext(ctx, sb.String(), user)
+	// Обновление полей идемпотентно, повторяем при переключении мастера
+	err := d.retry(ctx, func() error {
+		_, err := d.conn(ctx).NamedExecContext(ctx, sb.String(), user)
+		return err
+	})
 	if err != nil {
 		return model.User{}, err
 	}
+	d.written(userKey(user.UserId))
 	userDb, err := d.GetById(ctx, user.UserId)
 	if err != nil {
 		return model.User{}, err
@@ -185,7 +286,7 @@ func (d *dbc) Update(ctx context.Context, user model.User, fieldsForUpdating map
 // GetUserName Получить имя пользователя
 func (d *dbc) GetUserName(ctx context.Context, userId int64) (string, error) {
 	var user model.User
-	err := d.connection.GetContext(ctx, &user, "SELECT * from users where user_id=?", userId)
+	err := d.conn(ctx).GetContext(ctx, &user, "SELECT * from users where user_id=?", userId)
 	if err != nil {
 		return "", err
 	}
@@ -195,43 +296,97 @@ func (d *dbc) GetUserName(ctx context.Context, userId int64) (string, error) {
 // GetLogin Получить логин пользователя
 func (d *dbc) GetLogin(ctx context.Context, userId int64) (string, error) {
 	var user model.User
-	err := d.connection.GetContext(ctx, &user, "SELECT * from users where user_id=?", userId)
+	err := d.conn(ctx).GetContext(ctx, &user, "SELECT * from users where user_id=?", userId)
 	if err != nil {
 		return "", err
 	}
 	return user.Login, nil
 }
 
-func (d *dbc) GetFriends(ctx context.Context, id int64) ([]model.User, error) {
-	var friendsUsers []model.User
-	// If RO connection is enabled use it
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
+// GetUserNames Получить имена пользователей по списку id одним запросом
+func (d *dbc) GetUserNames(ctx context.Context, ids []int64) (map[int64]string, error) {
+	users, err := d.getUserFields(ctx, "user_id, name, surname", ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(users))
+	for _, user := range users {
+		names[user.UserId] = user.Name + " " + user.Surname
 	}
+	return names, nil
+}
+
+// GetUserShards Получить шарды пользователей по списку id одним запросом
+func (d *dbc) GetUserShards(ctx context.Context, ids []int64) (map[int64]string, error) {
+	users, err := d.getUserFields(ctx, "user_id, shard_id", ids)
+	if err != nil {
+		return nil, err
+	}
+	shards := make(map[int64]string, len(users))
+	for _, user := range users {
+		shards[user.UserId] = user.ShardId
+	}
+	return shards, nil
+}
+
+// getUserFields Выборка указанных полей пользователей по списку id
+func (d *dbc) getUserFields(ctx context.Context, fields string, ids []int64) ([]model.User, error) {
+	var users []model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	query, args, err := sqlx.In("SELECT "+fields+" from users where user_id IN (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	err = d.readRetry(ctx, userKeys(ids...), func(connection executor) error {
+		users = users[:0]
+		return connection.SelectContext(ctx, &users, connection.Rebind(query), args...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (d *dbc) GetFriends(ctx context.Context, id int64) ([]model.User, error) {
+	connection := d.reader(ctx, userKey(id))
 	// Get user friends
 	friendsId, err := d.getFriendIds(ctx, connection, id)
 	if err != nil {
 		return nil, err
 	}
+	return d.usersByIds(ctx, connection, friendsId)
+}
 
-	if friendsId != nil && len(friendsId) > 0 {
-		queryFriends, args, err := sqlx.In("SELECT * FROM users WHERE users.user_id IN (?)", friendsId)
-		if err != nil {
-			return nil, err
-		}
-		err = connection.SelectContext(ctx, &friendsUsers, d.connection.Rebind(queryFriends), args...)
-		if err != nil {
-			return nil, err
-		}
+// GetFriendUsers Получение списка друзей (взаимных подписок) пользователя
+func (d *dbc) GetFriendUsers(ctx context.Context, id int64) ([]model.User, error) {
+	friendsId, err := d.GetFriendIds(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	return d.usersByIds(ctx, d.reader(ctx, userKey(id)), friendsId)
+}
 
-	return friendsUsers, nil
-
+// usersByIds Пользователи по списку id
+func (d *dbc) usersByIds(ctx context.Context, connection executor, ids []int64) ([]model.User, error) {
+	var users []model.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	queryFriends, args, err := sqlx.In("SELECT * FROM users WHERE users.user_id IN (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	err = connection.SelectContext(ctx, &users, d.conn(ctx).Rebind(queryFriends), args...)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
 }
 
 // Получение id друзей
-func (d *dbc) getFriendIds(ctx context.Context, connection *sqlx.DB, userId int64) ([]int64, error) {
+func (d *dbc) getFriendIds(ctx context.Context, connection executor, userId int64) ([]int64, error) {
 	var friends []model.Friend
 	err := connection.SelectContext(ctx, &friends, "SELECT * from user_friend where user_id=?", userId)
 	if err != nil {
@@ -248,10 +403,7 @@ func (d *dbc) getFriendIds(ctx context.Context, connection *sqlx.DB, userId int6
 
 func (d *dbc) GetUserFollowers(ctx context.Context, id int64) ([]int64, error) {
 	var followers []model.Friend
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
-	}
+	connection := d.reader(ctx, userKey(id))
 	err := connection.SelectContext(ctx, &followers, "SELECT * from user_friend where friend_id=?", id)
 	if err != nil {
 		return nil, err
@@ -265,66 +417,80 @@ func (d *dbc) GetUserFollowers(ctx context.Context, id int64) ([]int64, error) {
 	return friendsId, nil
 }
 
-func (d *dbc) AddFriend(ctx context.Context, user int64, friend int64) (bool, error) {
-	sql := "insert into user_friend (user_id, friend_id) " +
-		"values (?, ?);"
-	stmt, err := d.connection.Prepare(sql)
-	defer stmt.Close()
-	if err != nil {
-		return false, err
-	} // TODO Check for duplicates
-
-	result, err := stmt.ExecContext(ctx, user, friend)
+// IsFollowing Проверка, что пользователь userId подписан на пользователя targetId
+func (d *dbc) IsFollowing(ctx context.Context, userId, targetId int64) (bool, error) {
+	var count int
+	err := d.conn(ctx).GetContext(ctx, &count, "SELECT count(*) from user_friend where user_id=? and friend_id=?", userId, targetId)
 	if err != nil {
 		return false, err
 	}
-	affected, err := result.RowsAffected()
+	return count > 0, nil
+}
+
+func (d *dbc) AddFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	var added bool
+	err := d.retry(ctx, func() error {
+		return d.WithTx(ctx, func(ctx context.Context) (err error) {
+			added, err = d.addFriend(ctx, user, friend)
+			return err
+		})
+	})
+	return added, err
+}
+
+func (d *dbc) addFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	blocked, err := d.IsBlocked(ctx, user, friend)
 	if err != nil {
 		return false, err
 	}
-	if affected > 0 {
-		return true, err
+	if blocked {
+		return false, storage.ErrBlocked
 	}
-	return false, err
-
+	// Повторная подписка игнорируется уникальным индексом
+	return d.follow(ctx, user, friend)
 }
 
 func (d *dbc) DelFriend(ctx context.Context, user int64, friend int64) (bool, error) {
-	sql := "delete from user_friend where user_id = ? and friend_id = ? ;"
-
-	stmt, err := d.connection.Prepare(sql)
-	defer stmt.Close()
-	if err != nil {
-		return false, err
-	}
+	var removed bool
+	err := d.retry(ctx, func() error {
+		return d.WithTx(ctx, func(ctx context.Context) (err error) {
+			removed, err = d.delFriend(ctx, user, friend)
+			return err
+		})
+	})
+	return removed, err
+}
 
-	result, err := stmt.ExecContext(ctx, user, friend)
-	if err != nil {
-		return false, err
-	}
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return false, err
-	}
-	if affected > 0 {
-		return true, err
-	}
-	return false, err
+func (d *dbc) delFriend(ctx context.Context, user int64, friend int64) (bool, error) {
+	return d.unfollow(ctx, user, friend)
 }
 
 // PublishPost Опубликовать запись
-func (d *dbc) PublishPost(ctx context.Context, user int64, title, message string) (model.Post, error) {
-	sql := "insert into posts (user_id, title, message) " +
-		"values (?, ?, ?);"
-	stmt, err := d.connection.Prepare(sql)
+func (d *dbc) PublishPost(ctx context.Context, user int64, title, message, visibility string) (model.Post, error) {
+	var result model.Post
+	err := d.WithTx(ctx, func(ctx context.Context) (err error) {
+		result, err = d.publishPost(ctx, user, title, message, visibility)
+		return err
+	})
+	return result, err
+}
+
+func (d *dbc) publishPost(ctx context.Context, user int64, title, message, visibility string) (model.Post, error) {
+	if visibility == "" {
+		visibility = model.PostVisibilityPublic
+	}
+	sql := "insert into posts (user_id, title, message, visibility) " +
+		"values (?, ?, ?, ?);"
+	stmt, err := d.conn(ctx).Prepare(sql)
 	defer stmt.Close()
 	if err != nil {
 		return model.Post{}, err
 	}
-	result, err := stmt.ExecContext(ctx, user, title, message)
+	result, err := stmt.ExecContext(ctx, user, title, message, visibility)
 	if err != nil {
 		return model.Post{}, err
 	}
+	d.written(userKey(user))
 	postId, err := result.LastInsertId()
 	if err != nil {
 		return model.Post{}, err
@@ -339,40 +505,50 @@ func (d *dbc) PublishPost(ctx context.Context, user int64, title, message string
 
 func (d *dbc) GetPostById(ctx context.Context, postId int64) (model.Post, error) {
 	var post model.Post
-	err := d.connection.GetContext(ctx, &post, "SELECT * from posts where id=?", postId)
+	err := d.conn(ctx).GetContext(ctx, &post, "SELECT * from posts where id=?", postId)
+	if err != nil {
+		return model.Post{}, err
+	}
+	post.Reactions, err = d.getItemReactions(ctx, model.ReactionItemPost, post.Id)
 	if err != nil {
 		return model.Post{}, err
 	}
-	// TODO Cache It!!!
 	return post, nil
 }
 
 // GetPostsByUserId Получение списка постов по id пользователя
-func (d *dbc) GetPostsByUserId(ctx context.Context, userId int64, limit, offset int64) ([]model.Post, error) {
+func (d *dbc) GetPostsByUserId(ctx context.Context, userId int64, visibility []string, limit, offset int64) ([]model.Post, error) {
 	var posts []model.Post
 	var sb strings.Builder
 	var args []interface{}
 
-	sb.WriteString("SELECT * from posts WHERE user_id=? ")
-	args = append(args, userId)
-
-	if offset > 0 {
-		sb.WriteString(" OFFSET ? ")
-		args = append(args, offset)
+	if len(visibility) == 0 {
+		return posts, nil
 	}
+
+	sb.WriteString("SELECT * from posts WHERE user_id=? AND deleted=false AND visibility IN (?) ORDER BY created_at DESC, id DESC ")
+	args = append(args, userId, visibility)
+
 	if limit > 0 {
 		sb.WriteString(" LIMIT ? ")
 		args = append(args, limit)
+		if offset > 0 {
+			sb.WriteString(" OFFSET ? ")
+			args = append(args, offset)
+		}
 	}
-	// If RO connection is enabled use it
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
+	query, args, err := sqlx.In(sb.String(), args...)
+	if err != nil {
+		return nil, err
 	}
-	err := connection.SelectContext(ctx, &posts, sb.String(), args...)
+	connection := d.reader(ctx, userKey(userId))
+	err = connection.SelectContext(ctx, &posts, d.conn(ctx).Rebind(query), args...)
 	if err != nil {
 		return nil, err
 	}
+	if err = d.attachPostReactions(ctx, posts); err != nil {
+		return nil, err
+	}
 	return posts, nil
 }
 
@@ -384,30 +560,42 @@ func (d *dbc) GetFriendsPosts(ctx context.Context, id int64, limit int64) ([]mod
 		if err == nil {
 			err = json.Unmarshal([]byte(result), &posts)
 			if err == nil {
+				// Реакции в кэше могут быть устаревшими, берем актуальные агрегаты
+				if err = d.attachPostReactions(ctx, posts); err != nil {
+					return nil, err
+				}
 				return posts, nil
 			}
 		}
 	}
 	// No cache connection or no feed in cache.
 
-	connection := d.connection
-	if d.roEnable {
-		connection = d.connectionRo
-	}
+	connection := d.reader(ctx, userKey(id))
 	friendsIds, err := d.getFriendIds(ctx, connection, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if friendsIds != nil && len(friendsIds) > 0 {
-		queryFriends, args, err := sqlx.In("SELECT * FROM posts WHERE user_id IN (?) order by created_at desc limit ?", friendsIds, limit)
+		// Публикации только для друзей попадают в ленту, если автор подписан на пользователя в ответ.
+		// Публикации скрытых или заблокированных пользователем авторов (и заблокировавших его) исключаются
+		queryFriends, args, err := sqlx.In("SELECT * FROM posts WHERE user_id IN (?) and deleted=false "+
+			"and (visibility = ? or (visibility = ? and exists "+
+			"(SELECT 1 FROM user_friend r WHERE r.user_id = posts.user_id and r.friend_id = ?))) "+
+			"and not exists (SELECT 1 FROM user_blocks b WHERE (b.user_id = ? and b.target_id = posts.user_id) "+
+			"or (b.user_id = posts.user_id and b.target_id = ? and b.kind = ?)) "+
+			"order by created_at desc limit ?",
+			friendsIds, model.PostVisibilityPublic, model.PostVisibilityFriends, id, id, id, model.BlockKindBlock, limit)
 		if err != nil {
 			return nil, err
 		}
-		err = connection.SelectContext(ctx, &posts, d.connection.Rebind(queryFriends), args...)
+		err = connection.SelectContext(ctx, &posts, d.conn(ctx).Rebind(queryFriends), args...)
 		if err != nil {
 			return nil, err
 		}
+		if err = d.attachPostReactions(ctx, posts); err != nil {
+			return nil, err
+		}
 	}
 	if d.redis != nil {
 		friendPostsJson, err := json.Marshal(posts)
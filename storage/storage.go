@@ -9,15 +9,42 @@ import (
 
 var (
 	ErrInvalidUserOrPassword = errors.New("invalid password or user not found")
+	ErrPostNotFound          = errors.New("post not found")
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrAccessDenied          = errors.New("access denied")
+	ErrMessageNotFound       = errors.New("message not found")
+	ErrInvalidReaction       = errors.New("invalid reaction")
+	ErrDraftNotFound         = errors.New("draft not found")
+	ErrDraftNotEditable      = errors.New("draft already published or cancelled")
+	ErrInvalidFriendRequest  = errors.New("invalid friend request")
+	ErrFriendRequestNotFound = errors.New("friend request not found")
+	ErrFriendRequestExists   = errors.New("friend request already sent")
+	ErrFriendRequestClosed   = errors.New("friend request already answered")
+	ErrAlreadyFriends        = errors.New("users are already friends")
+	ErrInvalidBlock          = errors.New("invalid block")
+	ErrBlocked               = errors.New("user is blocked")
+	ErrInvalidSearch         = errors.New("invalid search query")
+	ErrChatNotFound          = errors.New("chat not found")
+	ErrChatClosed            = errors.New("chat is closed")
+	ErrNotParticipant        = errors.New("user is not participant of this chat")
+	ErrInvalidChatRole       = errors.New("invalid chat role")
+	ErrDialogChat            = errors.New("operation is not allowed in dialog")
 )
 
 type UserService interface {
+	// WithTx Выполнение fn в транзакции: методы хранилища, вызванные с контекстом fn, выполняются в ней
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Close Остановить фоновые проверки и закрыть подключения
+	Close() error
 	// GetById Получение информации о пользователе по id
 	GetById(ctx context.Context, id int64) (model.User, error)
+	// GetByIds Получение информации о пользователях по списку id
+	GetByIds(ctx context.Context, ids []int64) ([]model.User, error)
 	// GetByLogin Поиск пользователя по логину
 	GetByLogin(ctx context.Context, login string) (model.User, error)
-	// GetUsers Поиск пользователей с фильтрацией по полям
-	GetUsers(ctx context.Context, filter map[string]string, order map[string]string, offset, limit int) ([]model.User, error)
+	// SearchUsers Поиск пользователей по фильтрам с общим количеством и keyset пагинацией (или по смещению).
+	// Пользователи, заблокированные viewer (или заблокировавшие его), исключаются
+	SearchUsers(ctx context.Context, viewer int64, search model.UserSearch) (model.UserSearchResult, error)
 	// ValidateUser Проверка пароля пользователя
 	ValidateUser(ctx context.Context, login string, password string) (bool, error)
 	// CheckPasswordHash Сравнение хэша с паролем
@@ -26,47 +53,162 @@ type UserService interface {
 	Create(ctx context.Context, user model.User) (model.User, error)
 	// Update Обновить пользователя
 	Update(ctx context.Context, user model.User, fieldsForUpdating map[string]struct{}) (model.User, error)
-	// GetFriends Получение списка пользователей
+	// GetFriends Получение списка пользователей, на которых подписан пользователь
 	GetFriends(ctx context.Context, id int64) ([]model.User, error)
+	// GetFriendUsers Получение списка друзей (взаимных подписок) пользователя
+	GetFriendUsers(ctx context.Context, id int64) ([]model.User, error)
+	// GetFriendIds Получение id друзей (взаимных подписок) пользователя
+	GetFriendIds(ctx context.Context, id int64) ([]int64, error)
+	// AreFriends Проверка, что пользователи являются друзьями
+	AreFriends(ctx context.Context, userId, otherId int64) (bool, error)
 	// GetUserFollowers Получить список followers пользователей
 	GetUserFollowers(ctx context.Context, id int64) ([]int64, error)
-	// AddFriend Добавить пользователя
+	// GetFollowers Получить страницу подписчиков пользователя
+	GetFollowers(ctx context.Context, userId int64, limit, offset int64) ([]model.User, error)
+	// GetFollowing Получить страницу пользователей, на которых подписан пользователь
+	GetFollowing(ctx context.Context, userId int64, limit, offset int64) ([]model.User, error)
+	// GetMutualFriends Получить страницу общих друзей пользователей
+	GetMutualFriends(ctx context.Context, userId, otherId int64, limit, offset int64) ([]model.User, error)
+	// CountMutualFriends Количество общих друзей пользователей
+	CountMutualFriends(ctx context.Context, userId, otherId int64) (int64, error)
+	// IsFollowing Проверка, что пользователь userId подписан на пользователя targetId
+	IsFollowing(ctx context.Context, userId, targetId int64) (bool, error)
+	// AddFriend Подписаться на пользователя
 	AddFriend(ctx context.Context, user int64, friend int64) (bool, error)
-	// DelFriend Удалить пользователя из друзей
+	// DelFriend Отписаться от пользователя
 	DelFriend(ctx context.Context, user int64, friend int64) (bool, error)
+	// RemoveFriend Удалить пользователя из друзей (удаляет подписки в обе стороны)
+	RemoveFriend(ctx context.Context, user int64, friend int64) (bool, error)
+	// SendFriendRequest Отправить заявку в друзья. Встречная заявка принимается автоматически
+	SendFriendRequest(ctx context.Context, from, to int64) (model.FriendRequest, error)
+	// GetFriendRequests Получить входящие (incoming) или исходящие заявки пользователя (опционально по статусу)
+	GetFriendRequests(ctx context.Context, userId int64, incoming bool, status string, limit, offset int64) ([]model.FriendRequest, error)
+	// AcceptFriendRequest Принять входящую заявку, пользователи становятся друзьями
+	AcceptFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error)
+	// DeclineFriendRequest Отклонить входящую заявку
+	DeclineFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error)
+	// CancelFriendRequest Отозвать исходящую заявку
+	CancelFriendRequest(ctx context.Context, requestId, userId int64) (model.FriendRequest, error)
+	// BlockUser Заблокировать (kind=block) или скрыть (kind=mute) пользователя
+	BlockUser(ctx context.Context, userId, targetId int64, kind string) (model.UserBlock, error)
+	// UnblockUser Снять блокировку (или скрытие) пользователя
+	UnblockUser(ctx context.Context, userId, targetId int64, kind string) (bool, error)
+	// GetBlocks Получить список заблокированных (скрытых) пользователей (опционально по типу)
+	GetBlocks(ctx context.Context, userId int64, kind string, limit, offset int64) ([]model.UserBlock, error)
+	// IsBlocked Проверка, что один из пользователей заблокировал другого
+	IsBlocked(ctx context.Context, userId, otherId int64) (bool, error)
+	// GetBlockedByIds Получить id пользователей, заблокировавших или скрывших пользователя
+	GetBlockedByIds(ctx context.Context, targetId int64) ([]int64, error)
+	// GetBlockedIds Получить id пользователей, с которыми у пользователя есть блокировка в любую сторону
+	GetBlockedIds(ctx context.Context, userId int64) ([]int64, error)
+	// GetSuggestionCandidates Получить кандидатов в друзья среди друзей друзей, отобранных по оценке без учета интересов
+	GetSuggestionCandidates(ctx context.Context, userId int64, limit int64) ([]model.FriendSuggestion, error)
 	// PublishPost Опубликовать запись
-	PublishPost(ctx context.Context, user int64, title, message string) (model.Post, error)
+	PublishPost(ctx context.Context, user int64, title, message, visibility string) (model.Post, error)
 	// GetFriendsPosts Получение ленты друзей
 	GetFriendsPosts(ctx context.Context, id int64, limit int64) ([]model.Post, error)
-	// GetPostsByUserId Получить список постов пользователя
-	GetPostsByUserId(ctx context.Context, userId int64, limit, offset int64) ([]model.Post, error)
+	// GetPostsByUserId Получить список постов пользователя с указанными уровнями видимости
+	GetPostsByUserId(ctx context.Context, userId int64, visibility []string, limit, offset int64) ([]model.Post, error)
 	// GetPostById Получить post по его Id
 	GetPostById(ctx context.Context, postId int64) (model.Post, error)
 	// GetUserName Получить имя пользователя
 	GetUserName(ctx context.Context, userId int64) (string, error)
 	// GetLogin Получить логин пользователя
 	GetLogin(ctx context.Context, userId int64) (string, error)
+	// GetUserNames Получить имена пользователей по списку id (отсутствующие пользователи пропускаются)
+	GetUserNames(ctx context.Context, ids []int64) (map[int64]string, error)
+	// GetUserShards Получить шарды пользователей по списку id (отсутствующие пользователи пропускаются)
+	GetUserShards(ctx context.Context, ids []int64) (map[int64]string, error)
+	// AddComment Добавить комментарий к публикации
+	AddComment(ctx context.Context, postId, userId int64, message string) (model.Comment, error)
+	// GetComments Получить список комментариев к публикации
+	GetComments(ctx context.Context, postId int64, limit, offset int64) ([]model.Comment, error)
+	// GetCommentById Получить комментарий по его Id
+	GetCommentById(ctx context.Context, commentId int64) (model.Comment, error)
+	// DelComment Удалить комментарий (автором комментария или автором публикации)
+	DelComment(ctx context.Context, commentId, userId int64) (bool, error)
+	// ReactPost Поставить (или заменить) реакцию на публикацию
+	ReactPost(ctx context.Context, postId, userId int64, reaction string) (model.ReactionCounts, error)
+	// UnreactPost Убрать реакцию с публикации
+	UnreactPost(ctx context.Context, postId, userId int64) (model.ReactionCounts, error)
+	// GetPostsReactions Получить агрегированные реакции для списка публикаций
+	GetPostsReactions(ctx context.Context, postIds []int64) (map[int64]model.ReactionCounts, error)
+	// SaveDraft Сохранить новый черновик (запланированную публикацию, если задан publishAt)
+	SaveDraft(ctx context.Context, userId int64, draft model.DraftPojo) (model.Draft, error)
+	// UpdateDraft Изменить черновик пользователя
+	UpdateDraft(ctx context.Context, draftId, userId int64, draft model.DraftPojo) (model.Draft, error)
+	// GetDraftById Получить черновик пользователя
+	GetDraftById(ctx context.Context, draftId, userId int64) (model.Draft, error)
+	// GetDrafts Получить черновики пользователя (опционально по статусу)
+	GetDrafts(ctx context.Context, userId int64, status string, limit, offset int64) ([]model.Draft, error)
+	// CancelDraft Отменить черновик или запланированную публикацию
+	CancelDraft(ctx context.Context, draftId, userId int64) (bool, error)
+	// GetDueDrafts Получить запланированные публикации, время которых наступило
+	GetDueDrafts(ctx context.Context, now time.Time, limit int64) ([]model.Draft, error)
+	// ClaimDraft Захватить черновик для публикации (переводит в статус published), false - уже захвачен или отменен
+	ClaimDraft(ctx context.Context, draftId int64) (bool, error)
+	// SetDraftPost Сохранить id публикации, созданной из черновика
+	SetDraftPost(ctx context.Context, draftId, postId int64) error
+	// Topology Текущая топология кластера БД
+	Topology() model.StorageTopology
+	// ReconcileCounters Пересчитать денормализованные счетчики (комментарии, реакции на публикации и сообщения, подписки), возвращает количество исправленных
+	ReconcileCounters(ctx context.Context) (int64, error)
 }
 
 type ChatsService interface {
+	// WithTx Выполнение fn в транзакции: методы хранилища, вызванные с контекстом fn, выполняются в ней
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// Close Остановить фоновые проверки и закрыть подключения
+	Close() error
 	// GetChat Получить информацию о чате по id
 	GetChat(ctx context.Context, chatId int64) (model.Chat, error)
+	// ChatAccess Проверка доступа к чату. Возвращает ErrChatNotFound или ErrAccessDenied, если пользователь не участник
+	ChatAccess(ctx context.Context, chatId, userId int64) (model.Chat, model.ChatParticipant, error)
 	// UserGetChats Получить список чатов
 	UserGetChats(ctx context.Context, userId int64) ([]model.Chat, error)
-	// ChatCreate Создать новый чат
-	ChatCreate(ctx context.Context, title string, participants ...int64) (model.Chat, error)
-	// ChatDelete Удалить чат
-	ChatDelete(ctx context.Context, chatId int64) (model.Chat, error)
+	// ChatCreate Создать новый чат от имени createdBy. Чат с одним собеседником является диалогом:
+	// если открытый диалог с ним уже есть, возвращается существующий, вместо закрытого создается новый.
+	// Возвращает ErrBlocked, если участники заблокировали друг друга
+	ChatCreate(ctx context.Context, createdBy int64, title string, participants ...int64) (model.Chat, error)
+	// ChatGetDialog Получить диалог двух пользователей. Возвращает ErrChatNotFound, если диалога нет
+	ChatGetDialog(ctx context.Context, userId, peerId int64) (model.Chat, error)
+	// ChatDelete Удалить чат вместе с сообщениями (только владельцем чата, в том числе закрытый)
+	ChatDelete(ctx context.Context, chatId, userId int64) (model.Chat, error)
 	// ChatGetParticipants Получить список участников чата
 	ChatGetParticipants(ctx context.Context, chatId int64) ([]int64, error)
-	// ChatAddParticipants Добавить участников чата
-	ChatAddParticipants(ctx context.Context, chatId int64, userIds []int64) error
-	// ChatLeave Покинуть чат
+	// ChatGetMembers Получить участников чата с их ролями
+	ChatGetMembers(ctx context.Context, chatId int64) ([]model.ChatParticipant, error)
+	// ChatGetParticipant Получить участника чата. Возвращает ErrNotParticipant, если пользователь не участник
+	ChatGetParticipant(ctx context.Context, chatId, userId int64) (model.ChatParticipant, error)
+	// ChatAddParticipants Добавить участников в чат от имени участника userId (по настройке приглашения).
+	// Возвращает ErrBlocked, если новые участники заблокированы кем-то из чата
+	ChatAddParticipants(ctx context.Context, chatId, userId int64, userIds []int64) error
+	// ChatRemoveParticipants Исключить участников с младшей ролью (администратором или владельцем)
+	ChatRemoveParticipants(ctx context.Context, chatId, userId int64, userIds []int64) error
+	// ChatLeave Покинуть чат (в том числе закрытый)
 	ChatLeave(ctx context.Context, chatId, userId int64) error
-	// MessageSave Отправить сообщение в чат
+	// ChatSetRole Сменить роль участника (только владельцем чата)
+	ChatSetRole(ctx context.Context, chatId, userId, targetId int64, role string) error
+	// ChatUpdateSettings Изменить настройки чата (только владельцем чата)
+	ChatUpdateSettings(ctx context.Context, chatId, userId int64, settings model.ChatSettings) (model.Chat, error)
+	// ChatRename Переименовать чат (по настройке переименования)
+	ChatRename(ctx context.Context, chatId, userId int64, title string) (model.Chat, error)
+	// ChatClose Закрыть чат (только владельцем чата)
+	ChatClose(ctx context.Context, chatId, userId int64) (model.Chat, error)
+	// MessageSave Отправить сообщение в чат. Писать могут участники с ролью не младше указанной в настройках
+	// (иначе ErrAccessDenied), в закрытый чат - ErrChatClosed, в диалоге с заблокировавшим пользователем - ErrBlocked
 	MessageSave(ctx context.Context, chatId, userFromId int64, date time.Time, message string) (model.Message, error)
 	// ChatMessages Получение списка сообщений из чата
 	ChatMessages(ctx context.Context, chatId int64, limit, offset int64) ([]model.Message, error)
 	// MessageGet Получить сообщение по id
 	MessageGet(ctx context.Context, id int64) (model.Message, error)
+	// ChatMessage Получить сообщение чата chatId, доступное участнику userId. Возвращает ErrMessageNotFound,
+	// если сообщения нет в чате, ErrChatNotFound или ErrAccessDenied, если пользователь не участник
+	ChatMessage(ctx context.Context, chatId, messageId, userId int64) (model.Message, error)
+	// ReactMessage Поставить (или заменить) реакцию на сообщение (участником открытого чата)
+	ReactMessage(ctx context.Context, messageId, userId int64, reaction string) (model.ReactionCounts, error)
+	// UnreactMessage Убрать реакцию с сообщения (участником открытого чата)
+	UnreactMessage(ctx context.Context, messageId, userId int64) (model.ReactionCounts, error)
+	// GetMessagesReactions Получить агрегированные реакции для списка сообщений
+	GetMessagesReactions(ctx context.Context, messageIds []int64) (map[int64]model.ReactionCounts, error)
 }
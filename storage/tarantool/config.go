@@ -0,0 +1,13 @@
+package tarantool
+
+import "time"
+
+type Config struct {
+	Enable    bool          `env:"TARANTOOL_ENABLE,default=false"`
+	Address   string        `env:"TARANTOOL_ADDRESS,default=localhost:3301"`
+	User      string        `env:"TARANTOOL_USER"`
+	Password  string        `env:"TARANTOOL_PASSWORD"`
+	Timeout   time.Duration `env:"TARANTOOL_TIMEOUT,default=1s"`
+	UserSpace uint32        `env:"TARANTOOL_USERS_SPACE,default=513"`
+	MaxScan   int           `env:"TARANTOOL_SEARCH_MAX_SCAN,default=10000"`
+}
@@ -0,0 +1,72 @@
+package tarantool
+
+import (
+	"context"
+	"fmt"
+	"github.com/tarantool/go-tarantool"
+)
+
+// Iterator Тип итератора выборки по индексу
+type Iterator uint32
+
+const (
+	IterEq  = Iterator(tarantool.IterEq)
+	IterAll = Iterator(tarantool.IterAll)
+	IterGe  = Iterator(tarantool.IterGe)
+)
+
+// Conn Выборка кортежей из спейсов Tarantool
+type Conn interface {
+	// Select Выборка кортежей спейса space по индексу index начиная с ключа key
+	Select(ctx context.Context, space, index uint32, offset, limit uint32, iterator Iterator, key []interface{}) ([][]interface{}, error)
+	// Close Закрыть соединение
+	Close() error
+}
+
+// client Соединение с Tarantool через go-tarantool. При потере соединения клиент переподключается сам
+type client struct {
+	conn *tarantool.Connection
+}
+
+// Dial Подключение к Tarantool
+func Dial(config Config) (Conn, error) {
+	conn, err := tarantool.Connect(config.Address, tarantool.Opts{
+		User:      config.User,
+		Pass:      config.Password,
+		Timeout:   config.Timeout,
+		Reconnect: config.Timeout,
+		// Номера спейсов и индексов заданы в конфигурации, схема не нужна
+		SkipSchema: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &client{conn: conn}, nil
+}
+
+func (c *client) Select(ctx context.Context, space, index uint32, offset, limit uint32, iterator Iterator, key []interface{}) ([][]interface{}, error) {
+	request := tarantool.NewSelectRequest(space).
+		Index(index).
+		Offset(offset).
+		Limit(limit).
+		Iterator(uint32(iterator)).
+		Key(key).
+		Context(ctx)
+	response, err := c.conn.Do(request).Get()
+	if err != nil {
+		return nil, err
+	}
+	tuples := make([][]interface{}, 0, len(response.Data))
+	for _, item := range response.Data {
+		tuple, ok := item.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("tarantool: unexpected tuple %T", item)
+		}
+		tuples = append(tuples, tuple)
+	}
+	return tuples, nil
+}
+
+func (c *client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,165 @@
+package tarantool
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Memory Хранение спейсов в памяти процесса вместо Tarantool (для тестов и локального запуска).
+// Поддерживаются итераторы EQ, GE и ALL по индексам из перечисленных полей
+type Memory struct {
+	m      sync.RWMutex
+	spaces map[uint32]*memorySpace
+}
+
+type memorySpace struct {
+	indexes map[uint32][]int
+	tuples  [][]interface{}
+}
+
+// NewMemory Создание пустого хранилища в памяти
+func NewMemory() *Memory {
+	return &Memory{spaces: make(map[uint32]*memorySpace)}
+}
+
+// CreateIndex Создание индекса index спейса space по полям fields (номера полей с 0). Индекс 0 - первичный
+func (s *Memory) CreateIndex(space, index uint32, fields ...int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	sp, ok := s.spaces[space]
+	if !ok {
+		sp = &memorySpace{indexes: make(map[uint32][]int)}
+		s.spaces[space] = sp
+	}
+	sp.indexes[index] = fields
+}
+
+// Replace Вставка или замена кортежа по первичному индексу
+func (s *Memory) Replace(space uint32, tuple []interface{}) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	sp, ok := s.spaces[space]
+	if !ok {
+		return
+	}
+	primary := sp.indexes[0]
+	for i, existing := range sp.tuples {
+		if compareTuples(existing, tuple, primary) == 0 {
+			sp.tuples[i] = tuple
+			return
+		}
+	}
+	sp.tuples = append(sp.tuples, tuple)
+}
+
+func (s *Memory) Select(_ context.Context, space, index uint32, offset, limit uint32, iterator Iterator, key []interface{}) ([][]interface{}, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	sp, ok := s.spaces[space]
+	if !ok {
+		return nil, nil
+	}
+	fields, ok := sp.indexes[index]
+	if !ok {
+		return nil, nil
+	}
+
+	tuples := make([][]interface{}, len(sp.tuples))
+	copy(tuples, sp.tuples)
+	sort.SliceStable(tuples, func(i, j int) bool {
+		return compareTuples(tuples[i], tuples[j], fields) < 0
+	})
+
+	var result [][]interface{}
+	skipped := uint32(0)
+	for _, tuple := range tuples {
+		c := compareKey(tuple, key, fields)
+		switch iterator {
+		case IterEq:
+			if c != 0 {
+				continue
+			}
+		case IterGe:
+			if c < 0 {
+				continue
+			}
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		result = append(result, tuple)
+		if uint32(len(result)) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *Memory) Close() error {
+	return nil
+}
+
+// compareKey Сравнение кортежа с (частичным) ключом по полям индекса
+func compareKey(tuple []interface{}, key []interface{}, fields []int) int {
+	for i, value := range key {
+		if i >= len(fields) {
+			break
+		}
+		if c := compareValues(field(tuple, fields[i]), value); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareTuples(a, b []interface{}, fields []int) int {
+	for _, f := range fields {
+		if c := compareValues(field(a, f), field(b, f)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func field(tuple []interface{}, f int) interface{} {
+	if f < len(tuple) {
+		return tuple[f]
+	}
+	return nil
+}
+
+func compareValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		if b, ok := b.(int64); ok {
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+	case nil:
+		if b == nil {
+			return 0
+		}
+		return -1
+	}
+	if b == nil {
+		return 1
+	}
+	return 0
+}
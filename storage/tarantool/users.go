@@ -0,0 +1,316 @@
+package tarantool
+
+import (
+	"context"
+	"database/sql"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"strings"
+)
+
+// Индексы спейса пользователей (см. docker/tarantool-replication/config/tarantool/up.lua)
+const (
+	indexPrimary = 0
+	indexLogin   = 1
+	indexName    = 3
+	indexSurname = 4
+
+	scanBatch = 1000
+)
+
+// Поля кортежа пользователя в порядке колонок репликатора
+const (
+	fieldUserId = iota
+	fieldLogin
+	fieldEmail
+	fieldPhone
+	fieldPassword
+	fieldName
+	fieldSurname
+	fieldAge
+	fieldSex
+	fieldCountry
+	fieldCity
+	fieldInterests
+	fieldShardId
+	fieldFollowersCount
+	fieldFollowingCount
+	fieldFriendsCount
+)
+
+// dbc Чтение пользователей из реплики в Tarantool. Остальные методы и промахи обслуживает next
+type dbc struct {
+	storage.UserService
+	conn   Conn
+	config Config
+	logger *logrus.Entry
+}
+
+// New Подключение к Tarantool и создание хранилища поверх next
+func New(cfg Config, next storage.UserService, logger *logrus.Logger) (storage.UserService, error) {
+	conn, err := Dial(cfg)
+	if err != nil {
+		return nil, err
+	}
+	logger.WithField("role", "tarantool").Infof("Using tarantool %s for user reads", cfg.Address)
+	return NewWithConn(cfg, conn, next, logger), nil
+}
+
+// NewWithConn Создание хранилища с готовым соединением (например, Memory)
+func NewWithConn(cfg Config, conn Conn, next storage.UserService, logger *logrus.Logger) storage.UserService {
+	if cfg.MaxScan <= 0 {
+		cfg.MaxScan = scanBatch
+	}
+	return &dbc{
+		UserService: next,
+		conn:        conn,
+		config:      cfg,
+		logger:      logger.WithField("role", "tarantool"),
+	}
+}
+
+// Close Закрытие соединения с Tarantool и хранилища next
+func (d *dbc) Close() error {
+	if err := d.conn.Close(); err != nil {
+		d.logger.WithError(err).Warn("tarantool close failed")
+	}
+	return d.UserService.Close()
+}
+
+// GetById Получение пользователя по id. Если пользователь еще не реплицирован, читаем из next
+func (d *dbc) GetById(ctx context.Context, id int64) (model.User, error) {
+	user, err := d.getOne(ctx, indexPrimary, id)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			d.logger.WithError(err).Warn("get user by id failed, fallback")
+		}
+		return d.UserService.GetById(ctx, id)
+	}
+	return user, nil
+}
+
+// GetByLogin Поиск пользователя по логину. Если пользователь еще не реплицирован, читаем из next
+func (d *dbc) GetByLogin(ctx context.Context, login string) (model.User, error) {
+	user, err := d.getOne(ctx, indexLogin, login)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			d.logger.WithError(err).Warn("get user by login failed, fallback")
+		}
+		return d.UserService.GetByLogin(ctx, login)
+	}
+	return user, nil
+}
+
+// SearchUsers Поиск по префиксу имени и (или) фамилии с сортировкой по id, имени или фамилии.
+// Прочие фильтры, а также выборки больше MaxScan кортежей обслуживает next
+func (d *dbc) SearchUsers(ctx context.Context, viewer int64, search model.UserSearch) (model.UserSearchResult, error) {
+	if search.Sort == "" {
+		search.Sort = model.SearchSortId
+	}
+	if !searchSupported(search) {
+		return d.UserService.SearchUsers(ctx, viewer, search)
+	}
+
+	found, complete, err := d.scanPrefix(ctx, search)
+	if err != nil {
+		d.logger.WithError(err).Warn("search users failed, fallback")
+		return d.UserService.SearchUsers(ctx, viewer, search)
+	}
+	if !complete {
+		return d.UserService.SearchUsers(ctx, viewer, search)
+	}
+
+	if viewer > 0 && len(found) > 0 {
+		blocked, err := d.UserService.GetBlockedIds(ctx, viewer)
+		if err != nil {
+			return model.UserSearchResult{}, err
+		}
+		found = excludeUsers(found, blocked)
+	}
+
+	sortUsers(found, search)
+	result := model.UserSearchResult{Count: int64(len(found)), Users: []model.User{}}
+	skip := search.Offset
+	for _, user := range found {
+		if search.After != nil && !afterCursor(user, search) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		result.Users = append(result.Users, user)
+		if search.Limit > 0 && int64(len(result.Users)) == search.Limit {
+			result.NextCursor = search.CursorFor(user).Encode()
+			break
+		}
+	}
+	return result, nil
+}
+
+// getOne Выборка одного пользователя по уникальному индексу
+func (d *dbc) getOne(ctx context.Context, index uint32, key interface{}) (model.User, error) {
+	tuples, err := d.conn.Select(ctx, d.config.UserSpace, index, 0, 1, IterEq, []interface{}{key})
+	if err != nil {
+		return model.User{}, err
+	}
+	if len(tuples) == 0 {
+		return model.User{}, sql.ErrNoRows
+	}
+	return tupleToUser(tuples[0]), nil
+}
+
+// scanPrefix Обход индекса по префиксу пачками. complete=false, если совпадений больше MaxScan
+func (d *dbc) scanPrefix(ctx context.Context, search model.UserSearch) ([]model.User, bool, error) {
+	index, prefix := uint32(indexName), search.Name
+	if prefix == "" {
+		index, prefix = indexSurname, search.Surname
+	}
+
+	var users []model.User
+	var offset uint32
+	scanned := 0
+	for {
+		tuples, err := d.conn.Select(ctx, d.config.UserSpace, index, offset, scanBatch, IterGe, []interface{}{prefix})
+		if err != nil {
+			return nil, false, err
+		}
+		for _, tuple := range tuples {
+			user := tupleToUser(tuple)
+			value := user.Name
+			if index == indexSurname {
+				value = user.Surname
+			}
+			if !hasPrefixFold(value, prefix) {
+				return users, true, nil
+			}
+			scanned++
+			if scanned > d.config.MaxScan {
+				return nil, false, nil
+			}
+			if search.Surname != "" && !hasPrefixFold(user.Surname, search.Surname) {
+				continue
+			}
+			users = append(users, user)
+		}
+		if len(tuples) < scanBatch {
+			return users, true, nil
+		}
+		offset += uint32(len(tuples))
+	}
+}
+
+// searchSupported Поиск можно выполнить по индексам Tarantool
+func searchSupported(search model.UserSearch) bool {
+	if search.Name == "" && search.Surname == "" {
+		return false
+	}
+	if search.City != "" || search.Country != "" || search.Sex != "" ||
+		search.AgeFrom > 0 || search.AgeTo > 0 || search.Interests != "" {
+		return false
+	}
+	switch search.Sort {
+	case model.SearchSortId, model.SearchSortName, model.SearchSortSurname:
+		return true
+	}
+	return false
+}
+
+// sortUsers Сортировка по полю поиска и id (как ORDER BY в MySQL)
+func sortUsers(users []model.User, search model.UserSearch) {
+	sort.Slice(users, func(i, j int) bool {
+		c := compareUsers(users[i], search.CursorFor(users[j]), search.Sort)
+		if search.Desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// afterCursor Пользователь находится после позиции курсора
+func afterCursor(user model.User, search model.UserSearch) bool {
+	c := compareUsers(user, *search.After, search.Sort)
+	if search.Desc {
+		return c < 0
+	}
+	return c > 0
+}
+
+// compareUsers Сравнение пользователя с позицией (значение поля сортировки, id)
+func compareUsers(user model.User, cursor model.SearchCursor, sortField string) int {
+	if sortField != model.SearchSortId {
+		value := model.UserSearch{Sort: sortField}.CursorFor(user).Value
+		if c := strings.Compare(strings.ToLower(value), strings.ToLower(cursor.Value)); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case user.UserId < cursor.Id:
+		return -1
+	case user.UserId > cursor.Id:
+		return 1
+	}
+	return 0
+}
+
+func excludeUsers(users []model.User, ids []int64) []model.User {
+	if len(ids) == 0 {
+		return users
+	}
+	exclude := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		exclude[id] = struct{}{}
+	}
+	result := users[:0]
+	for _, user := range users {
+		if _, ok := exclude[user.UserId]; !ok {
+			result = append(result, user)
+		}
+	}
+	return result
+}
+
+func hasPrefixFold(value, prefix string) bool {
+	return len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix)
+}
+
+// tupleToUser Преобразование кортежа спейса в пользователя
+func tupleToUser(tuple []interface{}) model.User {
+	return model.User{
+		UserId:         tupleInt(tuple, fieldUserId),
+		Login:          tupleString(tuple, fieldLogin),
+		Email:          tupleString(tuple, fieldEmail),
+		Phone:          tupleString(tuple, fieldPhone),
+		PasswordHash:   tupleString(tuple, fieldPassword),
+		Name:           tupleString(tuple, fieldName),
+		Surname:        tupleString(tuple, fieldSurname),
+		Age:            int(tupleInt(tuple, fieldAge)),
+		Sex:            tupleString(tuple, fieldSex),
+		Country:        tupleString(tuple, fieldCountry),
+		City:           tupleString(tuple, fieldCity),
+		Interests:      tupleString(tuple, fieldInterests),
+		ShardId:        tupleString(tuple, fieldShardId),
+		FollowersCount: tupleInt(tuple, fieldFollowersCount),
+		FollowingCount: tupleInt(tuple, fieldFollowingCount),
+		FriendsCount:   tupleInt(tuple, fieldFriendsCount),
+	}
+}
+
+func tupleString(tuple []interface{}, f int) string {
+	s, _ := field(tuple, f).(string)
+	return s
+}
+
+func tupleInt(tuple []interface{}, f int) int64 {
+	switch v := field(tuple, f).(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case int:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,192 @@
+package tarantool
+
+import (
+	"context"
+	"github.com/basicus/hla-course/model"
+	"github.com/basicus/hla-course/storage"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"testing"
+)
+
+const testSpace = 513
+
+// nextStub Основное хранилище, считающее обращения при промахе
+type nextStub struct {
+	storage.UserService
+	users   map[int64]model.User
+	blocked []int64
+	calls   int
+}
+
+func (n *nextStub) GetById(_ context.Context, id int64) (model.User, error) {
+	n.calls++
+	return n.users[id], nil
+}
+
+func (n *nextStub) GetByLogin(_ context.Context, login string) (model.User, error) {
+	n.calls++
+	for _, user := range n.users {
+		if user.Login == login {
+			return user, nil
+		}
+	}
+	return model.User{}, nil
+}
+
+func (n *nextStub) SearchUsers(_ context.Context, _ int64, _ model.UserSearch) (model.UserSearchResult, error) {
+	n.calls++
+	return model.UserSearchResult{}, nil
+}
+
+func (n *nextStub) GetBlockedIds(_ context.Context, _ int64) ([]int64, error) {
+	return n.blocked, nil
+}
+
+func userTuple(id int64, login, name, surname, shard string) []interface{} {
+	return []interface{}{id, login, login + "@mail.ru", "", "hash", name, surname, int64(30), "male", "Russia", "Moscow", "",
+		shard, int64(5), int64(4), int64(3)}
+}
+
+// newUsersMemory Спейс пользователей с индексами как в up.lua
+func newUsersMemory() *Memory {
+	memory := NewMemory()
+	memory.CreateIndex(testSpace, indexPrimary, fieldUserId)
+	memory.CreateIndex(testSpace, indexLogin, fieldLogin)
+	memory.CreateIndex(testSpace, indexName, fieldName, fieldUserId)
+	memory.CreateIndex(testSpace, indexSurname, fieldSurname, fieldUserId)
+	memory.Replace(testSpace, userTuple(1, "ivan", "Ivan", "Petrov", "shard-1"))
+	memory.Replace(testSpace, userTuple(2, "ivanna", "Ivanna", "Sidorova", "shard-2"))
+	memory.Replace(testSpace, userTuple(3, "igor", "Igor", "Ivanov", "shard-1"))
+	memory.Replace(testSpace, userTuple(4, "ivo", "Ivo", "Petrenko", "shard-2"))
+	return memory
+}
+
+func newTestUsers(next storage.UserService, maxScan int) storage.UserService {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	return NewWithConn(Config{UserSpace: testSpace, MaxScan: maxScan}, newUsersMemory(), next, logger)
+}
+
+func TestGetById(t *testing.T) {
+	next := &nextStub{users: map[int64]model.User{10: {UserId: 10, Login: "mysql"}}}
+	users := newTestUsers(next, 0)
+	ctx := context.Background()
+
+	user, err := users.GetById(ctx, 1)
+	if err != nil {
+		t.Fatalf("get: %s", err)
+	}
+	if user.Login != "ivan" || user.ShardId != "shard-1" || user.PasswordHash != "hash" || user.Age != 30 ||
+		user.FollowersCount != 5 || user.FollowingCount != 4 || user.FriendsCount != 3 {
+		t.Fatalf("unexpected user %+v", user)
+	}
+	if next.calls != 0 {
+		t.Fatalf("storage called %d times", next.calls)
+	}
+
+	// Пользователь еще не реплицирован
+	user, err = users.GetById(ctx, 10)
+	if err != nil {
+		t.Fatalf("get fallback: %s", err)
+	}
+	if user.Login != "mysql" || next.calls != 1 {
+		t.Fatalf("expected fallback, got %+v after %d calls", user, next.calls)
+	}
+}
+
+func TestGetByLogin(t *testing.T) {
+	next := &nextStub{}
+	users := newTestUsers(next, 0)
+
+	user, err := users.GetByLogin(context.Background(), "ivanna")
+	if err != nil {
+		t.Fatalf("get: %s", err)
+	}
+	if user.UserId != 2 || next.calls != 0 {
+		t.Fatalf("unexpected user %+v after %d calls", user, next.calls)
+	}
+	if _, err = users.GetByLogin(context.Background(), "unknown"); err != nil || next.calls != 1 {
+		t.Fatalf("expected fallback, got %v after %d calls", err, next.calls)
+	}
+}
+
+func userIds(users []model.User) []int64 {
+	ids := make([]int64, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.UserId)
+	}
+	return ids
+}
+
+func TestSearchUsersByNamePrefix(t *testing.T) {
+	next := &nextStub{blocked: []int64{4}}
+	users := newTestUsers(next, 0)
+	ctx := context.Background()
+
+	search := model.UserSearch{Name: "Iv", Sort: model.SearchSortName, Limit: 1}
+	result, err := users.SearchUsers(ctx, 7, search)
+	if err != nil {
+		t.Fatalf("search: %s", err)
+	}
+	if next.calls != 0 {
+		t.Fatalf("storage called %d times", next.calls)
+	}
+	// Ivo заблокирован, Igor не подходит по префиксу
+	if result.Count != 2 || len(result.Users) != 1 || result.Users[0].UserId != 1 || result.NextCursor == "" {
+		t.Fatalf("unexpected first page %+v", result)
+	}
+
+	search.After, err = model.DecodeSearchCursor(result.NextCursor)
+	if err != nil {
+		t.Fatalf("cursor: %s", err)
+	}
+	result, err = users.SearchUsers(ctx, 7, search)
+	if err != nil {
+		t.Fatalf("search: %s", err)
+	}
+	if ids := userIds(result.Users); len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("unexpected second page %v", ids)
+	}
+}
+
+func TestSearchUsersOffset(t *testing.T) {
+	next := &nextStub{}
+	users := newTestUsers(next, 0)
+
+	result, err := users.SearchUsers(context.Background(), 0, model.UserSearch{Name: "Iv", Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("search: %s", err)
+	}
+	if ids := userIds(result.Users); result.Count != 3 || len(ids) != 2 || ids[0] != 2 || ids[1] != 4 {
+		t.Fatalf("unexpected page %v of %d", ids, result.Count)
+	}
+}
+
+func TestSearchUsersByNameAndSurname(t *testing.T) {
+	next := &nextStub{}
+	users := newTestUsers(next, 0)
+
+	result, err := users.SearchUsers(context.Background(), 0, model.UserSearch{Name: "I", Surname: "Pet", Desc: true})
+	if err != nil {
+		t.Fatalf("search: %s", err)
+	}
+	if ids := userIds(result.Users); len(ids) != 2 || ids[0] != 4 || ids[1] != 1 {
+		t.Fatalf("unexpected users %v", ids)
+	}
+}
+
+func TestSearchUsersFallback(t *testing.T) {
+	next := &nextStub{}
+	users := newTestUsers(next, 2)
+	ctx := context.Background()
+
+	// Фильтр по городу индексом не обслуживается
+	if _, err := users.SearchUsers(ctx, 0, model.UserSearch{Name: "Iv", City: "Moscow"}); err != nil || next.calls != 1 {
+		t.Fatalf("expected fallback for filter, got %v after %d calls", err, next.calls)
+	}
+	// Совпадений больше MaxScan
+	if _, err := users.SearchUsers(ctx, 0, model.UserSearch{Name: "I"}); err != nil || next.calls != 2 {
+		t.Fatalf("expected fallback for large scan, got %v after %d calls", err, next.calls)
+	}
+}
@@ -31,6 +31,7 @@ func main() {
 	if err != nil {
 		logger.WithError(err).Fatal("Cannot access to database")
 	}
+	defer dbc.Close()
 	faker := gofakeit.New(0)
 	var userIds []int64
 